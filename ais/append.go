@@ -0,0 +1,202 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// POST /v1/objects/bucket-name/object-name?action=append
+//
+// Appends the request body to the object (local buckets only), creating the
+// object if it does not exist. The data is first received into a workfile
+// and then appended under the object's write lock, so that concurrent appends
+// are serialized and GETs never see a partially appended object.
+func (t *targetrunner) appendObject(w http.ResponseWriter, r *http.Request) {
+	started := time.Now()
+	apitems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objname := apitems[0], apitems[1]
+	if !t.validatebckname(w, r, bucket) {
+		return
+	}
+	if !t.verifyProxyRedirection(w, r, bucket, objname, cmn.ActAppend) {
+		return
+	}
+	if t.OOS() {
+		t.invalmsghdlr(w, r, "OOS")
+		return
+	}
+	lom := &cluster.LOM{T: t, Bucket: bucket, Objname: objname}
+	if errstr := lom.Fill(r.URL.Query().Get(cmn.URLParamBucketProvider), 0); errstr != "" {
+		t.invalmsghdlr(w, r, errstr)
+		return
+	}
+	if !lom.BckIsLocal {
+		t.invalmsghdlr(w, r, fmt.Sprintf("Append is supported only for local buckets (%s does not appear to be local)", bucket))
+		return
+	}
+	workFQN := lom.GenFQN(fs.WorkfileType, fs.WorkfileAppend)
+	size, err := t.recvAppend(r, workFQN)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	t.rtnamemap.Lock(lom.Uname, true)
+	errstr := t.doAppend(lom, workFQN)
+	t.rtnamemap.Unlock(lom.Uname, true)
+	if err := os.Remove(workFQN); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("Failed to remove %s, err: %v", workFQN, err)
+	}
+	if errstr != "" {
+		t.invalmsghdlr(w, r, errstr)
+		return
+	}
+	if err := t.ecmanager.EncodeObject(lom); err != nil && err != ec.ErrorECDisabled {
+		glog.Errorf("Failed to erasure-code %s, err: %v", lom, err)
+	}
+
+	hdr := w.Header()
+	if lom.Cksum != nil {
+		cksumType, cksumValue := lom.Cksum.Get()
+		hdr.Set(cmn.HeaderObjCksumType, cksumType)
+		hdr.Set(cmn.HeaderObjCksumVal, cksumValue)
+	}
+	if lom.Version != "" {
+		hdr.Set(cmn.HeaderObjVersion, lom.Version)
+	}
+	hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(lom.Size, 10))
+
+	t.statsif.Add(stats.AppendCount, 1)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("APPEND %s: %d bytes, new size %d, %d µs", lom, size, lom.Size, int64(time.Since(started)/time.Microsecond))
+	}
+	t.localMirror(lom) // the copy, if any, has been removed by doAppend
+}
+
+// recvAppend receives the data to append, validating its checksum if provided by the client
+func (t *targetrunner) recvAppend(r *http.Request, workFQN string) (size int64, err error) {
+	file, err := cmn.CreateFile(workFQN)
+	if err != nil {
+		t.fshc(err, workFQN)
+		return 0, fmt.Errorf("failed to create %s, err: %v", workFQN, err)
+	}
+	buf, slab := gmem2.AllocFromSlab2(0)
+	size, cksumValue, err := cmn.WriteWithHash(file, r.Body, buf)
+	slab.Free(buf)
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err != nil {
+		t.fshc(err, workFQN)
+		os.Remove(workFQN)
+		return 0, fmt.Errorf("failed to receive %s, err: %v", workFQN, err)
+	}
+	cksum := cmn.NewCksum(r.Header.Get(cmn.HeaderObjCksumType), r.Header.Get(cmn.HeaderObjCksumVal))
+	if cksum != nil {
+		if cksumType, _ := cksum.Get(); cksumType == cmn.ChecksumXXHash &&
+			!cmn.EqCksum(cksum, cmn.NewCksum(cmn.ChecksumXXHash, cksumValue)) {
+			os.Remove(workFQN)
+			t.statsif.AddMany(stats.NamedVal64{stats.ErrCksumCount, 1}, stats.NamedVal64{stats.ErrCksumSize, size})
+			return 0, fmt.Errorf("bad checksum of the appended data: expected %s, got %s", cksum, cksumValue)
+		}
+	}
+	return
+}
+
+// doAppend appends workFQN to the object and updates the object's metadata;
+// must be called under the object's write lock
+func (t *targetrunner) doAppend(lom *cluster.LOM, workFQN string) (errstr string) {
+	if errstr = lom.Fill("", cluster.LomFstat|cluster.LomCopy); errstr != "" {
+		return
+	}
+	existed, origSize := lom.Exists(), lom.Size
+	if existed && lom.HasCopy() {
+		if errstr = lom.DelCopy(); errstr != "" {
+			return
+		}
+	}
+	buf, slab := gmem2.AllocFromSlab2(0)
+	err := appendFile(lom.FQN, workFQN, origSize, buf)
+	slab.Free(buf)
+	if err != nil {
+		t.fshc(err, lom.FQN)
+		return fmt.Sprintf("Failed to append to %s, err: %v", lom, err)
+	}
+
+	finfo, err := os.Stat(lom.FQN)
+	if err != nil {
+		return fmt.Sprintf("Failed to fstat %s, err: %v", lom, err)
+	}
+	lom.Size = finfo.Size()
+	// xxhash cannot be resumed from the stored value - rehash the resulting object
+	file, err := os.Open(lom.FQN)
+	if err != nil {
+		return fmt.Sprintf("Failed to open %s, err: %v", lom, err)
+	}
+	buf, slab = gmem2.AllocFromSlab2(lom.Size)
+	cksumValue, errstr := cmn.ComputeXXHash(file, buf)
+	slab.Free(buf)
+	file.Close()
+	if errstr != "" {
+		return
+	}
+	lom.Cksum = cmn.NewCksum(cmn.ChecksumXXHash, cksumValue)
+	if versioningConfigured(true) {
+		if lom.Version, errstr = lom.IncObjectVersion(); errstr != "" {
+			return
+		}
+	}
+	lom.SetExists(true)
+	return lom.Persist()
+}
+
+// appendFile appends src to dst (creating dst if need be); on failure,
+// truncates dst back to its original size
+func appendFile(dst, src string, origSize int64, buf []byte) (err error) {
+	if err = cmn.CreateDir(filepath.Dir(dst)); err != nil {
+		return
+	}
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return
+	}
+	defer srcFile.Close()
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return
+	}
+	_, err = io.CopyBuffer(dstFile, srcFile, buf)
+	if err == nil {
+		err = dstFile.Close()
+	} else {
+		dstFile.Close()
+	}
+	if err != nil {
+		if errTrunc := os.Truncate(dst, origSize); errTrunc != nil {
+			err = errors.New(err.Error() + "; nested: " + errTrunc.Error())
+		}
+	}
+	return
+}
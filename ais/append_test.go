@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAppendFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "append")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		dst = filepath.Join(dir, "a", "b", "obj")
+		src = filepath.Join(dir, "src")
+		buf = make([]byte, 4096)
+	)
+	for i, record := range []string{"first\n", "second\n"} {
+		if err := ioutil.WriteFile(src, []byte(record), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var origSize int64
+		if i > 0 {
+			origSize = int64(len("first\n"))
+		}
+		if err := appendFile(dst, src, origSize, buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, err := ioutil.ReadFile(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "first\nsecond\n" {
+		t.Errorf("unexpected content %q", string(b))
+	}
+	if err := appendFile(dst, filepath.Join(dir, "nonexisting"), int64(len(b)), buf); err == nil {
+		t.Error("expected error appending a non-existing file")
+	}
+}
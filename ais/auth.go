@@ -26,14 +26,18 @@
 package ais
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
 	jwt "github.com/dgrijalva/jwt-go"
+	jsoniter "github.com/json-iterator/go"
 )
 
 // Declare a new type for Context field names
@@ -45,6 +49,18 @@ const (
 	ctxUserCreds contextID = "userCreds" // a field of a context that contains user credentials
 )
 
+const (
+	accessRead accessLevel = iota + 1
+	accessWrite
+	accessAdmin
+)
+
+// lifetime of the tokens that cluster members attach to their requests (see clusterToken)
+const (
+	clusterTokenLifetime = 24 * time.Hour
+	clusterTokenRenew    = time.Hour // renew the token when less than this is left
+)
+
 type (
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
@@ -57,10 +73,28 @@ type (
 		issued  time.Time
 		expires time.Time
 		creds   cmn.SimpleKVs
+		role    string        // cmn.AuthAdmin, cmn.AuthReadWrite, or cmn.AuthReadOnly
+		buckets cmn.SimpleKVs // per-bucket permissions that override the role
+	}
+
+	// access that a request requires: accessRead < accessWrite < accessAdmin
+	accessLevel int
+
+	// bucket (or, if empty, the cluster as a whole) and the access to it
+	bucketAccess struct {
+		bucket string
+		access accessLevel
 	}
 
 	authList map[string]*authRec
 
+	// token that the node attaches to the requests it sends to other nodes
+	clusterTokenCache struct {
+		sync.Mutex
+		token   string
+		expires time.Time
+	}
+
 	authManager struct {
 		sync.Mutex
 		// cache of decrypted tokens
@@ -111,6 +145,17 @@ func decryptToken(tokenStr string) (*authRec, error) {
 	if rec.expires, err = time.Parse(time.RFC822, expireStr); err != nil {
 		return nil, invalTokenErr
 	}
+	rec.role, _ = claims["role"].(string)
+	rec.buckets = make(cmn.SimpleKVs, 4)
+	if bb, ok := claims["buckets"].(map[string]interface{}); ok {
+		for bucket, value := range bb {
+			if perm, ok := value.(string); ok {
+				rec.buckets[bucket] = perm
+			} else {
+				glog.Warningf("Permission is not string: %v [%T]", value, value)
+			}
+		}
+	}
 	rec.creds = make(cmn.SimpleKVs, 10)
 	if cc, ok := claims["creds"].(map[string]interface{}); ok {
 		for key, value := range cc {
@@ -129,6 +174,122 @@ func decryptToken(tokenStr string) (*authRec, error) {
 	return rec, nil
 }
 
+// allowed returns true if the user has the required access to the bucket.
+// Tokens issued before the roles were introduced do not carry a role and are
+// treated as admin tokens - the access they had before
+func (rec *authRec) allowed(ba bucketAccess) bool {
+	if rec.role == cmn.AuthAdmin || rec.role == "" {
+		return true
+	}
+	if ba.access == accessAdmin {
+		return false
+	}
+	perm := rec.role
+	if p, ok := rec.buckets[ba.bucket]; ok && ba.bucket != "" {
+		perm = p
+	}
+	switch perm {
+	case cmn.AuthReadWrite:
+		return true
+	case cmn.AuthReadOnly:
+		return ba.access == accessRead
+	default:
+		return false
+	}
+}
+
+// requiredAccess returns the buckets that the request accesses along with the
+// required access, as per the HTTP method and, if present, the action message.
+// The message (if any) is read from the request body which is then restored
+func requiredAccess(r *http.Request) (required []bucketAccess, err error) {
+	items, err := cmn.MatchRESTItems(r.URL.Path, 1, true, cmn.Version)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		bucket string
+		objReq = len(items) > 2 // object (or S3 object) request
+		access = accessWrite
+	)
+	if len(items) > 1 && items[1] != "*" {
+		bucket = items[1]
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		if items[0] == cmn.Cluster || items[0] == cmn.Daemon {
+			// config includes the secret that signs the tokens
+			switch r.URL.Query().Get(cmn.URLParamWhat) {
+			case cmn.GetWhatConfig, cmn.GetWhatSmap, cmn.GetWhatBucketMeta:
+				return []bucketAccess{{"", accessAdmin}}, nil
+			}
+		}
+		return []bucketAccess{{bucket, accessRead}}, nil
+	}
+	switch items[0] {
+	case cmn.Cluster, cmn.Daemon, cmn.Download, cmn.Sort:
+		return []bucketAccess{{"", accessAdmin}}, nil
+	case cmn.Objects:
+		return []bucketAccess{{bucket, accessWrite}}, nil
+	case cmn.S3:
+		if !objReq { // create or destroy bucket
+			access = accessAdmin
+		}
+		return []bucketAccess{{bucket, access}}, nil
+	}
+
+	// /v1/buckets: depends on the action
+	var (
+		msg cmn.ActionMsg
+		b   []byte
+	)
+	if r.Body != nil {
+		if b, err = ioutil.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+	if len(b) == 0 || jsoniter.Unmarshal(b, &msg) != nil {
+		return []bucketAccess{{bucket, accessAdmin}}, nil // let the handler reject it
+	}
+	switch msg.Action {
+	case cmn.ActListObjects:
+		access = accessRead
+	case cmn.ActPrefetch, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActCopyBucketAbort:
+		access = accessWrite
+	case cmn.ActCopyBucket:
+		return []bucketAccess{{bucket, accessRead}, {msg.Name, accessWrite}}, nil
+	default: // createlb, destroylb, renamelb, setprops, evictcb, erasecopies, etc.
+		access = accessAdmin
+	}
+	return []bucketAccess{{bucket, access}}, nil
+}
+
+// clusterToken returns a token that the node attaches to the control plane
+// requests it sends to other nodes, so that the proxies do not reject them
+// when authentication is enabled. The token is signed with the same secret
+// as the tokens issued by AuthN and grants the admin role
+func (h *httprunner) clusterToken() (string, error) {
+	h.ctoken.Lock()
+	defer h.ctoken.Unlock()
+	now := time.Now()
+	if h.ctoken.token != "" && h.ctoken.expires.Sub(now) > clusterTokenRenew {
+		return h.ctoken.token, nil
+	}
+	expires := now.Add(clusterTokenLifetime)
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"issued":   now.Format(time.RFC822),
+		"expires":  expires.Format(time.RFC822),
+		"username": h.si.DaemonID,
+		"role":     cmn.AuthAdmin,
+	})
+	token, err := t.SignedString([]byte(cmn.GCO.Get().Auth.Secret))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate cluster token: %v", err)
+	}
+	h.ctoken.token, h.ctoken.expires = token, expires
+	return token, nil
+}
+
 // Retreives a string from context field or empty string if nothing found or
 //   the field is not of string type
 //lint:ignore U1000 unused
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+func TestAuthDecryptPermissions(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Secret = "secret"
+	cmn.GCO.CommitUpdate(config)
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"issued":   now.Format(time.RFC822),
+		"expires":  now.Add(time.Hour).Format(time.RFC822),
+		"username": "user",
+		"role":     cmn.AuthReadOnly,
+		"buckets":  map[string]string{"bck": cmn.AuthReadWrite},
+	})
+	tokenStr, err := token.SignedString([]byte(config.Auth.Secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, err := decryptToken(tokenStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.role != cmn.AuthReadOnly || rec.buckets["bck"] != cmn.AuthReadWrite {
+		t.Errorf("unexpected role %q and permissions %v", rec.role, rec.buckets)
+	}
+}
+
+func TestAuthAllowed(t *testing.T) {
+	var (
+		admin  = &authRec{role: cmn.AuthAdmin}
+		rw     = &authRec{role: cmn.AuthReadWrite, buckets: cmn.SimpleKVs{"ro": cmn.AuthReadOnly, "none": cmn.AuthNoAccess}}
+		ro     = &authRec{role: cmn.AuthReadOnly, buckets: cmn.SimpleKVs{"rw": cmn.AuthReadWrite}}
+		legacy = &authRec{}
+	)
+	tests := []struct {
+		rec     *authRec
+		ba      bucketAccess
+		allowed bool
+	}{
+		{admin, bucketAccess{"bck", accessAdmin}, true},
+		{admin, bucketAccess{"", accessAdmin}, true},
+		{rw, bucketAccess{"bck", accessWrite}, true},
+		{rw, bucketAccess{"bck", accessAdmin}, false},
+		{rw, bucketAccess{"ro", accessRead}, true},
+		{rw, bucketAccess{"ro", accessWrite}, false},
+		{rw, bucketAccess{"none", accessRead}, false},
+		{ro, bucketAccess{"bck", accessRead}, true},
+		{ro, bucketAccess{"bck", accessWrite}, false},
+		{ro, bucketAccess{"rw", accessWrite}, true},
+		{ro, bucketAccess{"", accessWrite}, false},
+		{legacy, bucketAccess{"bck", accessWrite}, true},
+		{legacy, bucketAccess{"", accessAdmin}, true},
+	}
+	for _, test := range tests {
+		if allowed := test.rec.allowed(test.ba); allowed != test.allowed {
+			t.Errorf("role %q, %+v: expected allowed=%t", test.rec.role, test.ba, test.allowed)
+		}
+	}
+}
+
+func TestAuthClusterToken(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Secret = "secret"
+	cmn.GCO.CommitUpdate(config)
+
+	h := &httprunner{si: &cluster.Snode{DaemonID: "proxy-1"}}
+	token, err := h.clusterToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, _ := h.clusterToken(); cached != token {
+		t.Error("expected the cluster token to be reused")
+	}
+	rec, err := decryptToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.userID != "proxy-1" || !rec.allowed(bucketAccess{"", accessAdmin}) {
+		t.Errorf("unexpected cluster token user %q, role %q", rec.userID, rec.role)
+	}
+}
+
+func TestAuthRequiredAccess(t *testing.T) {
+	tests := []struct {
+		method, path, body string
+		required           []bucketAccess
+	}{
+		{http.MethodGet, "/v1/objects/bck/obj", "", []bucketAccess{{"bck", accessRead}}},
+		{http.MethodHead, "/v1/buckets/bck", "", []bucketAccess{{"bck", accessRead}}},
+		{http.MethodGet, "/v1/buckets/*", "", []bucketAccess{{"", accessRead}}},
+		{http.MethodPut, "/v1/objects/bck/obj", "data", []bucketAccess{{"bck", accessWrite}}},
+		{http.MethodDelete, "/v1/objects/bck/obj", "", []bucketAccess{{"bck", accessWrite}}},
+		{http.MethodPut, "/v1/s3/bck", "", []bucketAccess{{"bck", accessAdmin}}},
+		{http.MethodPut, "/v1/s3/bck/obj", "data", []bucketAccess{{"bck", accessWrite}}},
+		{http.MethodPost, "/v1/buckets/bck", `{"action": "listobjects"}`, []bucketAccess{{"bck", accessRead}}},
+		{http.MethodDelete, "/v1/buckets/bck", `{"action": "delete"}`, []bucketAccess{{"bck", accessWrite}}},
+		{http.MethodDelete, "/v1/buckets/bck", `{"action": "destroylb"}`, []bucketAccess{{"bck", accessAdmin}}},
+		{http.MethodPut, "/v1/buckets/bck", `{"action": "setprops"}`, []bucketAccess{{"bck", accessAdmin}}},
+		{http.MethodPost, "/v1/buckets/bck", `{"action": "copybucket", "name": "dst"}`,
+			[]bucketAccess{{"bck", accessRead}, {"dst", accessWrite}}},
+		{http.MethodPost, "/v1/buckets/bck", "", []bucketAccess{{"bck", accessAdmin}}},
+		{http.MethodGet, "/v1/cluster", "", []bucketAccess{{"", accessRead}}},
+		{http.MethodGet, "/v1/cluster?what=stats", "", []bucketAccess{{"", accessRead}}},
+		{http.MethodGet, "/v1/cluster?what=config", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodGet, "/v1/daemon?what=config", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodGet, "/v1/daemon?what=smap", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodGet, "/v1/daemon?what=bucketmd", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodPut, "/v1/cluster", `{"action": "setconfig"}`, []bucketAccess{{"", accessAdmin}}},
+		{http.MethodPut, "/v1/daemon", `{"action": "shutdown"}`, []bucketAccess{{"", accessAdmin}}},
+		{http.MethodDelete, "/v1/cluster/daemon/target-1", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodPost, "/v1/download", "", []bucketAccess{{"", accessAdmin}}},
+		{http.MethodPost, "/v1/sort/start", "{}", []bucketAccess{{"", accessAdmin}}},
+	}
+	for _, test := range tests {
+		r, err := http.NewRequest(test.method, "http://localhost:8080"+test.path, strings.NewReader(test.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		required, err := requiredAccess(r)
+		if err != nil {
+			t.Errorf("%s %s: %v", test.method, test.path, err)
+			continue
+		}
+		if len(required) != len(test.required) {
+			t.Errorf("%s %s %s: expected %v, got %v", test.method, test.path, test.body, test.required, required)
+			continue
+		}
+		for i := range required {
+			if required[i] != test.required[i] {
+				t.Errorf("%s %s %s: expected %v, got %v", test.method, test.path, test.body, test.required, required)
+			}
+		}
+		// the handler must still be able to read the message
+		if b, _ := ioutil.ReadAll(r.Body); string(b) != test.body {
+			t.Errorf("%s %s: request body not restored: %q", test.method, test.path, b)
+		}
+	}
+}
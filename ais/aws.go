@@ -159,6 +159,22 @@ func awsIsVersionSet(version *string) bool {
 	return version != nil && *version != "null" && *version != ""
 }
 
+// awsUserMeta returns user-defined metadata stored in S3 object metadata (minus the
+// AIS checksum); S3 returns the keys in canonical (header) form - hence ToLower
+func awsUserMeta(md map[string]*string) (meta cmn.SimpleKVs) {
+	for k, v := range md {
+		k = strings.ToLower(k)
+		if v == nil || k == awsChecksumType || k == awsChecksumVal || !cmn.ValidUserMeta(k, *v) {
+			continue
+		}
+		if meta == nil {
+			meta = make(cmn.SimpleKVs, len(md))
+		}
+		meta[k] = *v
+	}
+	return
+}
+
 //==================
 //
 // bucket operations
@@ -339,6 +355,9 @@ func (awsimpl *awsimpl) headobject(ct context.Context, bucket string, objname st
 	if awsIsVersionSet(headOutput.VersionId) {
 		objmeta[cmn.HeaderObjVersion] = *headOutput.VersionId
 	}
+	for k, v := range awsUserMeta(headOutput.Metadata) {
+		objmeta[cmn.HeaderObjMetaPrefix+k] = v
+	}
 	return
 }
 
@@ -377,7 +396,7 @@ func (awsimpl *awsimpl) getobj(ct context.Context, workFQN, bucket, objname stri
 		cksumToCheck = cmn.NewCksum(cmn.ChecksumMD5, md5)
 	}
 
-	lom = &cluster.LOM{T: awsimpl.t, Bucket: bucket, Objname: objname, Cksum: cksum}
+	lom = &cluster.LOM{T: awsimpl.t, Bucket: bucket, Objname: objname, Cksum: cksum, UserMeta: awsUserMeta(obj.Metadata)}
 	if obj.VersionId != nil {
 		lom.Version = *obj.VersionId
 	}
@@ -402,14 +421,17 @@ func (awsimpl *awsimpl) getobj(ct context.Context, workFQN, bucket, objname stri
 	return
 }
 
-func (awsimpl *awsimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider) (version string, errstr string, errcode int) {
+func (awsimpl *awsimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int) {
 	var (
 		err          error
 		uploadoutput *s3manager.UploadOutput
 	)
 
 	cksumType, cksumValue := cksum.Get()
-	md := make(map[string]*string)
+	md := make(map[string]*string, len(userMeta)+2)
+	for k, v := range userMeta {
+		md[k] = aws.String(v)
+	}
 	md[awsChecksumType] = aws.String(cksumType)
 	md[awsChecksumVal] = aws.String(cksumValue)
 
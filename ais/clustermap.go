@@ -277,7 +277,8 @@ func (r *smapowner) persist(newsmap *smapX, saveSmap bool) (errstr string) {
 // new cluster.Snode
 //
 //=====================================================================
-func newSnode(id, proto string, publicAddr, intraControlAddr, intraDataAddr *net.TCPAddr) (snode *cluster.Snode) {
+// intraProto differs from proto (public network) when the intra-cluster networks use mTLS
+func newSnode(id, proto, intraProto string, publicAddr, intraControlAddr, intraDataAddr *net.TCPAddr) (snode *cluster.Snode) {
 	publicNet := cluster.NetInfo{
 		NodeIPAddr: publicAddr.IP.String(),
 		DaemonPort: strconv.Itoa(publicAddr.Port),
@@ -288,7 +289,7 @@ func newSnode(id, proto string, publicAddr, intraControlAddr, intraDataAddr *net
 		intraControlNet = cluster.NetInfo{
 			NodeIPAddr: intraControlAddr.IP.String(),
 			DaemonPort: strconv.Itoa(intraControlAddr.Port),
-			DirectURL:  proto + "://" + intraControlAddr.String(),
+			DirectURL:  intraProto + "://" + intraControlAddr.String(),
 		}
 	}
 	intraDataNet := publicNet
@@ -296,7 +297,7 @@ func newSnode(id, proto string, publicAddr, intraControlAddr, intraDataAddr *net
 		intraDataNet = cluster.NetInfo{
 			NodeIPAddr: intraDataAddr.IP.String(),
 			DaemonPort: strconv.Itoa(intraDataAddr.Port),
-			DirectURL:  proto + "://" + intraDataAddr.String(),
+			DirectURL:  intraProto + "://" + intraDataAddr.String(),
 		}
 	}
 	snode = &cluster.Snode{DaemonID: id, PublicNet: publicNet, IntraControlNet: intraControlNet, IntraDataNet: intraDataNet}
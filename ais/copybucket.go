@@ -0,0 +1,307 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/transport"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Copying a bucket is a cluster-wide xaction: each target copies the objects
+// it stores - or, in case of a Cloud bucket, the objects it is the HRW owner of -
+// to the targets that own the respective objects of the destination (local) bucket.
+// The objects are sent via the rebalance streams and received by recvRebalanceObj.
+
+type copyBucketCtx struct {
+	t              *targetrunner
+	xcopy          *xactCopyBucket
+	bucketFrom     string
+	bucketProvider string
+	prefix         string
+	regex          *regexp.Regexp
+}
+
+var errCopyBucketAborted = errors.New("copy bucket aborted")
+
+func (xcopy *xactCopyBucket) details() stats.CopyBucketXactDetails {
+	return stats.CopyBucketXactDetails{
+		XactionDetails: xactDetails(xcopy),
+		BucketTo:       xcopy.bucketTo,
+		NumCopiedFiles: xcopy.ObjCount(),
+		NumCopiedBytes: xcopy.BytesCount(),
+		NumErrors:      xcopy.ErrCount(),
+	}
+}
+
+func (t *targetrunner) getCopyBucketStats() []byte {
+	st := stats.CopyBucketTargetStats{Xactions: []stats.CopyBucketXactDetails{}}
+	for _, xx := range t.xactions.selectL(cmn.ActCopyBucket) {
+		st.Xactions = append(st.Xactions, xx.(*xactCopyBucket).details())
+	}
+	jsbytes, err := jsoniter.Marshal(st)
+	cmn.AssertNoErr(err)
+	return jsbytes
+}
+
+// startCopyBucket validates the request and starts copying in the background
+func (t *targetrunner) startCopyBucket(ct context.Context, bucketFrom, bucketTo, bucketProvider string,
+	msgInt *actionMsgInternal) (errstr string) {
+	copyMsg := &cmn.CopyBucketMsg{}
+	if msgInt.Value != nil {
+		b, err := jsoniter.Marshal(msgInt.Value)
+		if err == nil {
+			err = jsoniter.Unmarshal(b, copyMsg)
+		}
+		if err != nil {
+			return fmt.Sprintf("Failed to unmarshal %s message, err: %v", msgInt.Action, err)
+		}
+	}
+	bucketmd := t.bmdowner.get()
+	if !bucketmd.IsLocal(bucketTo) {
+		return fmt.Sprintf("Local bucket %s %s", bucketTo, cmn.DoesNotExist)
+	}
+	bckIsLocal, errstr := t.validateBucketProvider(bucketProvider, bucketFrom)
+	if errstr != "" {
+		return
+	}
+	cbctx := &copyBucketCtx{t: t, bucketFrom: bucketFrom, bucketProvider: bucketProvider, prefix: copyMsg.Prefix}
+	if copyMsg.Regex != "" {
+		var err error
+		if cbctx.regex, err = regexp.Compile(copyMsg.Regex); err != nil {
+			return fmt.Sprintf("Invalid regex %q, err: %v", copyMsg.Regex, err)
+		}
+	}
+	if cbctx.xcopy = t.xactions.renewCopyBucket(bucketFrom, bucketTo); cbctx.xcopy == nil {
+		return fmt.Sprintf("Bucket %s is already being copied", bucketFrom)
+	}
+	go cbctx.run(ct, bckIsLocal)
+	return
+}
+
+func (cbctx *copyBucketCtx) run(ct context.Context, bckIsLocal bool) {
+	var (
+		xcopy = cbctx.xcopy
+		err   error
+	)
+	glog.Infof("%s: copying %s => %s", xcopy, cbctx.bucketFrom, xcopy.bucketTo)
+	if bckIsLocal {
+		err = cbctx.copyLocal()
+	} else {
+		err = cbctx.copyCloud(ct)
+	}
+	if err != nil && err != errCopyBucketAborted {
+		glog.Errorf("%s: failed to copy %s => %s, err: %v", xcopy, cbctx.bucketFrom, xcopy.bucketTo, err)
+	}
+	if !xcopy.Finished() {
+		xcopy.EndTime(time.Now())
+	}
+	glog.Infof("%s: copied %d objects (%d bytes), %d errors", xcopy,
+		xcopy.ObjCount(), xcopy.BytesCount(), xcopy.ErrCount())
+}
+
+// copyLocal copies the objects stored on this target, one goroutine per mountpath
+func (cbctx *copyBucketCtx) copyLocal() error {
+	var (
+		availablePaths, _ = fs.Mountpaths.Get()
+		errCh             = make(chan error, len(availablePaths))
+		wg                = &sync.WaitGroup{}
+	)
+	for _, mpathInfo := range availablePaths {
+		wg.Add(1)
+		go func(dir string) {
+			if err := filepath.Walk(dir, cbctx.walkf); err != nil {
+				errCh <- err
+			}
+			wg.Done()
+		}(mpathInfo.MakePathBucket(fs.ObjectType, cbctx.bucketFrom, true /*bucket is local*/))
+	}
+	wg.Wait()
+	close(errCh)
+	return <-errCh
+}
+
+func (cbctx *copyBucketCtx) walkf(fqn string, osfi os.FileInfo, err error) error {
+	if err != nil {
+		if errstr := cmn.PathWalkErr(err); errstr != "" {
+			glog.Error(errstr)
+			return err
+		}
+		return nil
+	}
+	if cbctx.xcopy.Aborted() {
+		return errCopyBucketAborted
+	}
+	if osfi.Mode().IsDir() {
+		return nil
+	}
+	lom := &cluster.LOM{T: cbctx.t, FQN: fqn}
+	if errstr := lom.Fill("", cluster.LomFstat|cluster.LomCopy); errstr != "" {
+		glog.Errorln(errstr)
+		cbctx.xcopy.ErrorsAdd(1)
+		return nil
+	}
+	if !lom.Exists() || lom.IsCopy() || !cbctx.match(lom.Objname) {
+		return nil
+	}
+	cbctx.copyObj(lom)
+	return nil
+}
+
+// copyCloud lists the Cloud bucket and copies the objects this target is
+// the HRW owner of, performing a cold GET of the ones that are not cached
+func (cbctx *copyBucketCtx) copyCloud(ct context.Context) error {
+	var (
+		t   = cbctx.t
+		msg = &cmn.GetMsg{GetPrefix: cbctx.prefix}
+	)
+	for {
+		jsbytes, errstr, _ := getcloudif().listbucket(ct, cbctx.bucketFrom, msg)
+		if errstr != "" {
+			return errors.New(errstr)
+		}
+		page := &cmn.BucketList{}
+		if err := jsoniter.Unmarshal(jsbytes, page); err != nil {
+			return err
+		}
+		smap := t.smapowner.get()
+		for _, entry := range page.Entries {
+			if cbctx.xcopy.Aborted() {
+				return errCopyBucketAborted
+			}
+			if !cbctx.match(entry.Name) {
+				continue
+			}
+			si, errstr := hrwTarget(cbctx.bucketFrom, entry.Name, smap)
+			if errstr != "" {
+				return errors.New(errstr)
+			}
+			if si.DaemonID != t.si.DaemonID {
+				continue
+			}
+			t.prefetchMissing(ct, entry.Name, cbctx.bucketFrom, cbctx.bucketProvider)
+			lom := &cluster.LOM{T: t, Bucket: cbctx.bucketFrom, Objname: entry.Name}
+			if errstr := lom.Fill(cbctx.bucketProvider, cluster.LomFstat); errstr != "" || !lom.Exists() {
+				glog.Errorf("%s: failed to cold GET %s, err: %s", cbctx.xcopy, lom, errstr)
+				cbctx.xcopy.ErrorsAdd(1)
+				continue
+			}
+			cbctx.copyObj(lom)
+		}
+		if page.PageMarker == "" {
+			return nil
+		}
+		msg.GetPageMarker = page.PageMarker
+	}
+}
+
+func (cbctx *copyBucketCtx) match(objname string) bool {
+	if !strings.HasPrefix(objname, cbctx.prefix) {
+		return false
+	}
+	return cbctx.regex == nil || cbctx.regex.MatchString(objname)
+}
+
+func (cbctx *copyBucketCtx) copyObj(lom *cluster.LOM) {
+	xcopy := cbctx.xcopy
+	if errstr := cbctx.t.copyBucketObject(lom, xcopy.bucketTo); errstr != "" {
+		glog.Errorf("%s: %s", xcopy, errstr)
+		xcopy.ErrorsAdd(1)
+		return
+	}
+	xcopy.ObjectsAdd(1)
+	xcopy.BytesAdd(lom.Size)
+}
+
+// copyBucketObject copies the object to the same name in the (local) bucketTo,
+// either locally or to the target that owns the destination object
+func (t *targetrunner) copyBucketObject(lom *cluster.LOM, bucketTo string) (errstr string) {
+	si, errstr := hrwTarget(bucketTo, lom.Objname, t.smapowner.get())
+	if errstr != "" {
+		return
+	}
+	t.rtnamemap.Lock(lom.Uname, false)
+	defer t.rtnamemap.Unlock(lom.Uname, false)
+
+	if errstr = lom.Fill("", cluster.LomFstat|cluster.LomVersion|cluster.LomAtime|cluster.LomCksum|
+		cluster.LomCksumMissingRecomp|cluster.LomUserMeta); errstr != "" {
+		return
+	}
+	if !lom.Exists() {
+		return fmt.Sprintf("%s %s", lom, cmn.DoesNotExist)
+	}
+	file, err := cmn.NewFileHandle(lom.FQN)
+	if err != nil {
+		return fmt.Sprintf("failed to open %s, err: %v", lom.FQN, err)
+	}
+	defer file.Close() // in case the receiver (roi or stream) does not close it
+
+	if si.DaemonID == t.si.DaemonID {
+		roi := &recvObjInfo{
+			t:            t,
+			objname:      lom.Objname,
+			bucket:       bucketTo,
+			migrated:     true,
+			r:            file,
+			cksumToCheck: lom.Cksum,
+		}
+		if err := roi.init(); err != nil {
+			return err.Error()
+		}
+		roi.lom.Atime = lom.Atime
+		roi.lom.Version = lom.Version
+		roi.lom.UserMeta = lom.UserMeta
+		if err, _ := roi.recv(); err != nil {
+			return err.Error()
+		}
+		return
+	}
+
+	cksumType, cksumValue := lom.Cksum.Get()
+	hdr := transport.Header{
+		Bucket:  bucketTo,
+		Objname: lom.Objname,
+		IsLocal: true,
+		Opaque:  []byte(t.si.DaemonID),
+		ObjAttrs: transport.ObjectAttrs{
+			Size:       lom.Size,
+			Atime:      lom.Atime.UnixNano(),
+			CksumType:  cksumType,
+			CksumValue: cksumValue,
+			Version:    lom.Version,
+			UserMeta:   cmn.EncodeUserMeta(lom.UserMeta),
+		},
+	}
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	cb := func(hdr transport.Header, r io.ReadCloser, cbErr error) {
+		err = cbErr
+		wg.Done()
+	}
+	if err := t.streams.rebalance.SendV(hdr, file, cb, si); err != nil {
+		return fmt.Sprintf("failed to send %s to %s, err: %v", lom, tname(si), err)
+	}
+	wg.Wait()
+	if err != nil {
+		return fmt.Sprintf("failed to send %s to %s, err: %v", lom, tname(si), err)
+	}
+	t.statsif.AddMany(stats.NamedVal64{stats.TxCount, 1}, stats.NamedVal64{stats.TxSize, lom.Size})
+	return
+}
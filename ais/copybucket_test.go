@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestCopyBucketMatch(t *testing.T) {
+	cbctx := &copyBucketCtx{prefix: "train/", regex: regexp.MustCompile(`\.tar$`)}
+	tests := []struct {
+		objname string
+		match   bool
+	}{
+		{"train/shard-1.tar", true},
+		{"train/shard-1.tgz", false},
+		{"test/shard-1.tar", false},
+	}
+	for _, test := range tests {
+		if match := cbctx.match(test.objname); match != test.match {
+			t.Errorf("%s: expected match=%t", test.objname, test.match)
+		}
+	}
+	if cbctx := (&copyBucketCtx{}); !cbctx.match("any") {
+		t.Error("expected everything to match when there are no filters")
+	}
+}
+
+func TestCopyBucketXaction(t *testing.T) {
+	xs := newXs()
+	xcopy := xs.renewCopyBucket("src", "dst")
+	if xcopy == nil {
+		t.Fatal("failed to start copying")
+	}
+	if xcopy.Kind() != path.Join(cmn.ActCopyBucket, "src") || xcopy.Bucket() != "src" {
+		t.Errorf("unexpected %s (bucket %s)", xcopy, xcopy.Bucket())
+	}
+	if xs.renewCopyBucket("src", "other") != nil {
+		t.Error("expected the second copying of the same bucket to fail")
+	}
+	if xs.abortCopyBucket("dst") {
+		t.Error("expected no copying of the destination bucket")
+	}
+	if !xs.abortCopyBucket("src") {
+		t.Error("expected copying to be aborted")
+	}
+	details := xcopy.details()
+	if details.Status != cmn.XactionStatusAborted || details.BucketTo != "dst" {
+		t.Errorf("unexpected details of the aborted copying: %+v", details)
+	}
+	if xs.renewCopyBucket("src", "other") == nil {
+		t.Error("expected copying to restart after abort")
+	}
+}
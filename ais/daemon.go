@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	jsoniter "github.com/json-iterator/go"
 )
@@ -39,6 +40,7 @@ const (
 	xmetasyncer      = "metasyncer"
 	xfshc            = "fshc"
 	xreadahead       = "readahead"
+	xtracer          = "tracer"
 	//lint:ignore U1000 unused
 	xreplication = "replication" // TODO: fix replication
 )
@@ -216,6 +218,7 @@ func aisinit(version, build string) {
 
 		ctx.rg.add(newProxyKeepaliveRunner(p), xproxykeepalive)
 		ctx.rg.add(newmetasyncer(p), xmetasyncer)
+		initTracer(p.si.DaemonID)
 	} else {
 		t := &targetrunner{}
 		t.initSI()
@@ -227,6 +230,7 @@ func aisinit(version, build string) {
 		ts.Core.StatsdC = &t.statsdC
 
 		ctx.rg.add(newTargetKeepaliveRunner(t), xtargetkeepalive)
+		initTracer(t.si.DaemonID)
 
 		// iostat is required: ensure that it is installed and its version is right
 		if err := ios.CheckIostatVersion(); err != nil {
@@ -294,6 +298,16 @@ func aisinit(version, build string) {
 	ctx.rg.add(&sigrunner{}, xsignal)
 }
 
+func initTracer(daemonID string) {
+	tracer, err := tracing.Init(daemonID, cmn.GCO.Get())
+	if err != nil {
+		glog.Fatalf("Failed to initialize tracing, err: %v", err)
+	}
+	if tracer != nil {
+		ctx.rg.add(tracer, xtracer)
+	}
+}
+
 // Run is the 'main' where everything gets started
 func Run(version, build string) {
 	aisinit(version, build)
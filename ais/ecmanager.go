@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 )
 
@@ -131,7 +132,7 @@ func (mgr *ecManager) EncodeObject(lom *cluster.LOM) error {
 	if mgr.xact == nil || mgr.xact.Finished() {
 		mgr.xact = mgr.t.xactions.renewEC()
 	}
-	if errstr := lom.Fill("", cluster.LomAtime|cluster.LomVersion|cluster.LomCksum); errstr != "" {
+	if errstr := lom.Fill("", cluster.LomAtime|cluster.LomVersion|cluster.LomCksum|cluster.LomUserMeta); errstr != "" {
 		return errors.New(errstr)
 	}
 	mgr.xact.Encode(req)
@@ -159,7 +160,7 @@ func (mgr *ecManager) CleanupObject(lom *cluster.LOM) {
 	mgr.xact.Cleanup(req)
 }
 
-func (mgr *ecManager) RestoreObject(lom *cluster.LOM) error {
+func (mgr *ecManager) RestoreObject(lom *cluster.LOM, span *tracing.Span) error {
 	if lom.Bprops == nil || !lom.Bprops.ECEnabled {
 		return ec.ErrorECDisabled
 	}
@@ -169,6 +170,7 @@ func (mgr *ecManager) RestoreObject(lom *cluster.LOM) error {
 		Action: ec.ActRestore,
 		LOM:    lom,
 		ErrCh:  make(chan error), // unbuffered
+		Span:   span,
 	}
 	if mgr.xact == nil || mgr.xact.Finished() {
 		mgr.xact = mgr.t.xactions.renewEC()
@@ -81,6 +81,21 @@ func gcpErrorToHTTP(gcpError error) int {
 	return http.StatusInternalServerError
 }
 
+// gcpUserMeta returns user-defined metadata stored in GCS object metadata (minus the AIS checksum)
+func gcpUserMeta(md map[string]string) (meta cmn.SimpleKVs) {
+	for k, v := range md {
+		k = strings.ToLower(k)
+		if k == gcpChecksumType || k == gcpChecksumVal || !cmn.ValidUserMeta(k, v) {
+			continue
+		}
+		if meta == nil {
+			meta = make(cmn.SimpleKVs, len(md))
+		}
+		meta[k] = v
+	}
+	return
+}
+
 // If extractGCPCreds returns no error and gcpCreds is nil then the default
 //   GCP client is used (that loads credentials from dir ~/.config/gcloud/ -
 //   the directory is created after the first successful login with gsutil)
@@ -333,6 +348,9 @@ func (gcpimpl *gcpimpl) headobject(ct context.Context, bucket string, objname st
 	}
 	objmeta[cmn.HeaderCloudProvider] = cmn.ProviderGoogle
 	objmeta[cmn.HeaderObjVersion] = fmt.Sprintf("%d", attrs.Generation)
+	for k, v := range gcpUserMeta(attrs.Metadata) {
+		objmeta[cmn.HeaderObjMetaPrefix+k] = v
+	}
 	return
 }
 
@@ -363,7 +381,8 @@ func (gcpimpl *gcpimpl) getobj(ct context.Context, workFQN string, bucket string
 		return
 	}
 	// hashtype and hash could be empty for legacy objects.
-	lom = &cluster.LOM{T: gcpimpl.t, Bucket: bucket, Objname: objname, Cksum: cksum, Version: strconv.FormatInt(attrs.Generation, 10)}
+	lom = &cluster.LOM{T: gcpimpl.t, Bucket: bucket, Objname: objname, Cksum: cksum, Version: strconv.FormatInt(attrs.Generation, 10),
+		UserMeta: gcpUserMeta(attrs.Metadata)}
 	if errstr = lom.Fill(cmn.CloudBs, 0); errstr != "" {
 		return
 	}
@@ -386,13 +405,16 @@ func (gcpimpl *gcpimpl) getobj(ct context.Context, workFQN string, bucket string
 	return
 }
 
-func (gcpimpl *gcpimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider) (version string, errstr string, errcode int) {
+func (gcpimpl *gcpimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int) {
 	gcpclient, gctx, _, errstr := createClient(ct)
 	if errstr != "" {
 		return
 	}
 
-	md := make(cmn.SimpleKVs)
+	md := make(cmn.SimpleKVs, len(userMeta)+2)
+	for k, v := range userMeta {
+		md[k] = v
+	}
 	md[gcpChecksumType], md[gcpChecksumVal] = cksum.Get()
 
 	gcpObj := gcpclient.Bucket(bucket).Object(objname)
@@ -0,0 +1,241 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+//======
+//
+// implements cloudif
+//
+//======
+// httpcloudimpl is a read-only Cloud where each cloud bucket is bound to the
+// base URL of an HTTP(S) server (cmn.BucketProps.OriginURL): GET bucket/object
+// executes a cold GET of OriginURL/object and caches the result. The object's
+// version is its ETag or, if the server does not provide one, its Last-Modified.
+type (
+	httpcloudimpl struct {
+		t          *targetrunner
+		httpclient *http.Client
+	}
+)
+
+var (
+	_ cloudif = &httpcloudimpl{}
+)
+
+func newHTTPProvider(t *targetrunner) *httpcloudimpl {
+	config := cmn.GCO.Get()
+	return &httpcloudimpl{
+		t: t,
+		// verifies the origins' certificates (the scheme is determined by the origin URL);
+		// no overall timeout - large objects may take a while to download
+		httpclient: cmn.NewClient(cmn.ClientArgs{
+			DialTimeout:           config.Timeout.Default,
+			ResponseHeaderTimeout: config.Timeout.DefaultLong,
+		}),
+	}
+}
+
+// originURL returns the URL of a given object (or of the bucket itself if objname is empty);
+// each path segment of the object name is escaped so that names containing '?', '#', '%'
+// and such map onto the origin's paths rather than its queries and fragments
+func (hc *httpcloudimpl) originURL(bucket, objname string) (u, errstr string, errcode int) {
+	props, ok := hc.t.bmdowner.get().Get(bucket, false)
+	if !ok || props.OriginURL == "" {
+		errstr = fmt.Sprintf("Cloud bucket %s is not bound to an origin URL (bucket property %q)", bucket, cmn.HeaderOriginURL)
+		errcode = http.StatusNotFound
+		return
+	}
+	u = strings.TrimSuffix(props.OriginURL, "/")
+	if objname != "" {
+		segments := strings.Split(strings.TrimPrefix(objname, "/"), "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		u += "/" + strings.Join(segments, "/")
+	}
+	return
+}
+
+func httpcloudVersion(hdr http.Header) string {
+	if etag := hdr.Get("ETag"); etag != "" {
+		etag = strings.TrimPrefix(etag, "W/")
+		if unquoted, err := strconv.Unquote(etag); err == nil {
+			return unquoted
+		}
+		return etag
+	}
+	return hdr.Get("Last-Modified")
+}
+
+// returns MD5 (hex) if provided by the origin via Content-MD5 (base64, RFC 1864)
+func httpcloudCksum(hdr http.Header) cmn.CksumProvider {
+	b64 := hdr.Get("Content-MD5")
+	if b64 == "" {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil
+	}
+	return cmn.NewCksum(cmn.ChecksumMD5, hex.EncodeToString(b))
+}
+
+func (hc *httpcloudimpl) do(ct context.Context, method, u string) (resp *http.Response, errstr string, errcode int) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err.Error(), http.StatusBadRequest
+	}
+	resp, err = hc.httpclient.Do(req.WithContext(ct))
+	if err != nil {
+		return nil, fmt.Sprintf("Failed to %s %s, err: %v", method, u, err), http.StatusBadGateway
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Sprintf("Failed to %s %s, status: %d, response: %s", method, u, resp.StatusCode, string(b)),
+			resp.StatusCode
+	}
+	return
+}
+
+//==================
+//
+// bucket operations
+//
+//==================
+// listbucket: a generic HTTP server has no notion of listing - the bucket contents are
+// known only to the extent they were accessed (and cached) via AIS
+func (hc *httpcloudimpl) listbucket(ct context.Context, bucket string, msg *cmn.GetMsg) (jsbytes []byte, errstr string, errcode int) {
+	if _, errstr, errcode = hc.originURL(bucket, ""); errstr != "" {
+		return
+	}
+	errstr = fmt.Sprintf("Listing is not supported by %s bucket %s (use cached listing instead)", cmn.ProviderHTTP, bucket)
+	errcode = http.StatusNotImplemented
+	return
+}
+
+func (hc *httpcloudimpl) headbucket(ct context.Context, bucket string) (bucketprops cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headbucket %s", bucket)
+	}
+	var u string
+	if u, errstr, errcode = hc.originURL(bucket, ""); errstr != "" {
+		return
+	}
+	bucketprops = make(cmn.SimpleKVs)
+	bucketprops[cmn.HeaderCloudProvider] = cmn.ProviderHTTP
+	bucketprops[cmn.HeaderVersioning] = cmn.VersionCloud
+	bucketprops[cmn.HeaderOriginURL] = u
+	return
+}
+
+func (hc *httpcloudimpl) getbucketnames(ct context.Context) (buckets []string, errstr string, errcode int) {
+	bucketmd := hc.t.bmdowner.get()
+	buckets = make([]string, 0, len(bucketmd.CBmap))
+	for bucket, props := range bucketmd.CBmap {
+		if props.OriginURL != "" {
+			buckets = append(buckets, bucket)
+		}
+	}
+	sort.Strings(buckets)
+	return
+}
+
+//============
+//
+// object meta
+//
+//============
+func (hc *httpcloudimpl) headobject(ct context.Context, bucket string, objname string) (objmeta cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headobject %s/%s", bucket, objname)
+	}
+	u, errstr, errcode := hc.originURL(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	resp, errstr, errcode := hc.do(ct, http.MethodHead, u)
+	if errstr != "" {
+		return
+	}
+	resp.Body.Close()
+	objmeta = make(cmn.SimpleKVs)
+	objmeta[cmn.HeaderCloudProvider] = cmn.ProviderHTTP
+	if version := httpcloudVersion(resp.Header); version != "" {
+		objmeta[cmn.HeaderObjVersion] = version
+	}
+	if resp.ContentLength >= 0 {
+		objmeta[cmn.HeaderObjSize] = strconv.FormatInt(resp.ContentLength, 10)
+	}
+	return
+}
+
+//=======================
+//
+// object data operations
+//
+//=======================
+func (hc *httpcloudimpl) getobj(ct context.Context, workFQN, bucket, objname string) (lom *cluster.LOM, errstr string, errcode int) {
+	u, errstr, errcode := hc.originURL(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	resp, errstr, errcode := hc.do(ct, http.MethodGet, u)
+	if errstr != "" {
+		return
+	}
+	lom = &cluster.LOM{T: hc.t, Bucket: bucket, Objname: objname}
+	lom.Version = httpcloudVersion(resp.Header)
+	if errstr = lom.Fill(cmn.CloudBs, 0); errstr != "" {
+		resp.Body.Close()
+		return
+	}
+	roi := &recvObjInfo{
+		t:            hc.t,
+		cold:         true,
+		r:            resp.Body,
+		cksumToCheck: httpcloudCksum(resp.Header),
+		lom:          lom,
+		workFQN:      workFQN,
+	}
+	if err := roi.writeToFile(); err != nil {
+		errstr = err.Error()
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("GET %s/%s <= %s", bucket, objname, u)
+	}
+	return
+}
+
+func (hc *httpcloudimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int) {
+	errstr = fmt.Sprintf("Failed to PUT %s/%s: %s buckets are read-only", bucket, objname, cmn.ProviderHTTP)
+	errcode = http.StatusMethodNotAllowed
+	return
+}
+
+func (hc *httpcloudimpl) deleteobj(ct context.Context, bucket, objname string) (errstr string, errcode int) {
+	errstr = fmt.Sprintf("Failed to DELETE %s/%s: %s buckets are read-only", bucket, objname, cmn.ProviderHTTP)
+	errcode = http.StatusMethodNotAllowed
+	return
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestHTTPCloudHeadObject(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/etag.bin":
+			w.Header().Set("ETag", `"abc123"`)
+			w.Write([]byte("12345"))
+		case "/data/dir/odd name?#%.bin":
+			if r.URL.RawQuery != "" || r.URL.Fragment != "" {
+				t.Errorf("unexpected query %q or fragment %q", r.URL.RawQuery, r.URL.Fragment)
+			}
+			w.Write([]byte("123"))
+		case "/data/modified.bin":
+			w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+			w.Write([]byte("1"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer origin.Close()
+
+	tr := &targetrunner{}
+	tr.bmdowner = &bmdowner{}
+	bucketmd := newBucketMD()
+	bucketmd.add("bound", false, &cmn.BucketProps{OriginURL: origin.URL + "/data/"})
+	bucketmd.add("unbound", false, &cmn.BucketProps{})
+	tr.bmdowner.put(bucketmd)
+
+	var (
+		ctx = context.Background()
+		hc  = &httpcloudimpl{t: tr, httpclient: http.DefaultClient}
+	)
+	objmeta, errstr, _ := hc.headobject(ctx, "bound", "etag.bin")
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if objmeta[cmn.HeaderObjVersion] != "abc123" || objmeta[cmn.HeaderObjSize] != "5" {
+		t.Errorf("unexpected object metadata: %v", objmeta)
+	}
+	objmeta, errstr, _ = hc.headobject(ctx, "bound", "modified.bin")
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if objmeta[cmn.HeaderObjVersion] != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("unexpected object metadata: %v", objmeta)
+	}
+	objmeta, errstr, _ = hc.headobject(ctx, "bound", "dir/odd name?#%.bin")
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if objmeta[cmn.HeaderObjSize] != "3" {
+		t.Errorf("unexpected object metadata: %v", objmeta)
+	}
+	if u, _, _ := hc.originURL("bound", "dir/odd name?#%.bin"); u != origin.URL+"/data/dir/odd%20name%3F%23%25.bin" {
+		t.Errorf("unexpected origin URL %s", u)
+	}
+	if _, _, errcode := hc.headobject(ctx, "bound", "nonexisting"); errcode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d", http.StatusNotFound, errcode)
+	}
+	if _, errstr, _ := hc.headobject(ctx, "unbound", "etag.bin"); errstr == "" {
+		t.Error("expected error accessing an object in the bucket without origin URL")
+	}
+
+	buckets, _, _ := hc.getbucketnames(ctx)
+	if len(buckets) != 1 || buckets[0] != "bound" {
+		t.Errorf("expected [bound], got %v", buckets)
+	}
+	if _, _, errcode := hc.putobj(ctx, nil, "bound", "etag.bin", nil, nil); errcode != http.StatusMethodNotAllowed {
+		t.Errorf("expected %d, got %d", http.StatusMethodNotAllowed, errcode)
+	}
+}
+
+// the origins' certificates must be valid: a self-signed one is rejected
+func TestHTTPCloudVerifiesOrigin(t *testing.T) {
+	origin := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer origin.Close()
+
+	hc := newHTTPProvider(&targetrunner{})
+	if resp, err := hc.httpclient.Get(origin.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected the origin with self-signed certificate to be rejected")
+	}
+}
@@ -7,6 +7,7 @@ package ais
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/stats/statsd"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/OneOfOne/xxhash"
 	jsoniter "github.com/json-iterator/go"
 	"golang.org/x/net/http2"
@@ -68,6 +70,7 @@ type (
 		req     reqArgs
 		timeout time.Duration
 		si      *cluster.Snode
+		span    *tracing.Span // optional: the call is then traced as a child span
 	}
 
 	// bcastCallArgs contains arguments for an intra-cluster broadcast call
@@ -76,6 +79,7 @@ type (
 		network string // on of the cmn.KnownNetworks
 		timeout time.Duration
 		nodes   []cluster.NodeMap
+		span    *tracing.Span // optional, as in callArgs
 	}
 
 	networkHandler struct {
@@ -119,7 +123,7 @@ type cloudif interface {
 	headobject(ctx context.Context, bucket string, objname string) (objmeta cmn.SimpleKVs, errstr string, errcode int)
 	//
 	getobj(ctx context.Context, fqn, bucket, objname string) (props *cluster.LOM, errstr string, errcode int)
-	putobj(ctx context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider) (version string, errstr string, errcode int)
+	putobj(ctx context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int)
 	deleteobj(ctx context.Context, bucket, objname string) (errstr string, errcode int)
 }
 
@@ -173,8 +177,9 @@ func (r *glogwriter) Write(p []byte) (int, error) {
 }
 
 type netServer struct {
-	s   *http.Server
-	mux *mux.ServeMux
+	s       *http.Server
+	mux     *mux.ServeMux
+	tlsConf *tls.Config // intra-cluster mTLS (nil when disabled)
 }
 
 // Override muxer ServeHTTP to support proxying HTTPS requests. Clients
@@ -222,20 +227,23 @@ func (server *netServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 type httprunner struct {
 	cmn.Named
-	publicServer          *netServer
-	intraControlServer    *netServer
-	intraDataServer       *netServer
-	glogger               *log.Logger
-	si                    *cluster.Snode
-	httpclient            *http.Client // http client for intra-cluster comm
-	httpclientLongTimeout *http.Client // http client for long-wait intra-cluster comm
-	keepalive             keepaliver
-	smapowner             *smapowner
-	smaplisteners         *smaplisteners
-	bmdowner              *bmdowner
-	xactions              *xactions
-	statsif               stats.Tracker
-	statsdC               statsd.Client
+	publicServer           *netServer
+	intraControlServer     *netServer
+	intraDataServer        *netServer
+	glogger                *log.Logger
+	si                     *cluster.Snode
+	httpclient             *http.Client // http client for intra-cluster comm via public network
+	httpclientLongTimeout  *http.Client // http client for long-wait intra-cluster comm via public network
+	intraClient            *http.Client // same as httpclient - for intra-cluster (control and data) networks
+	intraClientLongTimeout *http.Client // same as httpclientLongTimeout - for intra-cluster networks
+	keepalive              keepaliver
+	smapowner              *smapowner
+	smaplisteners          *smaplisteners
+	bmdowner               *bmdowner
+	xactions               *xactions
+	statsif                stats.Tracker
+	statsdC                statsd.Client
+	ctoken                 clusterTokenCache
 }
 
 func (server *netServer) listenAndServe(addr string, logger *log.Logger) error {
@@ -250,7 +258,16 @@ func (server *netServer) listenAndServe(addr string, logger *log.Logger) error {
 		httpHandler = server
 	}
 
-	if config.Net.HTTP.UseHTTPS {
+	if server.tlsConf != nil {
+		// intra-cluster mTLS: certificates are part of the config
+		server.s = &http.Server{Addr: addr, Handler: httpHandler, ErrorLog: logger, TLSConfig: server.tlsConf}
+		if err := server.s.ListenAndServeTLS("", ""); err != nil {
+			if err != http.ErrServerClosed {
+				glog.Errorf("Terminated server with err: %v", err)
+				return err
+			}
+		}
+	} else if config.Net.HTTP.UseHTTPS {
 		server.s = &http.Server{Addr: addr, Handler: httpHandler, ErrorLog: logger}
 		if err := server.s.ListenAndServeTLS(config.Net.HTTP.Certificate, config.Net.HTTP.Key); err != nil {
 			if err != http.ErrServerClosed {
@@ -340,10 +357,10 @@ func (h *httprunner) registerIntraDataNetHandler(path string, handler func(http.
 	}
 }
 
-func (h *httprunner) init(s stats.Tracker, isproxy bool) {
-	h.statsif = s
-
-	config := cmn.GCO.Get()
+// initClients creates http clients for the public network and, separately, for the
+// intra-cluster networks - the latter present the node's mTLS certificate (if enabled)
+// and, unlike the former, accept only the peers that are issued by the cluster CA
+func (h *httprunner) initClients(config *cmn.Config) {
 	h.httpclient = cmn.NewClient(cmn.ClientArgs{
 		Timeout:  config.Timeout.Default,
 		UseHTTPS: config.Net.HTTP.UseHTTPS,
@@ -352,6 +369,31 @@ func (h *httprunner) init(s stats.Tracker, isproxy bool) {
 		Timeout:  config.Timeout.DefaultLong,
 		UseHTTPS: config.Net.HTTP.UseHTTPS,
 	})
+	h.intraClient, h.intraClientLongTimeout = h.httpclient, h.httpclientLongTimeout
+	if tlsConf := cmn.IntraClusterTLS(); tlsConf != nil {
+		h.intraClient = cmn.NewClient(cmn.ClientArgs{
+			Timeout:   config.Timeout.Default,
+			TLSConfig: tlsConf,
+		})
+		h.intraClientLongTimeout = cmn.NewClient(cmn.ClientArgs{
+			Timeout:   config.Timeout.DefaultLong,
+			TLSConfig: tlsConf,
+		})
+	}
+}
+
+func (h *httprunner) init(s stats.Tracker, isproxy bool) {
+	h.statsif = s
+
+	config := cmn.GCO.Get()
+	if config.Net.MTLS.Enabled {
+		tlsConf, err := cmn.NewMTLSConfig(&config.Net.MTLS, h.verifyPeer)
+		if err != nil {
+			glog.Fatalf("Failed to load mtls certificates: %v", err)
+		}
+		cmn.SetIntraClusterTLS(tlsConf)
+	}
+	h.initClients(config)
 
 	h.publicServer = &netServer{
 		mux: mux.NewServeMux(),
@@ -359,13 +401,15 @@ func (h *httprunner) init(s stats.Tracker, isproxy bool) {
 	h.intraControlServer = h.publicServer // by default intra control net is the same as public
 	if config.Net.UseIntraControl {
 		h.intraControlServer = &netServer{
-			mux: mux.NewServeMux(),
+			mux:     mux.NewServeMux(),
+			tlsConf: cmn.IntraClusterTLS(),
 		}
 	}
 	h.intraDataServer = h.publicServer // by default intra data net is the same as public
 	if config.Net.UseIntraData {
 		h.intraDataServer = &netServer{
-			mux: mux.NewServeMux(),
+			mux:     mux.NewServeMux(),
+			tlsConf: cmn.IntraClusterTLS(),
 		}
 	}
 
@@ -373,6 +417,9 @@ func (h *httprunner) init(s stats.Tracker, isproxy bool) {
 	h.smapowner = &smapowner{listeners: h.smaplisteners}
 	h.bmdowner = &bmdowner{}
 	h.xactions = newXs() // extended actions
+	if config.Confdir != "" {
+		h.xactions.history = newXactHistory(filepath.Join(config.Confdir, cmn.XactHistoryFile))
+	}
 }
 
 // initSI initializes this cluster.Snode
@@ -424,6 +471,18 @@ func (h *httprunner) initSI() {
 	}
 
 	daemonID := os.Getenv("AIS_DAEMONID")
+	intraProto := config.Net.HTTP.Proto
+	if config.Net.MTLS.Enabled {
+		// the node is identified by its certificate
+		certID, err := cmn.MTLSDaemonID(&config.Net.MTLS)
+		if err != nil {
+			glog.Fatalf("Failed to load mtls certificate: %v", err)
+		}
+		if daemonID != "" && daemonID != certID {
+			glog.Fatalf("Daemon ID %s does not match the mtls certificate issued for %s", daemonID, certID)
+		}
+		daemonID, intraProto = certID, "https"
+	}
 	if daemonID == "" {
 		cs := xxhash.ChecksumString32S(publicAddr.String(), cluster.MLCG32)
 		daemonID = strconv.Itoa(int(cs & 0xfffff))
@@ -432,7 +491,21 @@ func (h *httprunner) initSI() {
 		}
 	}
 
-	h.si = newSnode(daemonID, config.Net.HTTP.Proto, publicAddr, intraControlAddr, intraDataAddr)
+	h.si = newSnode(daemonID, config.Net.HTTP.Proto, intraProto, publicAddr, intraControlAddr, intraDataAddr)
+}
+
+// verifyPeer checks the daemon ID of the intra-cluster (mTLS) peer against the
+// current cluster map; until the node gets its first cluster map, the CA-issued
+// certificate is the only check
+func (h *httprunner) verifyPeer(daemonID string) error {
+	smap := h.smapowner.get()
+	if smap == nil || smap.version() == 0 {
+		return nil
+	}
+	if !smap.containsID(daemonID) {
+		return fmt.Errorf("%s is not a member of the cluster (Smap v%d)", daemonID, smap.version())
+	}
+	return nil
 }
 
 func (h *httprunner) run() error {
@@ -513,6 +586,17 @@ func (h *httprunner) stop(err error) {
 	wg.Wait()
 }
 
+// clients returns the http clients to use with the given URL of the node:
+// the intra-cluster (mTLS) ones unless it is the node's public URL
+// (the URLs with no node - e.g., primary URL from the config - are public)
+func (h *httprunner) clients(si *cluster.Snode, base string) (client, clientLong *http.Client) {
+	if si != nil && base != si.PublicNet.DirectURL &&
+		(base == si.IntraControlNet.DirectURL || base == si.IntraDataNet.DirectURL) {
+		return h.intraClient, h.intraClientLongTimeout
+	}
+	return h.httpclient, h.httpclientLongTimeout
+}
+
 //=================================
 //
 // intra-cluster IPC, control plane
@@ -556,20 +640,34 @@ func (h *httprunner) call(args callArgs) callResult {
 	}
 
 	copyHeaders(args.req.header, &request.Header)
+	if cmn.GCO.Get().Auth.Enabled && request.Header.Get("Authorization") == "" {
+		token, err := h.clusterToken()
+		if err != nil {
+			return callResult{args.si, outjson, err, err.Error(), status}
+		}
+		request.Header.Set("Authorization", tokenStart+" "+token)
+	}
+	if args.span != nil {
+		span := args.span.Child("call " + args.req.method + " " + args.req.path)
+		span.Tag("callee", sid)
+		span.Inject(request.Header)
+		defer span.Finish()
+	}
+	client, clientLong := h.clients(args.si, args.req.base)
 	switch args.timeout {
 	case defaultTimeout:
-		response, err = h.httpclient.Do(request)
+		response, err = client.Do(request)
 	case longTimeout:
-		response, err = h.httpclientLongTimeout.Do(request)
+		response, err = clientLong.Do(request)
 	default:
 		contextwith, cancel := context.WithTimeout(context.Background(), args.timeout)
 		defer cancel() // timeout => context.deadlineExceededError
 		newRequest := request.WithContext(contextwith)
 		copyHeaders(args.req.header, &newRequest.Header)
-		if args.timeout > h.httpclient.Timeout {
-			response, err = h.httpclientLongTimeout.Do(newRequest)
+		if args.timeout > client.Timeout {
+			response, err = clientLong.Do(newRequest)
 		} else {
-			response, err = h.httpclient.Do(newRequest)
+			response, err = client.Do(newRequest)
 		}
 	}
 	if err != nil {
@@ -673,6 +771,7 @@ func (h *httprunner) broadcast(bcastArgs bcastCallArgs) chan callResult {
 					si:      di,
 					req:     bcastArgs.req,
 					timeout: bcastArgs.timeout,
+					span:    bcastArgs.span,
 				}
 				args.req.base = di.URL(bcastArgs.network)
 
@@ -829,6 +928,9 @@ func (h *httprunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	case cmn.GetWhatDaemonInfo:
 		jsbytes, err = jsoniter.Marshal(h.si)
 		cmn.AssertNoErr(err)
+	case cmn.GetWhatXactionHistory:
+		jsbytes, err = jsoniter.Marshal(h.xactions.history.get())
+		cmn.AssertNoErr(err)
 	default:
 		s := fmt.Sprintf("Invalid GET /daemon request: unrecognized what=%s", getWhat)
 		h.invalmsghdlr(w, r, s)
@@ -1015,6 +1117,18 @@ func (h *httprunner) setconfig(name, value string) (errstr string) {
 		} else {
 			config.Mirror.MirrorUtilThresh = v
 		}
+	case "max_stream_bandwidth":
+		if v, err := cmn.S2B(value); err != nil || v < 0 {
+			errstr = fmt.Sprintf("Failed to parse max_stream_bandwidth, err: %v", err)
+		} else {
+			config.Transport.MaxStreamBandwidth, config.Transport.MaxStreamBandwidthStr = v, value
+		}
+	case "max_bundle_bandwidth":
+		if v, err := cmn.S2B(value); err != nil || v < 0 {
+			errstr = fmt.Sprintf("Failed to parse max_bundle_bandwidth, err: %v", err)
+		} else {
+			config.Transport.MaxBundleBandwidth, config.Transport.MaxBundleBandwidthStr = v, value
+		}
 	default:
 		errstr = fmt.Sprintf("Cannot set config var %s - is readonly or unsupported", name)
 	}
@@ -0,0 +1,87 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// listFilter is cmn.ListFilter compiled once per listing, to be evaluated
+// by allfinfos for each listed object
+type listFilter struct {
+	regex                *regexp.Regexp
+	minSize, maxSize     int64
+	atimeFrom, atimeTo   time.Time
+	ctimeFrom, ctimeTo   time.Time
+	misplaced, hasCopies bool
+}
+
+// newListFilter returns nil if there's nothing to filter
+func newListFilter(f *cmn.ListFilter) (lf *listFilter, err error) {
+	if f == nil {
+		return
+	}
+	if f.MinSize < 0 || f.MaxSize < 0 || (f.MaxSize != 0 && f.MinSize > f.MaxSize) {
+		return nil, fmt.Errorf("invalid size range [%d, %d]", f.MinSize, f.MaxSize)
+	}
+	lf = &listFilter{minSize: f.MinSize, maxSize: f.MaxSize, misplaced: f.Misplaced, hasCopies: f.Copies}
+	if f.Regex != "" {
+		if lf.regex, err = regexp.Compile(f.Regex); err != nil {
+			return nil, fmt.Errorf("invalid regex %q, err: %v", f.Regex, err)
+		}
+	}
+	for _, tr := range []struct {
+		s string
+		t *time.Time
+	}{
+		{f.AtimeFrom, &lf.atimeFrom}, {f.AtimeTo, &lf.atimeTo},
+		{f.CtimeFrom, &lf.ctimeFrom}, {f.CtimeTo, &lf.ctimeTo},
+	} {
+		if tr.s == "" {
+			continue
+		}
+		if *tr.t, err = time.Parse(time.RFC3339, tr.s); err != nil {
+			return nil, fmt.Errorf("invalid time %q (expecting RFC3339), err: %v", tr.s, err)
+		}
+	}
+	return
+}
+
+func (lf *listFilter) needAtime() bool { return !lf.atimeFrom.IsZero() || !lf.atimeTo.IsZero() }
+
+func inTimeRange(t, from, to time.Time) bool {
+	return (from.IsZero() || !t.Before(from)) && (to.IsZero() || !t.After(to))
+}
+
+// match is called with the object's name relative to the bucket; the object's
+// atime, if needed, must be already filled-in (cluster.LomAtime)
+func (lf *listFilter) match(relname string, lom *cluster.LOM, osfi os.FileInfo, objStatus string) bool {
+	if lf.regex != nil && !lf.regex.MatchString(relname) {
+		return false
+	}
+	size := osfi.Size()
+	if size < lf.minSize || (lf.maxSize != 0 && size > lf.maxSize) {
+		return false
+	}
+	if lf.misplaced && objStatus != cmn.ObjStatusMoved {
+		return false
+	}
+	if lf.hasCopies && !lom.HasCopy() {
+		return false
+	}
+	if !inTimeRange(osfi.ModTime(), lf.ctimeFrom, lf.ctimeTo) {
+		return false
+	}
+	if lf.needAtime() && (lom.Atime.IsZero() || !inTimeRange(lom.Atime, lf.atimeFrom, lf.atimeTo)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestListFilterInvalid(t *testing.T) {
+	for _, f := range []*cmn.ListFilter{
+		{Regex: "a(b"},
+		{MinSize: 10, MaxSize: 5},
+		{MinSize: -1},
+		{AtimeFrom: "yesterday"},
+		{CtimeTo: "2019-01-01"},
+	} {
+		if _, err := newListFilter(f); err == nil {
+			t.Errorf("expected %+v to be invalid", f)
+		}
+	}
+	if lf, err := newListFilter(nil); lf != nil || err != nil {
+		t.Errorf("expected no filter, got %v (%v)", lf, err)
+	}
+}
+
+func TestListFilterMatch(t *testing.T) {
+	file, err := ioutil.TempFile("", "listfilter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("0123456789")
+	file.Close()
+	ctime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(file.Name(), ctime, ctime); err != nil {
+		t.Fatal(err)
+	}
+	osfi, err := os.Stat(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		rfc  = func(t time.Time) string { return t.Format(time.RFC3339) }
+		lom  = &cluster.LOM{FQN: "a", HrwFQN: "a", Atime: ctime.Add(30 * time.Minute)}
+		copy = &cluster.LOM{FQN: "a", HrwFQN: "a", CopyFQN: "b", Atime: lom.Atime}
+	)
+	tests := []struct {
+		filter    cmn.ListFilter
+		lom       *cluster.LOM
+		objStatus string
+		match     bool
+	}{
+		{cmn.ListFilter{Regex: `^dir/.*\.tar$`}, lom, cmn.ObjStatusOK, true},
+		{cmn.ListFilter{Regex: `\.tgz$`}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{MinSize: 10, MaxSize: 10}, lom, cmn.ObjStatusOK, true},
+		{cmn.ListFilter{MinSize: 11}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{MaxSize: 9}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{CtimeFrom: rfc(ctime), CtimeTo: rfc(ctime)}, lom, cmn.ObjStatusOK, true},
+		{cmn.ListFilter{CtimeFrom: rfc(ctime.Add(time.Second))}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{AtimeFrom: rfc(ctime)}, lom, cmn.ObjStatusOK, true},
+		{cmn.ListFilter{AtimeTo: rfc(ctime)}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{AtimeFrom: rfc(ctime)}, &cluster.LOM{}, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{Misplaced: true}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{Misplaced: true}, lom, cmn.ObjStatusMoved, true},
+		{cmn.ListFilter{Copies: true}, lom, cmn.ObjStatusOK, false},
+		{cmn.ListFilter{Copies: true, Cached: true}, copy, cmn.ObjStatusOK, true},
+	}
+	for i, test := range tests {
+		lf, err := newListFilter(&test.filter)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if match := lf.match("dir/obj.tar", test.lom, osfi, test.objStatus); match != test.match {
+			t.Errorf("%d: %+v: expected match=%t", i, test.filter, test.match)
+		}
+	}
+}
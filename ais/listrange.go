@@ -132,8 +132,11 @@ func (t *targetrunner) doListEvictDelete(ct context.Context, evict bool, objs []
 		}
 		err := t.objDelete(ct, lom, evict)
 		if err != nil {
+			xdel.ErrorsAdd(1)
 			return err
 		}
+		xdel.ObjectsAdd(1)
+		xdel.BytesAdd(lom.Size)
 	}
 
 	return nil
@@ -155,7 +158,8 @@ func (t *targetrunner) doListEvict(ct context.Context, objs []string, bucket, bu
 //
 //=========
 
-func (t *targetrunner) prefetchMissing(ct context.Context, objname, bucket, bucketProvider string) {
+// prefetchMissing returns the size of the object when it gets prefetched (ok == true)
+func (t *targetrunner) prefetchMissing(ct context.Context, objname, bucket, bucketProvider string) (size int64, ok bool) {
 	var (
 		errstr            string
 		vchanged, coldGet bool
@@ -164,28 +168,28 @@ func (t *targetrunner) prefetchMissing(ct context.Context, objname, bucket, buck
 	)
 	if errstr = lom.Fill(bucketProvider, cluster.LomFstat|cluster.LomVersion|cluster.LomCksum); errstr != "" {
 		glog.Error(errstr)
-		return
+		return 0, false
 	}
 	if lom.BckIsLocal { // must not come here
 		if !lom.Exists() {
 			glog.Errorf("prefetch: %s", lom)
 		}
-		return
+		return 0, false
 	}
 	coldGet = !lom.Exists()
 	if lom.Exists() && versioncfg.ValidateWarmGet && lom.Version != "" && versioningConfigured(false) {
 		if coldGet, errstr, _ = t.checkCloudVersion(ct, bucket, objname, lom.Version); errstr != "" {
-			return
+			return 0, false
 		}
 	}
 	if !coldGet {
-		return
+		return 0, false
 	}
 	if errstr, _ = t.getCold(ct, lom, true); errstr != "" {
 		if errstr != "skip" {
 			glog.Errorln(errstr)
 		}
-		return
+		return 0, false
 	}
 	if glog.V(4) {
 		glog.Infof("prefetch: %s", lom)
@@ -196,6 +200,7 @@ func (t *targetrunner) prefetchMissing(ct context.Context, objname, bucket, buck
 		t.statsif.Add(stats.VerChangeSize, lom.Size)
 		t.statsif.Add(stats.VerChangeCount, 1)
 	}
+	return lom.Size, true
 }
 
 func (t *targetrunner) addPrefetchList(ct context.Context, objs []string, bucket string, bucketProvider string,
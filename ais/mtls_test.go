@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// mtlsCerts writes the cluster CA and the node certificates (named after the daemon IDs)
+// into dir and returns the mTLS config of each node
+func mtlsCerts(t *testing.T, dir string, daemonIDs ...string) map[string]*cmn.MTLSConf {
+	write := func(path, typ string, der []byte) {
+		if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, _ := x509.ParseCertificate(caDER)
+	write(filepath.Join(dir, "ca.crt"), "CERTIFICATE", caDER)
+
+	confs := make(map[string]*cmn.MTLSConf, len(daemonIDs))
+	for i, daemonID := range daemonIDs {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: daemonID},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := &cmn.MTLSConf{
+			Enabled:     true,
+			CACert:      filepath.Join(dir, "ca.crt"),
+			Certificate: filepath.Join(dir, daemonID+".crt"),
+			Key:         filepath.Join(dir, daemonID+".key"),
+		}
+		write(conf.Certificate, "CERTIFICATE", der)
+		write(conf.Key, "EC PRIVATE KEY", keyDER)
+		confs[daemonID] = conf
+	}
+	return confs
+}
+
+// with both use_https and mTLS enabled, the node calls the other nodes via the
+// public network (server-side TLS) as well as via the intra-cluster one (mTLS)
+func TestMTLSWithHTTPS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		confs      = mtlsCerts(t, dir, "p1", "t1")
+		verifyPeer = func(daemonID string) error { return nil }
+		handler    = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	)
+	serverTLS, err := cmn.NewMTLSConfig(confs["t1"], verifyPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientTLS, err := cmn.NewMTLSConfig(confs["p1"], verifyPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// public: self-signed server certificate that is not issued by the cluster CA
+	public := httptest.NewTLSServer(handler)
+	defer public.Close()
+	intra := httptest.NewUnstartedServer(handler)
+	intra.TLS = serverTLS
+	intra.StartTLS()
+	defer intra.Close()
+
+	cmn.SetIntraClusterTLS(clientTLS)
+	defer cmn.SetIntraClusterTLS(nil)
+	config := cmn.GCO.BeginUpdate()
+	config.Net.HTTP.UseHTTPS = true
+	config.KeepaliveTracker.Proxy.Name = "heartbeat"
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Net.HTTP.UseHTTPS = false
+		cmn.GCO.CommitUpdate(config)
+	}()
+
+	p := &proxyrunner{}
+	p.keepalive = newProxyKeepaliveRunner(p)
+	p.initClients(cmn.GCO.Get())
+	si := &cluster.Snode{
+		DaemonID:        "t1",
+		PublicNet:       cluster.NetInfo{DirectURL: public.URL},
+		IntraControlNet: cluster.NetInfo{DirectURL: intra.URL},
+		IntraDataNet:    cluster.NetInfo{DirectURL: intra.URL},
+	}
+	tests := []struct {
+		name string
+		base string
+	}{
+		{name: "public", base: public.URL},
+		{name: "intra-control", base: ""}, // default
+		{name: "intra-data", base: intra.URL},
+	}
+	for _, test := range tests {
+		for _, timeout := range []time.Duration{defaultTimeout, longTimeout, time.Minute} {
+			res := p.call(callArgs{
+				si:      si,
+				req:     reqArgs{method: http.MethodGet, base: test.base, path: cmn.URLPath(cmn.Version, cmn.Health)},
+				timeout: timeout,
+			})
+			if res.err != nil {
+				t.Errorf("%s (timeout %v): %s", test.name, timeout, res.errstr)
+			}
+		}
+	}
+
+	// the public URL with no node (e.g., primary URL from the config)
+	res := p.call(callArgs{req: reqArgs{method: http.MethodGet, base: public.URL}, timeout: defaultTimeout})
+	if res.err != nil {
+		t.Errorf("public (no node): %s", res.errstr)
+	}
+}
@@ -0,0 +1,742 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Multipart upload:
+//
+// 1. POST {"action": "mpartinit"} /v1/objects/bucket-name/object-name returns the upload ID
+// 2. PUT /v1/objects/bucket-name/object-name?upload_id=ID&part_num=N uploads (or re-uploads) part N
+// 3. POST {"action": "mpartcomplete", "value": {"upload_id": ID}} assembles the object from
+//    the parts 1, 2, ... N in one atomic step; the assembled object is then PUT as usual,
+//    with its (combined) xxhash returned in the response headers
+// 4. or, POST {"action": "mpartabort", "value": {"upload_id": ID}} discards the upload
+//
+// All requests are redirected by the proxy to the same target - the one named
+// by the upload ID, which is the HRW target at the time of mpartinit. The target
+// stores the parts next to the object as mpartType content, along with a metadata
+// file describing the upload; the part checksums are kept in the parts' xattrs.
+// At startup the target reloads the uploads from disk, so that an upload survives
+// restarts. Uploads that are neither completed nor aborted are garbage-collected
+// after mpartExpireTime of inactivity, and so are the parts that do not belong to
+// any upload. If the cluster map changes while the upload is in progress, the
+// owner forwards the assembled object to its new HRW target upon completion.
+
+const (
+	mpartType       = "mpart"          // content type of the uploaded parts
+	mpartMetaSuffix = "meta"           // upload metadata: <objname>.<upload ID>.meta.<tie breaker>.<pid>
+	mpartGCTime     = 10 * time.Minute // how often to look for abandoned uploads
+	mpartExpireTime = 24 * time.Hour   // an upload is abandoned when idle for longer
+)
+
+type (
+	mpartMgr struct {
+		sync.Mutex
+		t       *targetrunner
+		uploads map[string]*mpartUpload // upload ID => upload
+		stopCh  chan struct{}
+	}
+	mpartUpload struct {
+		sync.Mutex
+		id             string
+		bucket         string
+		objname        string
+		bucketProvider string
+		metaFQN        string
+		parts          map[int]*mpartPart
+		lastActive     time.Time
+		completing     bool
+		aborted        bool // by the client or by gc - parts that are still being uploaded get removed
+	}
+	// mpartMeta is the persistent part of mpartUpload
+	mpartMeta struct {
+		ID             string `json:"id"`
+		Bucket         string `json:"bucket"`
+		Objname        string `json:"objname"`
+		BucketProvider string `json:"bprovider"`
+	}
+	mpartPart struct {
+		fqn   string
+		size  int64
+		cksum string // xxhash
+		mtime time.Time
+	}
+	// mpartContentResolver implements fs.ContentResolver for the uploaded parts
+	mpartContentResolver struct{}
+	// mpartReader reads the parts one after another and closes them all when done
+	mpartReader struct {
+		io.Reader
+		files []*os.File
+	}
+)
+
+var (
+	_ fs.ContentResolver = &mpartContentResolver{}
+
+	mpartPID = strconv.FormatInt(int64(os.Getpid()), 16)
+)
+
+//
+// content resolver
+//
+
+func (mr *mpartContentResolver) PermToMove() bool    { return false }
+func (mr *mpartContentResolver) PermToEvict() bool   { return false }
+func (mr *mpartContentResolver) PermToProcess() bool { return false }
+
+// GenUniqueFQN: <objname>.<upload ID>.<part number>.<tie breaker>.<pid>, where prefix is "<upload ID>.<part number>"
+func (mr *mpartContentResolver) GenUniqueFQN(base, prefix string) string {
+	tieBreaker := strconv.FormatInt(time.Now().UnixNano(), 16)
+	return base + "." + prefix + "." + tieBreaker[5:] + "." + mpartPID
+}
+
+func (mr *mpartContentResolver) ParseUniqueFQN(base string) (orig string, old bool, ok bool) {
+	orig = base
+	for i := 0; i < 4; i++ {
+		idx := strings.LastIndex(orig, ".")
+		if idx < 0 {
+			return "", false, false
+		}
+		if i == 0 {
+			old = orig[idx+1:] != mpartPID
+		}
+		orig = orig[:idx]
+	}
+	return orig, old, true
+}
+
+// mpartParseFQN returns the upload ID and either the part number or mpartMetaSuffix
+func mpartParseFQN(fqn string) (id, kind string, ok bool) {
+	if _, info := fs.CSM.FileSpec(fqn); info == nil || info.Type != mpartType || strings.HasSuffix(fqn, ".tmp") {
+		return
+	}
+	// <objname>.<upload ID>.<part number | meta>.<tie breaker>.<pid>
+	fields := strings.Split(filepath.Base(fqn), ".")
+	if n := len(fields); n >= 5 {
+		id, kind, ok = fields[n-4], fields[n-3], true
+	}
+	return
+}
+
+// mpartOwner returns the ID of the target that owns a given upload
+func mpartOwner(uploadID string) string {
+	idx := strings.LastIndex(uploadID, "-")
+	if idx < 0 {
+		return ""
+	}
+	b, err := hex.DecodeString(uploadID[idx+1:])
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func mpartRemove(fqn string) {
+	if err := os.Remove(fqn); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("Failed to remove %s, err: %v", fqn, err)
+	}
+}
+
+func (r *mpartReader) Close() (err error) {
+	for _, file := range r.files {
+		if e := file.Close(); e != nil {
+			err = e
+		}
+	}
+	return
+}
+
+//
+// manager
+//
+
+func newMpartMgr(t *targetrunner) *mpartMgr {
+	return &mpartMgr{
+		t:       t,
+		uploads: make(map[string]*mpartUpload, 16),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+func (m *mpartMgr) run() {
+	ticker := time.NewTicker(mpartGCTime)
+	for {
+		select {
+		case <-ticker.C:
+			m.gc(time.Now())
+		case <-m.stopCh:
+			ticker.Stop()
+			return
+		}
+	}
+}
+
+func (m *mpartMgr) stop() { close(m.stopCh) }
+
+// gc aborts the uploads that have been idle for longer than mpartExpireTime
+func (m *mpartMgr) gc(now time.Time) {
+	m.Lock()
+	expired := make([]*mpartUpload, 0, 4)
+	for id, upload := range m.uploads {
+		upload.Lock()
+		if !upload.completing && !upload.aborted && now.Sub(upload.lastActive) > mpartExpireTime {
+			upload.aborted = true // to fail concurrent part uploads
+			delete(m.uploads, id)
+			expired = append(expired, upload)
+		}
+		upload.Unlock()
+	}
+	m.Unlock()
+	for _, upload := range expired {
+		glog.Infof("multipart upload %s (%s/%s) abandoned - removing %d part(s)",
+			upload.id, upload.bucket, upload.objname, len(upload.parts))
+		upload.removeParts()
+	}
+}
+
+// load restores the uploads persisted by the previous instances of the target;
+// expired uploads, parts that do not belong to any upload (or that were not
+// fully received), and replaced parts are removed
+func (m *mpartMgr) load(now time.Time) {
+	type partFile struct {
+		fqn   string
+		num   int
+		finfo os.FileInfo
+	}
+	var (
+		metas = make(map[string]string, 4)     // upload ID => metadata FQN
+		parts = make(map[string][]partFile, 4) // upload ID => part files
+	)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for mpath := range availablePaths {
+		dir := filepath.Join(mpath, mpartType)
+		filepath.Walk(dir, func(fqn string, finfo os.FileInfo, err error) error {
+			if err != nil || finfo.IsDir() {
+				return nil
+			}
+			id, kind, ok := mpartParseFQN(fqn)
+			if !ok {
+				mpartRemove(fqn)
+				return nil
+			}
+			if kind == mpartMetaSuffix {
+				metas[id] = fqn
+				return nil
+			}
+			num, err := strconv.Atoi(kind)
+			if err != nil {
+				mpartRemove(fqn)
+				return nil
+			}
+			parts[id] = append(parts[id], partFile{fqn: fqn, num: num, finfo: finfo})
+			return nil
+		})
+	}
+
+	m.Lock()
+	defer m.Unlock()
+	for id, metaFQN := range metas {
+		meta := &mpartMeta{}
+		if err := cmn.LocalLoad(metaFQN, meta); err != nil || meta.ID != id {
+			glog.Errorf("Failed to load multipart upload %s, err: %v", metaFQN, err)
+			mpartRemove(metaFQN)
+			continue
+		}
+		finfo, err := os.Stat(metaFQN)
+		if err != nil {
+			mpartRemove(metaFQN)
+			continue
+		}
+		upload := &mpartUpload{
+			id:             id,
+			bucket:         meta.Bucket,
+			objname:        meta.Objname,
+			bucketProvider: meta.BucketProvider,
+			metaFQN:        metaFQN,
+			parts:          make(map[int]*mpartPart, len(parts[id])),
+			lastActive:     finfo.ModTime(),
+		}
+		for _, pf := range parts[id] {
+			cksum, errstr := fs.GetXattr(pf.fqn, cmn.XattrXXHash)
+			if errstr != "" || len(cksum) == 0 {
+				mpartRemove(pf.fqn) // not fully received
+				continue
+			}
+			if prev, ok := upload.parts[pf.num]; ok {
+				if prev.mtime.After(pf.finfo.ModTime()) {
+					mpartRemove(pf.fqn)
+					continue
+				}
+				mpartRemove(prev.fqn)
+			}
+			upload.parts[pf.num] = &mpartPart{
+				fqn:   pf.fqn,
+				size:  pf.finfo.Size(),
+				cksum: string(cksum),
+				mtime: pf.finfo.ModTime(),
+			}
+			if pf.finfo.ModTime().After(upload.lastActive) {
+				upload.lastActive = pf.finfo.ModTime()
+			}
+		}
+		delete(parts, id)
+		if now.Sub(upload.lastActive) > mpartExpireTime {
+			glog.Infof("multipart upload %s (%s/%s) expired - removing %d part(s)",
+				upload.id, upload.bucket, upload.objname, len(upload.parts))
+			upload.removeParts()
+			continue
+		}
+		m.uploads[id] = upload
+		glog.Infof("multipart upload %s (%s/%s): restored %d part(s)", upload.id, upload.bucket, upload.objname, len(upload.parts))
+	}
+	for _, orphans := range parts {
+		for _, pf := range orphans {
+			mpartRemove(pf.fqn)
+		}
+	}
+}
+
+func (m *mpartMgr) get(id, bucket, objname string) (upload *mpartUpload, errstr string, errcode int) {
+	m.Lock()
+	upload, ok := m.uploads[id]
+	m.Unlock()
+	if !ok {
+		return nil, fmt.Sprintf("Multipart upload %q %s", id, cmn.DoesNotExist), http.StatusNotFound
+	}
+	if upload.bucket != bucket || upload.objname != objname {
+		return nil, fmt.Sprintf("Multipart upload %q does not belong to %s/%s", id, bucket, objname), http.StatusBadRequest
+	}
+	return
+}
+
+func (m *mpartMgr) remove(id string) {
+	m.Lock()
+	delete(m.uploads, id)
+	m.Unlock()
+}
+
+func (m *mpartMgr) init(bucket, objname, bucketProvider string) (id string, errstr string) {
+	lom := &cluster.LOM{T: m.t, Bucket: bucket, Objname: objname}
+	if errstr = lom.Fill(bucketProvider, 0); errstr != "" {
+		return
+	}
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Sprintf("Failed to generate upload ID, err: %v", err)
+	}
+	id = hex.EncodeToString(b) + "-" + hex.EncodeToString([]byte(m.t.si.DaemonID))
+	upload := &mpartUpload{
+		id:             id,
+		bucket:         bucket,
+		objname:        objname,
+		bucketProvider: bucketProvider,
+		metaFQN:        lom.GenFQN(mpartType, id+"."+mpartMetaSuffix),
+		parts:          make(map[int]*mpartPart, 16),
+		lastActive:     time.Now(),
+	}
+	meta := &mpartMeta{ID: id, Bucket: bucket, Objname: objname, BucketProvider: bucketProvider}
+	if err := cmn.CreateDir(filepath.Dir(upload.metaFQN)); err != nil {
+		return "", fmt.Sprintf("Failed to create multipart upload %s/%s, err: %v", bucket, objname, err)
+	}
+	if err := cmn.LocalSave(upload.metaFQN, meta); err != nil {
+		m.t.fshc(err, upload.metaFQN)
+		return "", fmt.Sprintf("Failed to create multipart upload %s/%s, err: %v", bucket, objname, err)
+	}
+	m.Lock()
+	m.uploads[id] = upload
+	m.Unlock()
+	return
+}
+
+// putPart receives a part into a new file and, once received, replaces
+// the previously uploaded part with the same number, if any
+func (m *mpartMgr) putPart(r *http.Request, bucket, objname string) (part *mpartPart, errstr string, errcode int) {
+	var (
+		query   = r.URL.Query()
+		id      = query.Get(cmn.URLParamUploadID)
+		partNum int
+		err     error
+	)
+	if partNum, err = strconv.Atoi(query.Get(cmn.URLParamPartNum)); err != nil || partNum < 1 || partNum > cmn.MPartMaxParts {
+		return nil, fmt.Sprintf("Invalid part number %q (expecting 1 - %d)", query.Get(cmn.URLParamPartNum), cmn.MPartMaxParts),
+			http.StatusBadRequest
+	}
+	upload, errstr, errcode := m.get(id, bucket, objname)
+	if errstr != "" {
+		return
+	}
+	lom := &cluster.LOM{T: m.t, Bucket: bucket, Objname: objname}
+	if errstr = lom.Fill(upload.bucketProvider, 0); errstr != "" {
+		return
+	}
+	part = &mpartPart{fqn: lom.GenFQN(mpartType, id+"."+strconv.Itoa(partNum))}
+	if err = part.recv(r.Body); err != nil {
+		m.t.fshc(err, part.fqn)
+		return nil, fmt.Sprintf("Failed to receive part %d of %s/%s, err: %v", partNum, bucket, objname, err),
+			http.StatusInternalServerError
+	}
+	cksum := cmn.NewCksum(r.Header.Get(cmn.HeaderObjCksumType), r.Header.Get(cmn.HeaderObjCksumVal))
+	if cksum != nil {
+		if cksumType, _ := cksum.Get(); cksumType == cmn.ChecksumXXHash &&
+			!cmn.EqCksum(cksum, cmn.NewCksum(cmn.ChecksumXXHash, part.cksum)) {
+			os.Remove(part.fqn)
+			return nil, fmt.Sprintf("Bad checksum of part %d of %s/%s: expected %s, got %s",
+				partNum, bucket, objname, cksum, part.cksum), http.StatusBadRequest
+		}
+	}
+
+	upload.Lock()
+	if errstr, errcode = upload.checkActive(); errstr != "" {
+		upload.Unlock()
+		os.Remove(part.fqn)
+		return nil, errstr, errcode
+	}
+	prev := upload.parts[partNum]
+	upload.parts[partNum] = part
+	upload.lastActive = time.Now()
+	upload.Unlock()
+	if prev != nil {
+		os.Remove(prev.fqn)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("multipart upload %s: PUT part %d of %s/%s, size %d", id, partNum, bucket, objname, part.size)
+	}
+	return
+}
+
+// complete assembles the object from the uploaded parts and PUTs it
+func (m *mpartMgr) complete(r *http.Request, bucket, objname string, msg *cmn.MPartMsg) (lom *cluster.LOM, errstr string, errcode int) {
+	upload, errstr, errcode := m.get(msg.UploadID, bucket, objname)
+	if errstr != "" {
+		return
+	}
+	upload.Lock()
+	if errstr, errcode = upload.checkActive(); errstr != "" {
+		upload.Unlock()
+		return
+	}
+	parts, errstr := upload.sortedParts(msg.Parts)
+	if errstr != "" {
+		upload.Unlock()
+		return nil, errstr, http.StatusBadRequest
+	}
+	upload.completing = true
+	upload.Unlock()
+
+	var size int64
+	reader := &mpartReader{files: make([]*os.File, 0, len(parts))}
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		file, err := os.Open(part.fqn)
+		if err != nil {
+			reader.Close()
+			upload.Lock()
+			upload.completing = false
+			upload.Unlock()
+			return nil, fmt.Sprintf("Failed to open part %s, err: %v", part.fqn, err), http.StatusInternalServerError
+		}
+		reader.files = append(reader.files, file)
+		readers = append(readers, file)
+		size += part.size
+	}
+	reader.Reader = io.MultiReader(readers...)
+
+	si, errstr := hrwTarget(bucket, objname, m.t.smapowner.get())
+	if errstr == "" {
+		if si.DaemonID != m.t.si.DaemonID {
+			lom, errstr, errcode = m.forward(r, si, upload, reader, size)
+		} else {
+			roi := &recvObjInfo{
+				t:              m.t,
+				objname:        objname,
+				bucket:         bucket,
+				r:              reader, // closed by writeToFile
+				ctx:            m.t.contextWithAuth(r),
+				bucketProvider: upload.bucketProvider,
+			}
+			if err := roi.init(); err != nil {
+				reader.Close()
+				errstr, errcode = err.Error(), http.StatusInternalServerError
+			} else if err, code := roi.recv(); err != nil {
+				errstr, errcode = err.Error(), code
+			}
+			lom = roi.lom
+		}
+	} else {
+		reader.Close()
+		errcode = http.StatusInternalServerError
+	}
+	if errstr != "" {
+		upload.Lock()
+		upload.completing = false
+		upload.lastActive = time.Now()
+		upload.Unlock()
+		return
+	}
+	m.remove(upload.id)
+	upload.removeParts()
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("multipart upload %s: %s assembled from %d part(s)", upload.id, lom, len(parts))
+	}
+	return lom, "", 0
+}
+
+// forward PUTs the assembled object to its current HRW target when the latter
+// is not the upload's owner (that is, when the cluster map has changed since mpartinit)
+func (m *mpartMgr) forward(r *http.Request, si *cluster.Snode, upload *mpartUpload, reader *mpartReader,
+	size int64) (lom *cluster.LOM, errstr string, errcode int) {
+	query := url.Values{}
+	query.Add(cmn.URLParamBucketProvider, upload.bucketProvider)
+	query.Add(cmn.URLParamProxyID, r.URL.Query().Get(cmn.URLParamProxyID))
+	puturl := si.IntraDataNet.DirectURL + cmn.URLPath(cmn.Version, cmn.Objects, upload.bucket, upload.objname) +
+		"?" + query.Encode()
+	req, err := http.NewRequest(http.MethodPut, puturl, reader) // the reader is closed by the client
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Sprintf("Unexpected failure to create %s request %s, err: %v", http.MethodPut, puturl, err),
+			http.StatusInternalServerError
+	}
+	req.ContentLength = size
+	if token := r.Header.Get("Authorization"); token != "" {
+		req.Header.Set("Authorization", token) // cloud credentials, if any
+	}
+	resp, err := m.t.intraClientLongTimeout.Do(req)
+	if err != nil {
+		return nil, fmt.Sprintf("Failed to forward %s/%s to %s, err: %v", upload.bucket, upload.objname, si, err),
+			http.StatusInternalServerError
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, fmt.Sprintf("Failed to forward %s/%s to %s: %s", upload.bucket, upload.objname, si, string(b)),
+			resp.StatusCode
+	}
+	lom = &cluster.LOM{
+		Bucket:  upload.bucket,
+		Objname: upload.objname,
+		Size:    size,
+		Cksum:   cmn.NewCksum(resp.Header.Get(cmn.HeaderObjCksumType), resp.Header.Get(cmn.HeaderObjCksumVal)),
+		Version: resp.Header.Get(cmn.HeaderObjVersion),
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("multipart upload %s: forwarded %s/%s to %s", upload.id, upload.bucket, upload.objname, si)
+	}
+	return
+}
+
+func (m *mpartMgr) abort(bucket, objname string, msg *cmn.MPartMsg) (errstr string, errcode int) {
+	upload, errstr, errcode := m.get(msg.UploadID, bucket, objname)
+	if errstr != "" {
+		return
+	}
+	upload.Lock()
+	if errstr, errcode = upload.checkActive(); errstr != "" {
+		upload.Unlock()
+		return
+	}
+	upload.aborted = true // to fail concurrent part uploads
+	upload.Unlock()
+	m.remove(upload.id)
+	upload.removeParts()
+	return
+}
+
+//
+// upload and part
+//
+
+// sortedParts returns the uploaded parts in order, making sure that there are
+// no gaps and that the parts match the client's list (if provided); must be
+// called under lock
+func (upload *mpartUpload) sortedParts(expected []cmn.MPartInfo) (parts []*mpartPart, errstr string) {
+	if len(upload.parts) == 0 {
+		return nil, fmt.Sprintf("Multipart upload %q has no parts", upload.id)
+	}
+	nums := make([]int, 0, len(upload.parts))
+	for num := range upload.parts {
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+	for i, num := range nums {
+		if num != i+1 {
+			return nil, fmt.Sprintf("Multipart upload %q: part %d is missing", upload.id, i+1)
+		}
+	}
+	if len(expected) != 0 {
+		if len(expected) != len(nums) {
+			return nil, fmt.Sprintf("Multipart upload %q: expected %d part(s), have %d", upload.id, len(expected), len(nums))
+		}
+		for _, info := range expected {
+			part, ok := upload.parts[info.PartNum]
+			if !ok {
+				return nil, fmt.Sprintf("Multipart upload %q: part %d is missing", upload.id, info.PartNum)
+			}
+			if info.Cksum != "" && info.Cksum != part.cksum {
+				return nil, fmt.Sprintf("Multipart upload %q: part %d checksum mismatch (expected %s, got %s)",
+					upload.id, info.PartNum, info.Cksum, part.cksum)
+			}
+		}
+	}
+	parts = make([]*mpartPart, 0, len(nums))
+	for _, num := range nums {
+		parts = append(parts, upload.parts[num])
+	}
+	return
+}
+
+// checkActive returns an error if the upload is being completed or has been aborted;
+// must be called under lock
+func (upload *mpartUpload) checkActive() (errstr string, errcode int) {
+	if upload.aborted {
+		return fmt.Sprintf("Multipart upload %q has been aborted", upload.id), http.StatusNotFound
+	}
+	if upload.completing {
+		return fmt.Sprintf("Multipart upload %q is being completed", upload.id), http.StatusConflict
+	}
+	return
+}
+
+// removeParts removes the parts along with the upload metadata
+func (upload *mpartUpload) removeParts() {
+	for _, part := range upload.parts {
+		mpartRemove(part.fqn)
+	}
+	if upload.metaFQN != "" {
+		mpartRemove(upload.metaFQN)
+	}
+}
+
+func (part *mpartPart) recv(r io.Reader) (err error) {
+	file, err := cmn.CreateFile(part.fqn)
+	if err != nil {
+		return
+	}
+	buf, slab := gmem2.AllocFromSlab2(0)
+	h := xxhash.New64()
+	part.size, err = cmn.ReceiveAndChecksum(file, r, buf, h)
+	slab.Free(buf)
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
+	if err != nil {
+		os.Remove(part.fqn)
+		return
+	}
+	part.cksum = cmn.HashToStr(h)
+	part.mtime = time.Now()
+	// the checksum marks the part as fully received (see load)
+	if errstr := fs.SetXattr(part.fqn, cmn.XattrXXHash, []byte(part.cksum)); errstr != "" {
+		os.Remove(part.fqn)
+		err = errors.New(errstr)
+	}
+	return
+}
+
+//
+// target HTTP handlers (the requests are redirected by the proxy)
+//
+
+func (t *targetrunner) mpartPost(w http.ResponseWriter, r *http.Request, msg cmn.ActionMsg) {
+	apitems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objname := apitems[0], apitems[1]
+	if !t.validatebckname(w, r, bucket) {
+		return
+	}
+	if !t.verifyProxyRedirection(w, r, bucket, objname, cmn.Objects) {
+		return
+	}
+	bucketProvider := r.URL.Query().Get(cmn.URLParamBucketProvider)
+	if msg.Action == cmn.ActMPartInit {
+		if t.OOS() {
+			t.invalmsghdlr(w, r, "OOS")
+			return
+		}
+		id, errstr := t.mpart.init(bucket, objname, bucketProvider)
+		if errstr != "" {
+			t.invalmsghdlr(w, r, errstr)
+			return
+		}
+		jsbytes, err := jsoniter.Marshal(&cmn.MPartMsg{UploadID: id})
+		cmn.AssertNoErr(err)
+		t.writeJSON(w, r, jsbytes, "mpartinit")
+		return
+	}
+
+	mpartMsg := &cmn.MPartMsg{}
+	b, err := jsoniter.Marshal(msg.Value)
+	if err == nil {
+		err = jsoniter.Unmarshal(b, mpartMsg)
+	}
+	if err != nil {
+		t.invalmsghdlr(w, r, fmt.Sprintf("Failed to unmarshal %s message, err: %v", msg.Action, err))
+		return
+	}
+	if mpartMsg.UploadID == "" {
+		t.invalmsghdlr(w, r, fmt.Sprintf("%s: upload ID is missing", msg.Action))
+		return
+	}
+	switch msg.Action {
+	case cmn.ActMPartComplete:
+		lom, errstr, errcode := t.mpart.complete(r, bucket, objname, mpartMsg)
+		if errstr != "" {
+			t.invalmsghdlr(w, r, errstr, errcode)
+			return
+		}
+		hdr := w.Header()
+		if lom.Cksum != nil {
+			cksumType, cksumValue := lom.Cksum.Get()
+			hdr.Set(cmn.HeaderObjCksumType, cksumType)
+			hdr.Set(cmn.HeaderObjCksumVal, cksumValue)
+		}
+		if lom.Version != "" {
+			hdr.Set(cmn.HeaderObjVersion, lom.Version)
+		}
+		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(lom.Size, 10))
+	case cmn.ActMPartAbort:
+		if errstr, errcode := t.mpart.abort(bucket, objname, mpartMsg); errstr != "" {
+			t.invalmsghdlr(w, r, errstr, errcode)
+		}
+	}
+}
+
+// PUT /v1/objects/bucket-name/object-name?upload_id=ID&part_num=N
+func (t *targetrunner) mpartPut(w http.ResponseWriter, r *http.Request, bucket, objname string) {
+	part, errstr, errcode := t.mpart.putPart(r, bucket, objname)
+	if errstr != "" {
+		t.invalmsghdlr(w, r, errstr, errcode)
+		return
+	}
+	w.Header().Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+	w.Header().Set(cmn.HeaderObjCksumVal, part.cksum)
+}
@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+func TestMpartContentResolver(t *testing.T) {
+	mr := &mpartContentResolver{}
+	for _, objname := range []string{"obj", "dir/obj.tar", "a.b.c.d.e"} {
+		fqn := mr.GenUniqueFQN(objname, "0123456789abcdef.17")
+		orig, old, ok := mr.ParseUniqueFQN(fqn)
+		if !ok || old || orig != objname {
+			t.Errorf("%s => %s: got (%q, %t, %t)", objname, fqn, orig, old, ok)
+		}
+	}
+	if _, old, ok := mr.ParseUniqueFQN("obj.0123456789abcdef.1.abc.deadbeef"); !ok || !old {
+		t.Errorf("expected a part of another process to be old (%t, %t)", old, ok)
+	}
+	if _, _, ok := mr.ParseUniqueFQN("obj.1.2"); ok {
+		t.Error("expected parsing to fail")
+	}
+}
+
+func TestMpartSortedParts(t *testing.T) {
+	upload := &mpartUpload{
+		id: "id",
+		parts: map[int]*mpartPart{
+			2: {fqn: "2", cksum: "b"},
+			1: {fqn: "1", cksum: "a"},
+			3: {fqn: "3", cksum: "c"},
+		},
+	}
+	parts, errstr := upload.sortedParts(nil)
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if len(parts) != 3 || parts[0].fqn != "1" || parts[1].fqn != "2" || parts[2].fqn != "3" {
+		t.Errorf("parts out of order: %v", parts)
+	}
+	if _, errstr := upload.sortedParts([]cmn.MPartInfo{{PartNum: 1}, {PartNum: 2}, {PartNum: 3, Cksum: "c"}}); errstr != "" {
+		t.Error(errstr)
+	}
+	if _, errstr := upload.sortedParts([]cmn.MPartInfo{{PartNum: 1}, {PartNum: 2}, {PartNum: 3, Cksum: "x"}}); errstr == "" {
+		t.Error("expected checksum mismatch")
+	}
+	if _, errstr := upload.sortedParts([]cmn.MPartInfo{{PartNum: 1}, {PartNum: 2}}); errstr == "" {
+		t.Error("expected number of parts mismatch")
+	}
+	delete(upload.parts, 2)
+	if _, errstr := upload.sortedParts(nil); errstr == "" {
+		t.Error("expected missing part error")
+	}
+}
+
+func TestMpartGC(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mpart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		now   = time.Now()
+		m     = newMpartMgr(nil)
+		fresh = filepath.Join(dir, "fresh")
+		stale = filepath.Join(dir, "stale")
+	)
+	for _, fqn := range []string{fresh, stale} {
+		if err := ioutil.WriteFile(fqn, []byte("part"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	m.uploads["fresh"] = &mpartUpload{id: "fresh", lastActive: now, parts: map[int]*mpartPart{1: {fqn: fresh}}}
+	abandoned := &mpartUpload{id: "stale", lastActive: now.Add(-mpartExpireTime - time.Minute),
+		parts: map[int]*mpartPart{1: {fqn: stale}}}
+	m.uploads["stale"] = abandoned
+	m.gc(now)
+
+	if _, ok := m.uploads["stale"]; ok {
+		t.Error("expected abandoned upload to be removed")
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected abandoned part to be removed, err: %v", err)
+	}
+	// a part that is still being received (the upload is already looked up) must be rejected
+	if errstr, _ := abandoned.checkActive(); errstr == "" {
+		t.Error("expected abandoned upload to be marked as aborted")
+	}
+	if _, ok := m.uploads["fresh"]; !ok {
+		t.Error("expected active upload to stay")
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMpartOwner(t *testing.T) {
+	id := "0123456789abcdef-" + "7461726765742d31" // hex("target-1")
+	if owner := mpartOwner(id); owner != "target-1" {
+		t.Errorf("expected target-1, got %q", owner)
+	}
+	for _, id := range []string{"", "0123456789abcdef", "0123456789abcdef-xyz"} {
+		if owner := mpartOwner(id); owner != "" {
+			t.Errorf("%q: expected no owner, got %q", id, owner)
+		}
+	}
+}
+
+func TestMpartLoad(t *testing.T) {
+	const bucket = "mpart-bck"
+	mpath, err := ioutil.TempDir("", "mpart-load")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mpath)
+	fs.Mountpaths = fs.NewMountedFS()
+	if err := fs.Mountpaths.Add(mpath); err != nil {
+		t.Fatal(err)
+	}
+	fs.CSM.RegisterFileType(fs.ObjectType, &fs.ObjectContentResolver{})
+	fs.CSM.RegisterFileType(mpartType, &mpartContentResolver{})
+	if gmem2 == nil {
+		gmem2 = &memsys.Mem2{Name: "mpart-test"}
+		gmem2.Init(false)
+	}
+
+	tr := &targetrunner{}
+	tr.si = newSnode("target-1", httpProto, httpProto, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{})
+	tr.bmdowner = &bmdowner{}
+	bucketmd := newBucketMD()
+	bucketmd.add(bucket, true, &cmn.BucketProps{})
+	tr.bmdowner.put(bucketmd)
+
+	m := newMpartMgr(tr)
+	id, errstr := m.init(bucket, "dir/obj", cmn.LocalBs)
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if owner := mpartOwner(id); owner != tr.si.DaemonID {
+		t.Errorf("expected upload owned by %s, got %q", tr.si.DaemonID, owner)
+	}
+	putPart := func(num, content string) *mpartPart {
+		r := httptest.NewRequest(http.MethodPut, "/v1/objects/"+bucket+"/dir/obj?upload_id="+id+"&part_num="+num,
+			strings.NewReader(content))
+		part, errstr, _ := m.putPart(r, bucket, "dir/obj")
+		if errstr != "" {
+			t.Fatal(errstr)
+		}
+		return part
+	}
+	putPart("1", "first")
+	replaced := putPart("2", "second")
+	part2 := putPart("2", "SECOND")
+	if _, err := os.Stat(replaced.fqn); !os.IsNotExist(err) {
+		t.Errorf("expected replaced part to be removed, err: %v", err)
+	}
+
+	// a part that does not belong to any upload and a part that was not fully received
+	lom := &cluster.LOM{T: tr, Bucket: bucket, Objname: "dir/obj"}
+	if errstr := lom.Fill(cmn.LocalBs, 0); errstr != "" {
+		t.Fatal(errstr)
+	}
+	orphan := lom.GenFQN(mpartType, "fedcba9876543210-00.1")
+	partial := lom.GenFQN(mpartType, id+".3")
+	for _, fqn := range []string{orphan, partial} {
+		if err := ioutil.WriteFile(fqn, []byte("part"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// restart
+	m = newMpartMgr(tr)
+	m.load(time.Now())
+	upload, errstr, _ := m.get(id, bucket, "dir/obj")
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if len(upload.parts) != 2 || upload.parts[2].fqn != part2.fqn || upload.parts[2].cksum != part2.cksum ||
+		upload.parts[2].size != int64(len("SECOND")) {
+		t.Errorf("unexpected parts after restart: %+v", upload.parts)
+	}
+	for _, fqn := range []string{orphan, partial} {
+		if _, err := os.Stat(fqn); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, err: %v", fqn, err)
+		}
+	}
+
+	// restart after the upload has expired
+	m = newMpartMgr(tr)
+	m.load(time.Now().Add(mpartExpireTime + time.Minute))
+	if len(m.uploads) != 0 {
+		t.Errorf("expected expired upload to be removed, got %d upload(s)", len(m.uploads))
+	}
+	for _, fqn := range []string{upload.metaFQN, upload.parts[1].fqn, upload.parts[2].fqn} {
+		if _, err := os.Stat(fqn); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be removed, err: %v", fqn, err)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	posixTmpPrefix = ".ais.tmp." // in-progress PUTs; never listed
+)
+
+//======
+//
+// implements cloudif
+//
+//======
+// posiximpl treats a directory tree (e.g., an NFS mount) as the Cloud:
+// each top-level subdirectory of the configured root is a bucket, and each
+// regular file underneath it is an object named by its relative path.
+// The object's version is its modification time in nanoseconds.
+type (
+	posiximpl struct {
+		t    *targetrunner
+		root string
+	}
+)
+
+var (
+	_ cloudif = &posiximpl{}
+)
+
+func newPosixProvider(t *targetrunner) *posiximpl {
+	return &posiximpl{t: t, root: filepath.Clean(cmn.GCO.Get().Posix.Root)}
+}
+
+func posixVersion(finfo os.FileInfo) string {
+	return strconv.FormatInt(finfo.ModTime().UnixNano(), 10)
+}
+
+func posixErrorToHTTP(err error) int {
+	if os.IsNotExist(err) {
+		return http.StatusNotFound
+	}
+	if os.IsPermission(err) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func (posix *posiximpl) bucketPath(bucket string) (dir, errstr string, errcode int) {
+	if bucket == "" || strings.ContainsRune(bucket, filepath.Separator) || bucket == "." || bucket == ".." {
+		return "", fmt.Sprintf("Invalid bucket name %q", bucket), http.StatusBadRequest
+	}
+	dir = filepath.Join(posix.root, bucket)
+	finfo, err := os.Stat(dir)
+	if err != nil {
+		return "", fmt.Sprintf("The bucket %s either %s or is not accessible, err: %v", bucket, cmn.DoesNotExist, err),
+			posixErrorToHTTP(err)
+	}
+	if !finfo.IsDir() {
+		return "", fmt.Sprintf("The bucket %s %s (%s is not a directory)", bucket, cmn.DoesNotExist, dir), http.StatusNotFound
+	}
+	return
+}
+
+// objPath returns the file path of a given object, making sure that the object
+// does not escape its bucket
+func (posix *posiximpl) objPath(bucket, objname string) (fqn, errstr string, errcode int) {
+	var dir string
+	if dir, errstr, errcode = posix.bucketPath(bucket); errstr != "" {
+		return
+	}
+	fqn = filepath.Join(dir, objname)
+	if !strings.HasPrefix(fqn, dir+string(filepath.Separator)) ||
+		strings.HasPrefix(filepath.Base(fqn), posixTmpPrefix) {
+		return "", fmt.Sprintf("Invalid object name %s/%s", bucket, objname), http.StatusBadRequest
+	}
+	return
+}
+
+//==================
+//
+// bucket operations
+//
+//==================
+func (posix *posiximpl) listbucket(ct context.Context, bucket string, msg *cmn.GetMsg) (jsbytes []byte, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("listbucket %s", bucket)
+	}
+	dir, errstr, errcode := posix.bucketPath(bucket)
+	if errstr != "" {
+		return
+	}
+	pageSize := cmn.DefaultPageSize
+	if msg.GetPageSize != 0 {
+		pageSize = msg.GetPageSize
+	}
+
+	// start walking from the deepest directory that is fully covered by the prefix
+	walkRoot := dir
+	if idx := strings.LastIndex(msg.GetPrefix, "/"); idx > 0 {
+		walkRoot = filepath.Join(dir, msg.GetPrefix[:idx])
+		if walkRoot != dir && !strings.HasPrefix(walkRoot, dir+string(filepath.Separator)) {
+			return nil, fmt.Sprintf("Invalid prefix %q for bucket %s", msg.GetPrefix, bucket), http.StatusBadRequest
+		}
+	}
+	type posixEntry struct {
+		name  string
+		finfo os.FileInfo
+	}
+	entries := make([]posixEntry, 0, initialBucketListSize)
+	err := filepath.Walk(walkRoot, func(fqn string, finfo os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !finfo.Mode().IsRegular() || strings.HasPrefix(finfo.Name(), posixTmpPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, fqn)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, msg.GetPrefix) || (msg.GetPageMarker != "" && name <= msg.GetPageMarker) {
+			return nil
+		}
+		entries = append(entries, posixEntry{name: name, finfo: finfo})
+		return nil
+	})
+	if err != nil {
+		errstr = fmt.Sprintf("Failed to list bucket %s, err: %v", bucket, err)
+		errcode = posixErrorToHTTP(err)
+		return
+	}
+	// the walk is lexical per directory; the listing must be lexical per full name
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	var reslist = cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, cmn.Min(len(entries), pageSize))}
+	for _, e := range entries {
+		if len(reslist.Entries) == pageSize {
+			reslist.PageMarker = reslist.Entries[pageSize-1].Name
+			break
+		}
+		entry := &cmn.BucketEntry{Name: e.name}
+		if strings.Contains(msg.GetProps, cmn.GetPropsSize) {
+			entry.Size = e.finfo.Size()
+		}
+		if strings.Contains(msg.GetProps, cmn.GetPropsCtime) {
+			t := e.finfo.ModTime()
+			switch msg.GetTimeFormat {
+			case "":
+				fallthrough
+			case cmn.RFC822:
+				entry.Ctime = t.Format(time.RFC822)
+			default:
+				entry.Ctime = t.Format(msg.GetTimeFormat)
+			}
+		}
+		if strings.Contains(msg.GetProps, cmn.GetPropsVersion) {
+			entry.Version = posixVersion(e.finfo)
+		}
+		reslist.Entries = append(reslist.Entries, entry)
+	}
+	if glog.V(4) {
+		glog.Infof("listbucket count %d", len(reslist.Entries))
+	}
+
+	jsbytes, err = jsoniter.Marshal(reslist)
+	cmn.AssertNoErr(err)
+	return
+}
+
+func (posix *posiximpl) headbucket(ct context.Context, bucket string) (bucketprops cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headbucket %s", bucket)
+	}
+	if _, errstr, errcode = posix.bucketPath(bucket); errstr != "" {
+		return
+	}
+	bucketprops = make(cmn.SimpleKVs)
+	bucketprops[cmn.HeaderCloudProvider] = cmn.ProviderPosix
+	bucketprops[cmn.HeaderVersioning] = cmn.VersionCloud
+	return
+}
+
+func (posix *posiximpl) getbucketnames(ct context.Context) (buckets []string, errstr string, errcode int) {
+	finfos, err := ioutil.ReadDir(posix.root)
+	if err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to list all buckets, err: %v", err)
+		return
+	}
+	buckets = make([]string, 0, len(finfos))
+	for _, finfo := range finfos {
+		if finfo.IsDir() {
+			buckets = append(buckets, finfo.Name())
+		}
+	}
+	return
+}
+
+//============
+//
+// object meta
+//
+//============
+func (posix *posiximpl) headobject(ct context.Context, bucket string, objname string) (objmeta cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headobject %s/%s", bucket, objname)
+	}
+	fqn, errstr, errcode := posix.objPath(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil || !finfo.Mode().IsRegular() {
+		errcode = http.StatusNotFound
+		if err != nil {
+			errcode = posixErrorToHTTP(err)
+		}
+		errstr = fmt.Sprintf("Failed to retrieve %s/%s metadata, err: %v", bucket, objname, err)
+		return
+	}
+	objmeta = make(cmn.SimpleKVs)
+	objmeta[cmn.HeaderCloudProvider] = cmn.ProviderPosix
+	objmeta[cmn.HeaderObjVersion] = posixVersion(finfo)
+	objmeta[cmn.HeaderObjSize] = strconv.FormatInt(finfo.Size(), 10)
+	return
+}
+
+//=======================
+//
+// object data operations
+//
+//=======================
+func (posix *posiximpl) getobj(ct context.Context, workFQN, bucket, objname string) (lom *cluster.LOM, errstr string, errcode int) {
+	fqn, errstr, errcode := posix.objPath(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	file, err := os.Open(fqn)
+	if err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to GET %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	finfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to GET %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	lom = &cluster.LOM{T: posix.t, Bucket: bucket, Objname: objname}
+	lom.Version = posixVersion(finfo)
+	if errstr = lom.Fill(cmn.CloudBs, 0); errstr != "" {
+		file.Close()
+		return
+	}
+	roi := &recvObjInfo{
+		t:       posix.t,
+		cold:    true,
+		r:       file, // closed by writeToFile
+		lom:     lom,
+		workFQN: workFQN,
+	}
+	if err := roi.writeToFile(); err != nil {
+		errstr = err.Error()
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("GET %s/%s", bucket, objname)
+	}
+	return
+}
+
+// putobj writes the object into a temporary file next to its destination
+// and renames it, so that concurrent readers never see a partial object
+func (posix *posiximpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int) {
+	fqn, errstr, errcode := posix.objPath(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	dir := filepath.Dir(fqn)
+	if err := cmn.CreateDir(dir); err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to PUT %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	tmp, err := ioutil.TempFile(dir, posixTmpPrefix)
+	if err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to PUT %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	if _, err = io.Copy(tmp, file); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), fqn)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to PUT %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to PUT %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	version = posixVersion(finfo)
+	if glog.V(4) {
+		glog.Infof("PUT %s/%s, version %s", bucket, objname, version)
+	}
+	return
+}
+
+func (posix *posiximpl) deleteobj(ct context.Context, bucket, objname string) (errstr string, errcode int) {
+	fqn, errstr, errcode := posix.objPath(bucket, objname)
+	if errstr != "" {
+		return
+	}
+	if err := os.Remove(fqn); err != nil {
+		errcode = posixErrorToHTTP(err)
+		errstr = fmt.Sprintf("Failed to DELETE %s/%s, err: %v", bucket, objname, err)
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("DELETE %s/%s", bucket, objname)
+	}
+	return
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func posixListNames(t *testing.T, posix *posiximpl, bucket string, msg *cmn.GetMsg) (names []string, pageMarker string) {
+	jsbytes, errstr, _ := posix.listbucket(context.Background(), bucket, msg)
+	if errstr != "" {
+		t.Fatalf("listbucket failed: %s", errstr)
+	}
+	reslist := &cmn.BucketList{}
+	if err := jsoniter.Unmarshal(jsbytes, reslist); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range reslist.Entries {
+		names = append(names, e.Name)
+	}
+	return names, reslist.PageMarker
+}
+
+func TestPosixCloud(t *testing.T) {
+	root, err := ioutil.TempDir("", "posix-cloud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	var (
+		ctx   = context.Background()
+		posix = &posiximpl{root: root}
+		objs  = []string{"a.txt", "a/b", "a/c/d", "b"}
+	)
+	for _, objname := range objs {
+		fqn := filepath.Join(root, "bck", objname)
+		if err := cmn.CreateDir(filepath.Dir(fqn)); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(fqn, []byte(objname), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buckets, errstr, _ := posix.getbucketnames(ctx)
+	if errstr != "" || len(buckets) != 1 || buckets[0] != "bck" {
+		t.Fatalf("expected [bck], got %v (%s)", buckets, errstr)
+	}
+	if _, errstr, errcode := posix.headbucket(ctx, "nonexisting"); errcode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d (%s)", http.StatusNotFound, errcode, errstr)
+	}
+
+	// full listing is sorted by full name
+	names, pageMarker := posixListNames(t, posix, "bck", &cmn.GetMsg{})
+	if len(names) != len(objs) || pageMarker != "" {
+		t.Fatalf("expected %v, got %v (page marker %q)", objs, names, pageMarker)
+	}
+	for i := range objs {
+		if names[i] != objs[i] {
+			t.Fatalf("expected %v, got %v", objs, names)
+		}
+	}
+	// prefix
+	names, _ = posixListNames(t, posix, "bck", &cmn.GetMsg{GetPrefix: "a/c"})
+	if len(names) != 1 || names[0] != "a/c/d" {
+		t.Errorf("expected [a/c/d], got %v", names)
+	}
+	// paging
+	names, pageMarker = posixListNames(t, posix, "bck", &cmn.GetMsg{GetPageSize: 3})
+	if len(names) != 3 || pageMarker != "a/c/d" {
+		t.Errorf("expected 3 names and page marker a/c/d, got %v (%q)", names, pageMarker)
+	}
+	names, pageMarker = posixListNames(t, posix, "bck", &cmn.GetMsg{GetPageSize: 3, GetPageMarker: pageMarker})
+	if len(names) != 1 || names[0] != "b" || pageMarker != "" {
+		t.Errorf("expected [b] and no page marker, got %v (%q)", names, pageMarker)
+	}
+
+	// put, head, delete
+	src := filepath.Join(root, "src")
+	if err := ioutil.WriteFile(src, []byte("new object"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, errstr, _ := posix.putobj(ctx, file, "bck", "x/y/z", nil, nil)
+	file.Close()
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	objmeta, errstr, _ := posix.headobject(ctx, "bck", "x/y/z")
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if objmeta[cmn.HeaderObjVersion] != version || objmeta[cmn.HeaderObjSize] != "10" {
+		t.Errorf("unexpected object metadata %v (version %s)", objmeta, version)
+	}
+	if errstr, _ := posix.deleteobj(ctx, "bck", "x/y/z"); errstr != "" {
+		t.Fatal(errstr)
+	}
+	if _, _, errcode := posix.headobject(ctx, "bck", "x/y/z"); errcode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d", http.StatusNotFound, errcode)
+	}
+
+	// objects must not escape their buckets
+	if _, errstr, _ := posix.headobject(ctx, "bck", "../src"); errstr == "" {
+		t.Error("expected error accessing an object outside of the bucket")
+	}
+	for _, prefix := range []string{"../", "../../etc/", "a/../../src"} {
+		_, errstr, errcode := posix.listbucket(ctx, "bck", &cmn.GetMsg{GetPrefix: prefix})
+		if errstr == "" || errcode != http.StatusBadRequest {
+			t.Errorf("prefix %q: expected %d, got %d (%s)", prefix, http.StatusBadRequest, errcode, errstr)
+		}
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -140,19 +141,27 @@ func (p *proxyrunner) Run() error {
 		p.registerPublicNetHandler("/", cmn.InvalidHandler)
 	}
 
-	bucketHandler, objectHandler := p.bucketHandler, p.objectHandler
+	var (
+		bucketHandler, objectHandler, s3Handler = p.bucketHandler, p.objectHandler, p.s3Handler
+		daemonHandler, clusterHandler           = p.daemonHandler, p.clusterHandler
+		downloadHandler, sortHandler            = p.downloadHandler, http.HandlerFunc(dsort.ProxySortHandler)
+	)
 	if config.Auth.Enabled {
 		bucketHandler, objectHandler = wrapHandler(p.bucketHandler, p.checkHTTPAuth), wrapHandler(p.objectHandler, p.checkHTTPAuth)
+		s3Handler = wrapHandler(p.s3Handler, p.s3CheckHTTPAuth)
+		daemonHandler, clusterHandler = wrapHandler(p.daemonHandler, p.checkHTTPAuth), wrapHandler(p.clusterHandler, p.checkHTTPAuth)
+		downloadHandler, sortHandler = wrapHandler(p.downloadHandler, p.checkHTTPAuth), wrapHandler(sortHandler, p.checkHTTPAuth)
 	}
 
 	networkHandlers := []networkHandler{
 		networkHandler{r: cmn.Buckets, h: bucketHandler, net: []string{cmn.NetworkPublic}},
 		networkHandler{r: cmn.Objects, h: objectHandler, net: []string{cmn.NetworkPublic}},
-		networkHandler{r: cmn.Download, h: p.downloadHandler, net: []string{cmn.NetworkPublic}},
-		networkHandler{r: cmn.Daemon, h: p.daemonHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
-		networkHandler{r: cmn.Cluster, h: p.clusterHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
+		networkHandler{r: cmn.Download, h: downloadHandler, net: []string{cmn.NetworkPublic}},
+		networkHandler{r: cmn.Daemon, h: daemonHandler, net: []string{cmn.NetworkPublic}},
+		networkHandler{r: cmn.Cluster, h: clusterHandler, net: []string{cmn.NetworkPublic}},
 		networkHandler{r: cmn.Tokens, h: p.tokenHandler, net: []string{cmn.NetworkPublic}},
-		networkHandler{r: cmn.Sort, h: dsort.ProxySortHandler, net: []string{cmn.NetworkPublic}},
+		networkHandler{r: cmn.Sort, h: sortHandler, net: []string{cmn.NetworkPublic}},
+		networkHandler{r: cmn.S3, h: s3Handler, net: []string{cmn.NetworkPublic}},
 
 		networkHandler{r: cmn.Metasync, h: p.metasyncHandler, net: []string{cmn.NetworkIntraControl}},
 		networkHandler{r: cmn.Health, h: p.healthHandler, net: []string{cmn.NetworkIntraControl}},
@@ -160,7 +169,16 @@ func (p *proxyrunner) Run() error {
 
 		networkHandler{r: "/", h: cmn.InvalidHandler, net: []string{cmn.NetworkIntraControl, cmn.NetworkIntraData}},
 	}
+	// the intra-control network is trusted: cluster members do not authenticate there
+	// (on the public network they attach a cluster token - see clusterToken)
+	if config.Net.UseIntraControl {
+		networkHandlers = append(networkHandlers,
+			networkHandler{r: cmn.Daemon, h: p.daemonHandler, net: []string{cmn.NetworkIntraControl}},
+			networkHandler{r: cmn.Cluster, h: p.clusterHandler, net: []string{cmn.NetworkIntraControl}},
+		)
+	}
 	p.registerNetworkHandlers(networkHandlers)
+	p.registerPublicNetHandler(cmn.URLPath(cmn.Metrics), p.metricsHandler) // Prometheus
 
 	glog.Infof("%s: [public net] listening on: %s", pname(p.si), p.si.PublicNet.DirectURL)
 	if p.si.PublicNet.DirectURL != p.si.IntraControlNet.DirectURL {
@@ -174,7 +192,7 @@ func (p *proxyrunner) Run() error {
 	}
 	p.starttime = time.Now()
 
-	dsort.RegisterNode(p.smapowner, p.si, nil, nil)
+	dsort.RegisterNode(p.smapowner, p.bmdowner, p.si, nil, nil)
 	return p.httprunner.run()
 }
 
@@ -384,11 +402,18 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	span := tracing.StartFromRequest(r, "proxy.get")
+	span.Tag("object", bucket+"/"+objname)
+	span.Tag("target", si.DaemonID)
+	defer span.Finish()
+	r = r.WithContext(tracing.NewContext(r.Context(), span))
+
 	config := cmn.GCO.Get()
 	if config.Net.HTTP.RevProxy == cmn.RevProxyTarget {
 		if glog.V(4) {
 			glog.Infof("reverse-proxy: %s %s/%s <= %s", r.Method, bucket, objname, si)
 		}
+		span.Inject(r.Header)
 		p.reverseDP(w, r, si)
 		delta := time.Since(started)
 		p.statsif.Add(stats.GetLatency, int64(delta))
@@ -415,6 +440,9 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 				}
 			}(redirecturl)
 		}
+		if span != nil {
+			w.Header().Set(cmn.HeaderTraceID, span.TraceID)
+		}
 		http.Redirect(w, r, redirecturl, http.StatusMovedPermanently)
 	}
 	p.statsif.Add(stats.GetCount, 1)
@@ -432,7 +460,7 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	//
 	bucket, objname := apitems[0], apitems[1]
 	smap := p.smapowner.get()
-	si, errstr := hrwTarget(bucket, objname, smap)
+	si, errstr := mpartTarget(r.URL.Query().Get(cmn.URLParamUploadID), bucket, objname, smap)
 	if errstr != "" {
 		p.invalmsghdlr(w, r, errstr)
 		return
@@ -480,29 +508,10 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		if p.forwardCP(w, r, &msg, bucket, nil) {
 			return
 		}
-		bucketmd := p.bmdowner.get()
-		if !bucketmd.IsLocal(bucket) {
-			p.invalmsghdlr(w, r, fmt.Sprintf("Bucket %s does not appear to be local", bucket))
-			return
-		}
-		p.bmdowner.Lock()
-		clone := bucketmd.clone()
-		if !clone.del(bucket, true) {
-			p.bmdowner.Unlock()
-			s := fmt.Sprintf("Local bucket %s "+cmn.DoesNotExist, bucket)
-			p.invalmsghdlr(w, r, s)
-			return
-		}
-		if errstr := p.savebmdconf(clone, cmn.GCO.Get()); errstr != "" {
-			p.bmdowner.Unlock()
-			p.invalmsghdlr(w, r, errstr)
+		if err := p.destroyLocalBucket(&msg, bucket); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
 			return
 		}
-		p.bmdowner.put(clone)
-		p.bmdowner.Unlock()
-
-		msgInt := p.newActionMsgInternal(&msg, nil, clone)
-		p.metasyncer.sync(true, clone, msgInt)
 	case cmn.ActEvictCB:
 		// Check that users didn't specify bprovider=cloud
 		if bucketProvider == "" {
@@ -690,6 +699,16 @@ func (p *proxyrunner) healthHandler(w http.ResponseWriter, r *http.Request) {
 	p.writeJSON(w, r, jsbytes, "proxycorestats")
 }
 
+// GET /metrics: stats in the Prometheus text exposition format
+func (p *proxyrunner) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /metrics path")
+		return
+	}
+	w.Header().Set("Content-Type", stats.PromContentType)
+	getproxystatsrunner().WritePrometheus(w, p.si.DaemonID, gmem2)
+}
+
 func (p *proxyrunner) createLocalBucket(msg *cmn.ActionMsg, bucket string) error {
 	config := cmn.GCO.Get()
 
@@ -715,6 +734,28 @@ func (p *proxyrunner) createLocalBucket(msg *cmn.ActionMsg, bucket string) error
 	return nil
 }
 
+func (p *proxyrunner) destroyLocalBucket(msg *cmn.ActionMsg, bucket string) error {
+	bucketmd := p.bmdowner.get()
+	if !bucketmd.IsLocal(bucket) {
+		return fmt.Errorf("bucket %s does not appear to be local", bucket)
+	}
+	p.bmdowner.Lock()
+	clone := p.bmdowner.get().clone()
+	if !clone.del(bucket, true) {
+		p.bmdowner.Unlock()
+		return fmt.Errorf("local bucket %s %s", bucket, cmn.DoesNotExist)
+	}
+	if errstr := p.savebmdconf(clone, cmn.GCO.Get()); errstr != "" {
+		p.bmdowner.Unlock()
+		return errors.New(errstr)
+	}
+	p.bmdowner.put(clone)
+	p.bmdowner.Unlock()
+	msgInt := p.newActionMsgInternal(msg, nil, clone)
+	p.metasyncer.sync(true, clone, msgInt)
+	return nil
+}
+
 // POST { action } /v1/buckets/bucket-name
 func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	started := time.Now()
@@ -795,6 +836,16 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		p.listBucketAndCollectStats(w, r, bucket, bucketProvider, msg, started)
 	case cmn.ActEraseCopies:
 		p.eraseCopies(w, r, bucket, &msg, config)
+	case cmn.ActCopyBucket:
+		if p.forwardCP(w, r, &msg, "", nil) {
+			return
+		}
+		p.copyBucket(w, r, bucket, &msg, config)
+	case cmn.ActCopyBucketAbort:
+		if p.forwardCP(w, r, &msg, "", nil) {
+			return
+		}
+		p.bcastCopyBucket(w, r, bucket, &msg, config)
 	default:
 		s := fmt.Sprintf("Unexpected cmn.ActionMsg <- JSON [%v]", msg)
 		p.invalmsghdlr(w, r, s)
@@ -829,6 +880,10 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	if !p.validatebckname(w, r, lbucket) {
 		return
 	}
+	if r.URL.Query().Get(cmn.URLParamAction) == cmn.ActAppend {
+		p.appendObject(w, r)
+		return
+	}
 	if cmn.ReadJSON(w, r, &msg) != nil {
 		return
 	}
@@ -839,6 +894,9 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	case cmn.ActReplicate:
 		p.replicate(w, r, &msg)
 		return
+	case cmn.ActMPartInit, cmn.ActMPartComplete, cmn.ActMPartAbort:
+		p.mpart(w, r, &msg)
+		return
 	default:
 		s := fmt.Sprintf("Unexpected cmn.ActionMsg <- JSON [%v]", msg)
 		p.invalmsghdlr(w, r, s)
@@ -900,6 +958,14 @@ func (p *proxyrunner) updateBucketProp(w http.ResponseWriter, r *http.Request, b
 	errFmt := "Invalid %s value %q: %v"
 	propName := strings.ToLower(name)
 	switch propName {
+	case cmn.HeaderOriginURL:
+		if proxyLocal {
+			errStr = fmt.Sprintf("Local bucket %s cannot be bound to origin URL", bucket)
+		} else if err := validateOriginURL(value); err != nil {
+			errStr = err.Error()
+		} else {
+			bprops.OriginURL = value
+		}
 	case cmn.HeaderBucketECEnabled:
 		if v, err := strconv.ParseBool(value); err == nil {
 			if v {
@@ -1243,6 +1309,58 @@ func (p *proxyrunner) eraseCopies(w http.ResponseWriter, r *http.Request, bucket
 	}
 }
 
+// copyBucket creates the destination local bucket if need be and starts copying
+// on all targets; the progress can be monitored via the xaction stats API
+func (p *proxyrunner) copyBucket(w http.ResponseWriter, r *http.Request, bucketFrom string, actionMsg *cmn.ActionMsg,
+	config *cmn.Config) {
+	bucketTo := actionMsg.Name
+	if bucketTo == "" || bucketTo == bucketFrom {
+		p.invalmsghdlr(w, r, fmt.Sprintf("Invalid copy bucket request: %q => %q", bucketFrom, bucketTo))
+		return
+	}
+	if !p.validatebckname(w, r, bucketTo) {
+		return
+	}
+	if !p.bmdowner.get().IsLocal(bucketTo) {
+		createMsg := &cmn.ActionMsg{Action: cmn.ActCreateLB, Name: bucketTo}
+		if err := p.createLocalBucket(createMsg, bucketTo); err != nil && !p.bmdowner.get().IsLocal(bucketTo) {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		glog.Infof("%s: created local bucket %s", actionMsg.Action, bucketTo)
+	}
+	p.bcastCopyBucket(w, r, bucketFrom, actionMsg, config)
+}
+
+func (p *proxyrunner) bcastCopyBucket(w http.ResponseWriter, r *http.Request, bucketFrom string,
+	actionMsg *cmn.ActionMsg, config *cmn.Config) {
+	smap := p.smapowner.get()
+	msgInt := p.newActionMsgInternal(actionMsg, smap, p.bmdowner.get())
+	jsbytes, err := jsoniter.Marshal(msgInt)
+	cmn.AssertNoErr(err)
+	results := p.broadcastTo(
+		cmn.URLPath(cmn.Version, cmn.Buckets, bucketFrom),
+		r.URL.Query(),
+		http.MethodPost,
+		jsbytes,
+		smap,
+		config.Timeout.CplaneOperation,
+		cmn.NetworkIntraControl,
+		cluster.Targets,
+	)
+	for res := range results {
+		if res.err != nil {
+			s := fmt.Sprintf("Failed to %s, %s, bucket %s, err: %v(%d)",
+				actionMsg.Action, tname(res.si), bucketFrom, res.err, res.status)
+			if res.errstr != "" {
+				glog.Errorln(res.errstr)
+			}
+			p.invalmsghdlr(w, r, s)
+			return
+		}
+	}
+}
+
 func (p *proxyrunner) getbucketnames(w http.ResponseWriter, r *http.Request, bucketspec, bucketProvider string) {
 	bucketmd := p.bmdowner.get()
 	bckProviderStr := "?" + cmn.URLParamBucketProvider + "=" + bucketProvider
@@ -1298,6 +1416,7 @@ func (p *proxyrunner) redirectURL(r *http.Request, to string, ts time.Time, buck
 	query.Add(cmn.URLParamProxyID, p.si.DaemonID)
 	query.Add(cmn.URLParamBMDVersion, bucketmd.vstr)
 	query.Add(cmn.URLParamUnixTime, strconv.FormatInt(int64(ts.UnixNano()), 10))
+	tracing.FromContext(r.Context()).AddToQuery(query)
 	redirect += query.Encode()
 	return
 }
@@ -1373,6 +1492,7 @@ func (p *proxyrunner) consumeCachedList(bmap map[string]*cmn.BucketEntry, dataCh
 			if entry, ok := bmap[nm]; ok && !entry.IsCached {
 				entry.Atime = newEntry.Atime
 				entry.Status = newEntry.Status
+				entry.UserMeta = newEntry.UserMeta
 				// Status not OK means the object is temporarily misplaced and
 				// the object cannot be marked as cached.
 				// Such objects will retrieve data from Cloud on GET request
@@ -1496,14 +1616,14 @@ func (p *proxyrunner) collectCachedFileList(bucket, bucketProvider string, fileL
 	return
 }
 
-func (p *proxyrunner) getLocalBucketObjects(bucket, bucketProvider string, listmsgjson []byte) (allEntries *cmn.BucketList, err error) {
+// getLocalBucketObjects lists a local bucket or, if cachedObjs is true, the objects
+// of a Cloud bucket that are cached in the cluster
+func (p *proxyrunner) getLocalBucketObjects(bucket, bucketProvider string, listmsgjson []byte,
+	cachedObjs bool) (allEntries *cmn.BucketList, err error) {
 	type targetReply struct {
 		resp *bucketResp
 		err  error
 	}
-	const (
-		cachedObjs = false
-	)
 	msg := &cmn.GetMsg{}
 	if err = jsoniter.Unmarshal(listmsgjson, msg); err != nil {
 		return
@@ -1633,6 +1753,7 @@ func (p *proxyrunner) getCloudBucketObjects(r *http.Request, bucket, bucketProvi
 	if strings.Contains(msg.GetProps, cmn.GetPropsAtime) ||
 		strings.Contains(msg.GetProps, cmn.GetPropsStatus) ||
 		strings.Contains(msg.GetProps, cmn.GetPropsCopies) ||
+		strings.Contains(msg.GetProps, cmn.GetPropsUserMeta) ||
 		strings.Contains(msg.GetProps, cmn.GetPropsIsCached) {
 		// Now add local properties to the cloud objects
 		// The call replaces allentries.Entries with new values
@@ -1650,19 +1771,41 @@ func (p *proxyrunner) getCloudBucketObjects(r *http.Request, bucket, bucketProvi
 //      * get list of cached files info from all targets
 //      * updates the list of objects from the cloud with cached info
 //   - returns the list
+// Filtered listing (GetMsg.GetFilter) of either bucket:
+//   - targets apply the filters to the objects they store, which means that
+//     a filtered listing of a Cloud bucket includes only the cached objects
 func (p *proxyrunner) listbucket(w http.ResponseWriter, r *http.Request, bucket, bucketProvider string, actionMsg *cmn.ActionMsg) (pagemarker string, ok bool) {
-	var allentries *cmn.BucketList
+	var (
+		allentries *cmn.BucketList
+		msg        cmn.GetMsg
+	)
 	listmsgjson, err := jsoniter.Marshal(actionMsg.Value)
 	if err != nil {
 		s := fmt.Sprintf("Unable to marshal action message: %v. Error: %v", actionMsg, err)
 		p.invalmsghdlr(w, r, s)
 		return
 	}
+	if err = jsoniter.Unmarshal(listmsgjson, &msg); err != nil {
+		s := fmt.Sprintf("Unable to unmarshal 'value' in request to a cmn.GetMsg: %v", actionMsg.Value)
+		p.invalmsghdlr(w, r, s)
+		return
+	}
+	if _, err = newListFilter(msg.GetFilter); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	var (
+		bckIsLocal = bucketProvider != cmn.CloudBs && p.bmdowner.get().IsLocal(bucket)
+		cachedObjs = !bckIsLocal && msg.GetFilter != nil
+	)
+	if msg.GetStream {
+		return p.listbucketStream(w, r, bucket, bucketProvider, &msg, bckIsLocal || cachedObjs, cachedObjs)
+	}
 
-	if bucketProvider == cmn.CloudBs || !p.bmdowner.get().IsLocal(bucket) {
+	if !bckIsLocal && !cachedObjs {
 		allentries, err = p.getCloudBucketObjects(r, bucket, bucketProvider, listmsgjson)
 	} else {
-		allentries, err = p.getLocalBucketObjects(bucket, bucketProvider, listmsgjson)
+		allentries, err = p.getLocalBucketObjects(bucket, bucketProvider, listmsgjson, cachedObjs)
 	}
 	if err != nil {
 		p.invalmsghdlr(w, r, err.Error())
@@ -1675,6 +1818,60 @@ func (p *proxyrunner) listbucket(w http.ResponseWriter, r *http.Request, bucket,
 	return
 }
 
+// listbucketStream writes the listing as newline-delimited JSON, one cmn.BucketEntry
+// per line, while getting it from the targets (or the Cloud) page by page - so that
+// neither the proxy nor the client have to keep the entire listing in memory.
+// Once the first page is written, errors can only be reported via the "Error"
+// trailer (see cmn.ReadBytes)
+func (p *proxyrunner) listbucketStream(w http.ResponseWriter, r *http.Request, bucket, bucketProvider string,
+	msg *cmn.GetMsg, fromTargets, cachedObjs bool) (pagemarker string, ok bool) {
+	var (
+		page    *cmn.BucketList
+		started bool
+		hdr     = w.Header()
+	)
+	hdr.Set("Content-Type", "application/x-ndjson")
+	hdr.Set("Trailer", "Error")
+	msg.GetStream = false
+	for {
+		listmsgjson, err := jsoniter.Marshal(msg)
+		cmn.AssertNoErr(err)
+		if fromTargets {
+			page, err = p.getLocalBucketObjects(bucket, bucketProvider, listmsgjson, cachedObjs)
+		} else {
+			page, err = p.getCloudBucketObjects(r, bucket, bucketProvider, listmsgjson)
+		}
+		if err != nil {
+			if !started {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
+			glog.Errorf("Failed to list bucket %s (page %s), err: %v", bucket, msg.GetPageMarker, err)
+			hdr.Set("Error", err.Error())
+			return
+		}
+		for _, entry := range page.Entries {
+			// one entry per line (newline-delimited JSON)
+			b, err := jsoniter.Marshal(entry)
+			cmn.AssertNoErr(err)
+			if _, err = w.Write(append(b, '\n')); err != nil {
+				glog.Errorf("Failed to stream bucket %s listing, err: %v", bucket, err)
+				p.statsif.AddErrorHTTP(r.Method, 1)
+				return
+			}
+		}
+		started = true
+		if flusher, isFlusher := w.(http.Flusher); isFlusher {
+			flusher.Flush()
+		}
+		if page.PageMarker == "" {
+			ok = true
+			return
+		}
+		pagemarker, msg.GetPageMarker = page.PageMarker, page.PageMarker
+	}
+}
+
 func (p *proxyrunner) savebmdconf(bucketmd *bucketMD, config *cmn.Config) (errstr string) {
 	bucketmdfull := filepath.Join(config.Confdir, cmn.BucketmdBackupFile)
 	if err := cmn.LocalSave(bucketmdfull, bucketmd); err != nil {
@@ -1715,6 +1912,82 @@ func (p *proxyrunner) filrename(w http.ResponseWriter, r *http.Request, msg *cmn
 	p.statsif.Add(stats.RenameCount, 1)
 }
 
+// append: the data (request body) is redirected to the target that stores the object
+func (p *proxyrunner) appendObject(w http.ResponseWriter, r *http.Request) {
+	started := time.Now()
+	apitems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objname := apitems[0], apitems[1]
+	bucketProvider := r.URL.Query().Get(cmn.URLParamBucketProvider)
+	bckIsLocal, errstr := p.validateBucketProvider(bucketProvider, bucket)
+	if errstr != "" {
+		p.invalmsghdlr(w, r, errstr)
+		return
+	}
+	if !bckIsLocal {
+		p.invalmsghdlr(w, r, fmt.Sprintf("Append is supported only for local buckets (%s does not appear to be local)", bucket))
+		return
+	}
+	si, errstr := hrwTarget(bucket, objname, p.smapowner.get())
+	if errstr != "" {
+		p.invalmsghdlr(w, r, errstr)
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("%s %s/%s => %s", cmn.ActAppend, bucket, objname, si)
+	}
+	redirecturl := p.redirectURL(r, si.PublicNet.DirectURL, started, bucket)
+	http.Redirect(w, r, redirecturl, http.StatusTemporaryRedirect)
+
+	p.statsif.Add(stats.AppendCount, 1)
+}
+
+// multipart upload: all requests of a given upload (including part PUTs) must
+// end up at the same target - the one that will store the object
+func (p *proxyrunner) mpart(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	started := time.Now()
+	apitems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objname := apitems[0], apitems[1]
+	bucketProvider := r.URL.Query().Get(cmn.URLParamBucketProvider)
+	if _, errstr := p.validateBucketProvider(bucketProvider, bucket); errstr != "" {
+		p.invalmsghdlr(w, r, errstr)
+		return
+	}
+	var uploadID string
+	if msg.Action != cmn.ActMPartInit {
+		mpartMsg := &cmn.MPartMsg{}
+		if b, err := jsoniter.Marshal(msg.Value); err == nil && jsoniter.Unmarshal(b, mpartMsg) == nil {
+			uploadID = mpartMsg.UploadID
+		}
+	}
+	si, errstr := mpartTarget(uploadID, bucket, objname, p.smapowner.get())
+	if errstr != "" {
+		p.invalmsghdlr(w, r, errstr)
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("%s %s/%s => %s", msg.Action, bucket, objname, si)
+	}
+	redirecturl := p.redirectURL(r, si.PublicNet.DirectURL, started, bucket)
+	http.Redirect(w, r, redirecturl, http.StatusTemporaryRedirect)
+}
+
+// mpartTarget returns the target that owns a given upload - or, if the upload ID
+// is not specified or the owner has left the cluster, the HRW target of the object
+func mpartTarget(uploadID, bucket, objname string, smap *smapX) (si *cluster.Snode, errstr string) {
+	if owner := mpartOwner(uploadID); owner != "" {
+		if si = smap.GetTarget(owner); si != nil {
+			return
+		}
+	}
+	return hrwTarget(bucket, objname, smap)
+}
+
 func (p *proxyrunner) replicate(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
 	p.invalmsghdlr(w, r, cmn.NotSupported) // see also: daemon.go, config.sh, and tests/replication
 }
@@ -1845,12 +2118,14 @@ func (p *proxyrunner) validateToken(r *http.Request) (*authRec, error) {
 // A wrapper to check any request before delegating the request to real handler
 // If authentication is disabled, it does nothing.
 // If authentication is enabled, it looks for token in request header and
-// makes sure that it is valid
+// makes sure that it is valid and that the user's role and bucket permissions
+// (see requiredAccess) allow the request
 func (p *proxyrunner) checkHTTPAuth(h http.HandlerFunc) http.HandlerFunc {
 	wrappedFunc := func(w http.ResponseWriter, r *http.Request) {
 		var (
-			auth *authRec
-			err  error
+			auth     *authRec
+			required []bucketAccess
+			err      error
 		)
 
 		if cmn.GCO.Get().Auth.Enabled {
@@ -1862,6 +2137,17 @@ func (p *proxyrunner) checkHTTPAuth(h http.HandlerFunc) http.HandlerFunc {
 			if glog.V(3) {
 				glog.Infof("Logged as %s", auth.userID)
 			}
+			if required, err = requiredAccess(r); err != nil {
+				p.invalmsghdlr(w, r, err.Error())
+				return
+			}
+			for _, ba := range required {
+				if !auth.allowed(ba) {
+					glog.Errorf("User %s (role %q) is not allowed to %s %s", auth.userID, auth.role, r.Method, r.URL.Path)
+					p.invalmsghdlr(w, r, "Forbidden", http.StatusForbidden)
+					return
+				}
+			}
 		}
 
 		h.ServeHTTP(w, r)
@@ -2363,6 +2649,10 @@ func (p *proxyrunner) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.invokeHTTPGetXaction(w, r)
 	case cmn.GetWhatMountpaths:
 		p.invokeHTTPGetClusterMountpaths(w, r)
+	case cmn.GetWhatBucketStats:
+		p.invokeHTTPGetClusterBucketStats(w, r)
+	case cmn.GetWhatXactionList:
+		p.invokeHTTPGetClusterXactions(w, r)
 	default:
 		s := fmt.Sprintf("Unexpected GET request, invalid param 'what': [%s]", getWhat)
 		cmn.InvalidHandlerWithMsg(w, r, s)
@@ -2379,7 +2669,7 @@ func (p *proxyrunner) invokeHTTPGetXaction(w http.ResponseWriter, r *http.Reques
 		err     error
 		kind    = r.URL.Query().Get(cmn.URLParamProps)
 	)
-	if kind == cmn.ActGlobalReb || kind == cmn.ActPrefetch {
+	if kind == cmn.ActGlobalReb || kind == cmn.ActPrefetch || kind == cmn.ActCopyBucket {
 		outputXactionStats := &stats.XactionStats{}
 		outputXactionStats.Kind = kind
 		outputXactionStats.TargetStats = results
@@ -2454,6 +2744,58 @@ func (p *proxyrunner) invokeHTTPGetClusterMountpaths(w http.ResponseWriter, r *h
 	return ok
 }
 
+// sums up the per-bucket stats of all targets
+func (p *proxyrunner) invokeHTTPGetClusterBucketStats(w http.ResponseWriter, r *http.Request) bool {
+	targetStats, ok := p.invokeHTTPGetMsgOnTargets(w, r)
+	if !ok {
+		return false
+	}
+	all := make([][]stats.BucketStats, 0, len(targetStats))
+	for sid, raw := range targetStats {
+		var bstats []stats.BucketStats
+		if err := jsoniter.Unmarshal(raw, &bstats); err != nil {
+			p.invalmsghdlr(w, r, fmt.Sprintf("Failed to unmarshal bucket stats of target %s, err: %v", sid, err))
+			return false
+		}
+		all = append(all, bstats)
+	}
+	jsbytes, err := jsoniter.Marshal(stats.MergeBucketStats(all...))
+	cmn.AssertNoErr(err)
+	return p.writeJSON(w, r, jsbytes, "HttpGetClusterBucketStats")
+}
+
+// all xactions of all targets, optionally filtered by kind and bucket
+func (p *proxyrunner) invokeHTTPGetClusterXactions(w http.ResponseWriter, r *http.Request) bool {
+	targetXactions, ok := p.invokeHTTPGetMsgOnTargets(w, r)
+	if !ok {
+		return false
+	}
+	all := make([]stats.XactionDetails, 0, len(targetXactions)*4)
+	for sid, raw := range targetXactions {
+		var list []stats.XactionDetails
+		if err := jsoniter.Unmarshal(raw, &list); err != nil {
+			p.invalmsghdlr(w, r, fmt.Sprintf("Failed to unmarshal xactions of target %s, err: %v", sid, err))
+			return false
+		}
+		for i := range list {
+			list[i].TargetID = sid
+		}
+		all = append(all, list...)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Kind != all[j].Kind {
+			return all[i].Kind < all[j].Kind
+		}
+		if all[i].TargetID != all[j].TargetID {
+			return all[i].TargetID < all[j].TargetID
+		}
+		return all[i].ID < all[j].ID
+	})
+	jsbytes, err := jsoniter.Marshal(all)
+	cmn.AssertNoErr(err)
+	return p.writeJSON(w, r, jsbytes, "HttpGetClusterXactions")
+}
+
 // register|keepalive target|proxy
 func (p *proxyrunner) httpclupost(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -2813,12 +3155,93 @@ func (p *proxyrunner) httpcluput(w http.ResponseWriter, r *http.Request) {
 		msgInt := p.newActionMsgInternal(&msg, smap, nil)
 		p.metasyncer.sync(false, smap, msgInt)
 
+	case cmn.ActXactStart, cmn.ActXactStop:
+		p.xactStartStop(w, r, &msg)
+
 	default:
 		s := fmt.Sprintf("Unexpected cmn.ActionMsg <- JSON [%v]", msg)
 		p.invalmsghdlr(w, r, s)
 	}
 }
 
+// start or stop (abort) xactions on all targets or, if the target ID is specified,
+// on the given target only; global rebalance, however, is started the same way
+// as ActGlobalReb - by the primary via metasync
+func (p *proxyrunner) xactStartStop(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	xactMsg := &cmn.XactionMsg{}
+	b, err := jsoniter.Marshal(msg.Value)
+	if err == nil {
+		err = jsoniter.Unmarshal(b, xactMsg)
+	}
+	if err != nil {
+		p.invalmsghdlr(w, r, fmt.Sprintf("Failed to unmarshal %s message, err: %v", msg.Action, err))
+		return
+	}
+	if msg.Action == cmn.ActXactStart && !cmn.StringInSlice(xactMsg.Kind, xactStartKinds) {
+		p.invalmsghdlr(w, r, fmt.Sprintf("%s: xaction %q cannot be started, supported kinds: %s",
+			msg.Action, xactMsg.Kind, strings.Join(xactStartKinds, ", ")))
+		return
+	}
+	if msg.Action == cmn.ActXactStart && xactMsg.Kind == cmn.ActGlobalReb {
+		smap := p.smapowner.get()
+		msgInt := p.newActionMsgInternal(&cmn.ActionMsg{Action: cmn.ActGlobalReb}, smap, nil)
+		p.metasyncer.sync(false, smap, msgInt)
+		return
+	}
+	if msg.Action == cmn.ActXactStop && xactMsg.ID == 0 && xactMsg.UUID == "" && xactMsg.Kind == "" && xactMsg.Bucket == "" {
+		p.invalmsghdlr(w, r, fmt.Sprintf("%s: xaction ID, UUID, kind, or bucket must be specified", msg.Action))
+		return
+	}
+	// dSort job runs on all targets, and so it is aborted on all targets
+	if (xactMsg.Kind == cmn.XactionDsort || xactMsg.UUID != "") && (xactMsg.ID != 0 || xactMsg.TargetID != "") {
+		p.invalmsghdlr(w, r, fmt.Sprintf("%s: dSort jobs are stopped by UUID on all targets", msg.Action))
+		return
+	}
+	if xactMsg.ID != 0 && xactMsg.TargetID == "" {
+		p.invalmsghdlr(w, r, fmt.Sprintf("%s: xaction ID %d requires target ID", msg.Action, xactMsg.ID))
+		return
+	}
+	msgbytes, err := jsoniter.Marshal(msg)
+	cmn.AssertNoErr(err)
+	smap := p.smapowner.get()
+	if xactMsg.TargetID != "" {
+		si := smap.GetTarget(xactMsg.TargetID)
+		if si == nil {
+			p.invalmsghdlr(w, r, fmt.Sprintf("%s: unknown target %s", msg.Action, xactMsg.TargetID), http.StatusNotFound)
+			return
+		}
+		res := p.call(callArgs{
+			si: si,
+			req: reqArgs{
+				method: http.MethodPut,
+				path:   cmn.URLPath(cmn.Version, cmn.Daemon),
+				body:   msgbytes,
+			},
+			timeout: defaultTimeout,
+		})
+		if res.err != nil {
+			p.invalmsghdlr(w, r, fmt.Sprintf("%s %+v failed on %s, err: %s", msg.Action, *xactMsg, si, res.errstr))
+		}
+		return
+	}
+	results := p.broadcastTo(
+		cmn.URLPath(cmn.Version, cmn.Daemon),
+		nil, // query
+		http.MethodPut,
+		msgbytes,
+		smap,
+		defaultTimeout,
+		cmn.NetworkIntraControl,
+		cluster.Targets,
+	)
+	for result := range results {
+		if result.err != nil {
+			p.invalmsghdlr(w, r, fmt.Sprintf("%s %+v failed on %s, err: %s", msg.Action, *xactMsg, result.si, result.errstr))
+			return
+		}
+	}
+}
+
 //========================
 //
 // broadcasts: Rx and Tx
@@ -2880,6 +3303,14 @@ func (p *proxyrunner) validateBucketProps(props *cmn.BucketProps, isLocal bool)
 	if err := validateCloudProvider(props.CloudProvider, isLocal); err != nil {
 		return err
 	}
+	if props.OriginURL != "" {
+		if isLocal {
+			return fmt.Errorf("local bucket cannot be bound to origin URL %s", props.OriginURL)
+		}
+		if err := validateOriginURL(props.OriginURL); err != nil {
+			return err
+		}
+	}
 	if props.ReadPolicy != "" && props.ReadPolicy != cmn.RWPolicyCloud && props.ReadPolicy != cmn.RWPolicyNextTier {
 		return fmt.Errorf("invalid read policy: %s", props.ReadPolicy)
 	}
@@ -3022,19 +3453,31 @@ func (p *proxyrunner) detectDaemonDuplicate(osi *cluster.Snode, nsi *cluster.Sno
 }
 
 func validateCloudProvider(provider string, isLocal bool) error {
-	if provider != "" && provider != cmn.ProviderAmazon && provider != cmn.ProviderGoogle && provider != cmn.ProviderAIS {
-		return fmt.Errorf("invalid cloud provider: %s, must be one of (%s | %s | %s)", provider,
-			cmn.ProviderAmazon, cmn.ProviderGoogle, cmn.ProviderAIS)
+	providers := []string{cmn.ProviderAmazon, cmn.ProviderGoogle, cmn.ProviderAIS, cmn.ProviderPosix, cmn.ProviderHTTP, cmn.ProviderRemAIS}
+	if provider != "" && !cmn.StringInSlice(provider, providers) {
+		return fmt.Errorf("invalid cloud provider: %s, must be one of (%s)", provider, strings.Join(providers, " | "))
 	} else if isLocal && provider != cmn.ProviderAIS && provider != "" {
 		return fmt.Errorf("local bucket can only have '%s' as the cloud provider", cmn.ProviderAIS)
 	}
 	return nil
 }
 
+func validateOriginURL(originURL string) error {
+	u, err := url.ParseRequestURI(originURL)
+	if err != nil {
+		return fmt.Errorf("invalid origin URL: %s, err: %v", originURL, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid origin URL: %s, expecting http or https scheme", originURL)
+	}
+	return nil
+}
+
 // FIXME: redundant vs. setconfig; will always miss when adding new props
 func (p *proxyrunner) copyBucketProps(bprops /*to*/, nprops /*from*/ *cmn.BucketProps, bucket string) {
 	bprops.NextTierURL = nprops.NextTierURL
 	bprops.CloudProvider = nprops.CloudProvider
+	bprops.OriginURL = nprops.OriginURL
 	if nprops.ReadPolicy != "" {
 		bprops.ReadPolicy = nprops.ReadPolicy
 	}
@@ -84,9 +84,12 @@ func (rcl *globalRebJogger) rebalanceObjCallback(hdr transport.Header, r io.Read
 
 	if err != nil {
 		glog.Errorf("failed to send obj rebalance: %s/%s, err: %v", hdr.Bucket, hdr.Objname, err)
+		rcl.xreb.ErrorsAdd(1)
 	} else {
 		atomic.AddInt64(&rcl.objectMoved, 1)
 		atomic.AddInt64(&rcl.byteMoved, hdr.ObjAttrs.Size)
+		rcl.xreb.ObjectsAdd(1)
+		rcl.xreb.BytesAdd(hdr.ObjAttrs.Size)
 	}
 
 	rcl.wg.Done()
@@ -132,7 +135,7 @@ func (rcl *globalRebJogger) walk(fqn string, fi os.FileInfo, err error) error {
 		glog.Infof("%s %s => %s", lom, tname(rcl.t.si), tname(si))
 	}
 
-	if errstr := lom.Fill("", cluster.LomAtime|cluster.LomCksum|cluster.LomCksumMissingRecomp); errstr != "" {
+	if errstr := lom.Fill("", cluster.LomAtime|cluster.LomCksum|cluster.LomCksumMissingRecomp|cluster.LomUserMeta); errstr != "" {
 		return errors.New(errstr)
 	}
 	if !lom.Exists() || lom.IsCopy() {
@@ -160,6 +163,7 @@ func (rcl *globalRebJogger) walk(fqn string, fi os.FileInfo, err error) error {
 			CksumType:  cksumType,
 			CksumValue: cksumValue,
 			Version:    lom.Version,
+			UserMeta:   cmn.EncodeUserMeta(lom.UserMeta),
 		},
 	}
 
@@ -256,6 +260,8 @@ func (rb *localRebJogger) walk(fqn string, fileInfo os.FileInfo, err error) erro
 	rb.t.rtnamemap.Unlock(lom.Uname, false)
 	rb.objectMoved++
 	rb.byteMoved += fileInfo.Size()
+	rb.xreb.ObjectsAdd(1)
+	rb.xreb.BytesAdd(fileInfo.Size())
 	return nil
 }
 
@@ -105,5 +105,7 @@ func (rcksctx *recksumctx) walk(fqn string, osfi os.FileInfo, err error) error {
 	// TODO: future scrubber to |cluster.LomCksumPresentRecomp, and scrub
 	lom := &cluster.LOM{FQN: fqn, Size: osfi.Size()}
 	_ = lom.Fill("", cluster.LomCksum|cluster.LomCksumMissingRecomp)
+	rcksctx.xrcksum.ObjectsAdd(1)
+	rcksctx.xrcksum.BytesAdd(osfi.Size())
 	return nil
 }
@@ -0,0 +1,292 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+//======
+//
+// implements cloudif
+//
+//======
+// remaisimpl uses another AIS cluster as the Cloud: all requests go to the
+// remote cluster's proxy (cmn.RemAISConf.URL), which in turn redirects them
+// to its targets. Remote buckets, local and Cloud alike, are visible here as
+// Cloud buckets with the same names. The object's version is its remote
+// version (empty if the remote bucket is not versioned).
+type (
+	remaisimpl struct {
+		t          *targetrunner
+		url        string
+		httpclient *http.Client
+	}
+)
+
+var (
+	_ cloudif = &remaisimpl{}
+)
+
+func newRemAISProvider(t *targetrunner) *remaisimpl {
+	config := cmn.GCO.Get()
+	u := strings.TrimSuffix(config.RemAIS.URL, "/")
+	return &remaisimpl{
+		t:   t,
+		url: u,
+		// the certificate of the remote cluster is verified unless explicitly configured otherwise
+		httpclient: cmn.NewClient(cmn.ClientArgs{
+			Timeout:  config.Timeout.DefaultLong,
+			UseHTTPS: strings.HasPrefix(u, "https://") && config.RemAIS.SkipVerify,
+		}),
+	}
+}
+
+func remaisCksum(hdr http.Header) cmn.CksumProvider {
+	cksumType, cksumValue := hdr.Get(cmn.HeaderObjCksumType), hdr.Get(cmn.HeaderObjCksumVal)
+	if cksumValue == "" || (cksumType != cmn.ChecksumXXHash && cksumType != cmn.ChecksumMD5) {
+		return nil
+	}
+	return cmn.NewCksum(cksumType, cksumValue)
+}
+
+// reqURL returns the remote URL of a given bucket (objname == "") or object
+func (rais *remaisimpl) reqURL(bucket, objname string) string {
+	path := cmn.URLPath(cmn.Version, cmn.Buckets, bucket)
+	if objname != "" {
+		path = cmn.URLPath(cmn.Version, cmn.Objects, bucket) + "/" + objname
+	}
+	return rais.url + (&url.URL{Path: path}).EscapedPath()
+}
+
+// do executes the request and returns the response if successful; the caller
+// must close the response body
+func (rais *remaisimpl) do(ct context.Context, req *http.Request) (resp *http.Response, errstr string, errcode int) {
+	resp, err := rais.httpclient.Do(req.WithContext(ct))
+	if err != nil {
+		return nil, fmt.Sprintf("Failed to %s %s, err: %v", req.Method, req.URL, err), http.StatusBadGateway
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Sprintf("Failed to %s %s, status: %d, response: %s", req.Method, req.URL, resp.StatusCode, string(b)),
+			resp.StatusCode
+	}
+	return
+}
+
+func (rais *remaisimpl) doSimple(ct context.Context, method, u string, body []byte) (resp *http.Response, errstr string, errcode int) {
+	var (
+		req *http.Request
+		err error
+	)
+	if body == nil {
+		req, err = http.NewRequest(method, u, nil)
+	} else {
+		req, err = http.NewRequest(method, u, bytes.NewReader(body))
+	}
+	if err != nil {
+		return nil, err.Error(), http.StatusBadRequest
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return rais.do(ct, req)
+}
+
+//==================
+//
+// bucket operations
+//
+//==================
+// listbucket passes the remote listing through as is, page markers included
+func (rais *remaisimpl) listbucket(ct context.Context, bucket string, msg *cmn.GetMsg) (jsbytes []byte, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("listbucket %s", bucket)
+	}
+	body, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActListObjects, Value: msg})
+	cmn.AssertNoErr(err)
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodPost, rais.reqURL(bucket, ""), body)
+	if errstr != "" {
+		return
+	}
+	defer resp.Body.Close()
+	if jsbytes, err = ioutil.ReadAll(resp.Body); err != nil {
+		errstr = fmt.Sprintf("Failed to list bucket %s, err: %v", bucket, err)
+		errcode = http.StatusBadGateway
+	}
+	return
+}
+
+func (rais *remaisimpl) headbucket(ct context.Context, bucket string) (bucketprops cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headbucket %s", bucket)
+	}
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodHead, rais.reqURL(bucket, ""), nil)
+	if errstr != "" {
+		return
+	}
+	resp.Body.Close()
+	bucketprops = make(cmn.SimpleKVs)
+	bucketprops[cmn.HeaderCloudProvider] = cmn.ProviderRemAIS
+	bucketprops[cmn.HeaderVersioning] = cmn.VersionCloud
+	return
+}
+
+func (rais *remaisimpl) getbucketnames(ct context.Context) (buckets []string, errstr string, errcode int) {
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodGet, rais.reqURL("*", ""), nil)
+	if errstr != "" {
+		return
+	}
+	defer resp.Body.Close()
+	bucketnames := &cmn.BucketNames{}
+	if err := jsoniter.NewDecoder(resp.Body).Decode(bucketnames); err != nil {
+		errstr = fmt.Sprintf("Failed to list all buckets, err: %v", err)
+		errcode = http.StatusBadGateway
+		return
+	}
+	buckets = make([]string, 0, len(bucketnames.Local)+len(bucketnames.Cloud))
+	buckets = append(buckets, bucketnames.Local...)
+	buckets = append(buckets, bucketnames.Cloud...)
+	sort.Strings(buckets)
+	return
+}
+
+//============
+//
+// object meta
+//
+//============
+func (rais *remaisimpl) headobject(ct context.Context, bucket string, objname string) (objmeta cmn.SimpleKVs, errstr string, errcode int) {
+	if glog.V(4) {
+		glog.Infof("headobject %s/%s", bucket, objname)
+	}
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodHead, rais.reqURL(bucket, objname), nil)
+	if errstr != "" {
+		return
+	}
+	resp.Body.Close()
+	objmeta = make(cmn.SimpleKVs)
+	objmeta[cmn.HeaderCloudProvider] = cmn.ProviderRemAIS
+	if version := resp.Header.Get(cmn.HeaderObjVersion); version != "" {
+		objmeta[cmn.HeaderObjVersion] = version
+	}
+	if size := resp.Header.Get(cmn.HeaderObjSize); size != "" {
+		objmeta[cmn.HeaderObjSize] = size
+	}
+	userMeta, _ := cmn.UserMetaFromHeader(resp.Header)
+	for k, v := range userMeta {
+		objmeta[cmn.HeaderObjMetaPrefix+k] = v
+	}
+	return
+}
+
+//=======================
+//
+// object data operations
+//
+//=======================
+func (rais *remaisimpl) getobj(ct context.Context, workFQN, bucket, objname string) (lom *cluster.LOM, errstr string, errcode int) {
+	u := rais.reqURL(bucket, objname)
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodGet, u, nil)
+	if errstr != "" {
+		return
+	}
+	lom = &cluster.LOM{T: rais.t, Bucket: bucket, Objname: objname}
+	lom.Version = resp.Header.Get(cmn.HeaderObjVersion)
+	lom.UserMeta, _ = cmn.UserMetaFromHeader(resp.Header)
+	if errstr = lom.Fill(cmn.CloudBs, 0); errstr != "" {
+		resp.Body.Close()
+		return
+	}
+	roi := &recvObjInfo{
+		t:            rais.t,
+		cold:         true,
+		r:            resp.Body,
+		cksumToCheck: remaisCksum(resp.Header),
+		lom:          lom,
+		workFQN:      workFQN,
+	}
+	if err := roi.writeToFile(); err != nil {
+		errstr = err.Error()
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("GET %s/%s <= %s", bucket, objname, u)
+	}
+	return
+}
+
+// putobj: the remote proxy redirects the PUT to the target that owns the
+// object; GetBody rewinds the file so that the client can follow the redirect
+func (rais *remaisimpl) putobj(ct context.Context, file *os.File, bucket, objname string, cksum cmn.CksumProvider, userMeta cmn.SimpleKVs) (version string, errstr string, errcode int) {
+	u := rais.reqURL(bucket, objname)
+	finfo, err := file.Stat()
+	if err != nil {
+		errstr = fmt.Sprintf("Failed to PUT %s/%s, err: %v", bucket, objname, err)
+		errcode = http.StatusInternalServerError
+		return
+	}
+	req, err := http.NewRequest(http.MethodPut, u, ioutil.NopCloser(file))
+	if err != nil {
+		return "", err.Error(), http.StatusBadRequest
+	}
+	req.ContentLength = finfo.Size()
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(file), nil
+	}
+	if cksum != nil {
+		if cksumType, cksumValue := cksum.Get(); cksumType == cmn.ChecksumXXHash {
+			req.Header.Set(cmn.HeaderObjCksumType, cksumType)
+			req.Header.Set(cmn.HeaderObjCksumVal, cksumValue)
+		}
+	}
+	cmn.UserMetaToHeader(req.Header, userMeta)
+	resp, errstr, errcode := rais.do(ct, req)
+	if errstr != "" {
+		return
+	}
+	resp.Body.Close()
+
+	// PUT does not return the version - HEAD the object to learn it
+	objmeta, errstr, errcode := rais.headobject(ct, bucket, objname)
+	if errstr != "" {
+		return
+	}
+	version = objmeta[cmn.HeaderObjVersion]
+	if glog.V(4) {
+		glog.Infof("PUT %s/%s => %s, version %s", bucket, objname, u, version)
+	}
+	return
+}
+
+func (rais *remaisimpl) deleteobj(ct context.Context, bucket, objname string) (errstr string, errcode int) {
+	resp, errstr, errcode := rais.doSimple(ct, http.MethodDelete, rais.reqURL(bucket, objname), nil)
+	if errstr != "" {
+		return
+	}
+	resp.Body.Close()
+	if glog.V(4) {
+		glog.Infof("DELETE %s/%s", bucket, objname)
+	}
+	return
+}
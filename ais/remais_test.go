@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestRemAISCloud(t *testing.T) {
+	var (
+		objects = map[string][]byte{}
+		metas   = map[string]cmn.SimpleKVs{}
+	)
+	// target: stores objects
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		objname := r.URL.Path[len("/v1/objects/bck/"):]
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			objects[objname] = b
+			metas[objname], _ = cmn.UserMetaFromHeader(r.Header)
+		case http.MethodHead:
+			b, ok := objects[objname]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			w.Header().Set(cmn.HeaderObjVersion, "1")
+			w.Header().Set(cmn.HeaderObjSize, strconv.Itoa(len(b)))
+			cmn.UserMetaToHeader(w.Header(), metas[objname])
+		}
+	}))
+	defer target.Close()
+	// proxy: redirects object requests to the target, handles bucket requests itself
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut || r.Method == http.MethodHead:
+			http.Redirect(w, r, target.URL+r.URL.Path, http.StatusTemporaryRedirect)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/buckets/*":
+			b, _ := jsoniter.Marshal(cmn.BucketNames{Local: []string{"bck"}, Cloud: []string{"abc"}})
+			w.Write(b)
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/buckets/bck":
+			msg := cmn.ActionMsg{Value: &cmn.GetMsg{}}
+			if err := jsoniter.NewDecoder(r.Body).Decode(&msg); err != nil || msg.Action != cmn.ActListObjects {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			getMsg := msg.Value.(*cmn.GetMsg)
+			b, _ := jsoniter.Marshal(cmn.BucketList{
+				Entries:    []*cmn.BucketEntry{{Name: "obj"}},
+				PageMarker: getMsg.GetPageMarker + "-next",
+			})
+			w.Write(b)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer proxy.Close()
+
+	var (
+		ctx  = context.Background()
+		rais = &remaisimpl{url: proxy.URL, httpclient: http.DefaultClient}
+	)
+	buckets, errstr, _ := rais.getbucketnames(ctx)
+	if errstr != "" || len(buckets) != 2 || buckets[0] != "abc" || buckets[1] != "bck" {
+		t.Fatalf("expected [abc bck], got %v (%s)", buckets, errstr)
+	}
+
+	// the listing, page marker included, is the remote one
+	jsbytes, errstr, _ := rais.listbucket(ctx, "bck", &cmn.GetMsg{GetPageMarker: "marker"})
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	reslist := &cmn.BucketList{}
+	if err := jsoniter.Unmarshal(jsbytes, reslist); err != nil {
+		t.Fatal(err)
+	}
+	if len(reslist.Entries) != 1 || reslist.PageMarker != "marker-next" {
+		t.Errorf("unexpected listing: %s", string(jsbytes))
+	}
+
+	// PUT follows the redirect and returns the version
+	file, err := ioutil.TempFile("", "remais")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, err := file.WriteString("remote object"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+	version, errstr, _ := rais.putobj(ctx, file, "bck", "dir/obj", nil, cmn.SimpleKVs{"owner": "alice"})
+	if errstr != "" {
+		t.Fatal(errstr)
+	}
+	if version != "1" || string(objects["dir/obj"]) != "remote object" {
+		t.Errorf("unexpected PUT result: version %q, objects %v", version, objects)
+	}
+	objmeta, errstr, _ := rais.headobject(ctx, "bck", "dir/obj")
+	if errstr != "" || objmeta[cmn.HeaderObjSize] != "13" || objmeta[cmn.HeaderObjMetaPrefix+"owner"] != "alice" {
+		t.Errorf("unexpected object metadata %v (%s)", objmeta, errstr)
+	}
+	if _, _, errcode := rais.headobject(ctx, "bck", "nonexisting"); errcode != http.StatusNotFound {
+		t.Errorf("expected %d, got %d", http.StatusNotFound, errcode)
+	}
+}
+
+// the remote cluster's certificate is verified unless skip_verify is set
+func TestRemAISVerifiesCertificate(t *testing.T) {
+	proxy := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer proxy.Close()
+
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.RemAIS = cmn.RemAISConf{}
+		cmn.GCO.CommitUpdate(config)
+	}()
+	for _, skipVerify := range []bool{false, true} {
+		config := cmn.GCO.BeginUpdate()
+		config.RemAIS = cmn.RemAISConf{URL: proxy.URL, SkipVerify: skipVerify}
+		cmn.GCO.CommitUpdate(config)
+
+		rais := newRemAISProvider(&targetrunner{})
+		resp, err := rais.httpclient.Get(rais.url)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if skipVerify && err != nil {
+			t.Errorf("skip_verify: unexpected error: %v", err)
+		} else if !skipVerify && err == nil {
+			t.Error("expected the remote cluster with self-signed certificate to be rejected")
+		}
+	}
+}
@@ -408,7 +408,7 @@ func (r *mpathReplicator) receive(req *replRequest) error {
 			lom.Atime = tm // FIXME: not used
 		}
 	}
-	if err, _ := r.t.doPut(httpReq, lom.Bucket, lom.Objname); err != nil {
+	if err, _ := r.t.doPut(httpReq, lom.Bucket, lom.Objname, nil); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,627 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// S3 compatibility layer: a subset of the Amazon S3 REST API served by the
+// proxy under /v1/s3. S3 buckets map onto AIS local buckets and onto the cloud
+// buckets recorded in the bucket metadata; object requests are executed by the
+// HRW target on behalf of the client (S3 clients do not follow redirects).
+//
+//   GET    /v1/s3                  - ListBuckets
+//   GET    /v1/s3/bucket           - ListObjectsV2
+//   HEAD   /v1/s3/bucket           - HeadBucket
+//   PUT    /v1/s3/bucket           - CreateBucket (local bucket)
+//   DELETE /v1/s3/bucket           - DeleteBucket (local bucket)
+//   GET    /v1/s3/bucket/object    - GetObject, including 'Range: bytes=...'
+//   HEAD   /v1/s3/bucket/object    - HeadObject
+//   PUT    /v1/s3/bucket/object    - PutObject
+//   DELETE /v1/s3/bucket/object    - DeleteObject
+
+const (
+	s3Namespace    = "http://s3.amazonaws.com/doc/2006-03-01/"
+	s3StorageClass = "STANDARD"
+	s3TimeFormat   = "2006-01-02T15:04:05.000Z"
+
+	// S3 error codes (subset)
+	s3ErrNoSuchBucket     = "NoSuchBucket"
+	s3ErrNoSuchKey        = "NoSuchKey"
+	s3ErrBucketExists     = "BucketAlreadyOwnedByYou"
+	s3ErrInvalidRange     = "InvalidRange"
+	s3ErrInvalidArgument  = "InvalidArgument"
+	s3ErrAccessDenied     = "AccessDenied"
+	s3ErrMethodNotAllowed = "MethodNotAllowed"
+	s3ErrInternal         = "InternalError"
+	s3ErrNotImplemented   = "NotImplemented"
+)
+
+type (
+	s3Error struct {
+		XMLName  xml.Name `xml:"Error"`
+		Code     string   `xml:"Code"`
+		Message  string   `xml:"Message"`
+		Resource string   `xml:"Resource"`
+	}
+	s3Bucket struct {
+		Name         string `xml:"Name"`
+		CreationDate string `xml:"CreationDate"`
+	}
+	s3ListBucketsResult struct {
+		XMLName xml.Name   `xml:"ListAllMyBucketsResult"`
+		Xmlns   string     `xml:"xmlns,attr"`
+		Owner   s3Owner    `xml:"Owner"`
+		Buckets []s3Bucket `xml:"Buckets>Bucket"`
+	}
+	s3Owner struct {
+		ID          string `xml:"ID"`
+		DisplayName string `xml:"DisplayName"`
+	}
+	s3Object struct {
+		Key          string `xml:"Key"`
+		LastModified string `xml:"LastModified,omitempty"`
+		ETag         string `xml:"ETag,omitempty"`
+		Size         int64  `xml:"Size"`
+		StorageClass string `xml:"StorageClass"`
+	}
+	s3CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
+	}
+	s3ListObjectsResult struct {
+		XMLName               xml.Name         `xml:"ListBucketResult"`
+		Xmlns                 string           `xml:"xmlns,attr"`
+		Name                  string           `xml:"Name"`
+		Prefix                string           `xml:"Prefix"`
+		Delimiter             string           `xml:"Delimiter,omitempty"`
+		StartAfter            string           `xml:"StartAfter,omitempty"`
+		ContinuationToken     string           `xml:"ContinuationToken,omitempty"`
+		NextContinuationToken string           `xml:"NextContinuationToken,omitempty"`
+		KeyCount              int              `xml:"KeyCount"`
+		MaxKeys               int              `xml:"MaxKeys"`
+		IsTruncated           bool             `xml:"IsTruncated"`
+		Contents              []s3Object       `xml:"Contents"`
+		CommonPrefixes        []s3CommonPrefix `xml:"CommonPrefixes,omitempty"`
+	}
+	// s3ListToken is the continuation token of ListObjectsV2 (opaque to the clients)
+	s3ListToken struct {
+		PageMarker string `json:"marker"`           // AIS page marker
+		Prefix     string `json:"prefix,omitempty"` // common prefix the previous page ended with
+	}
+)
+
+// s3CheckHTTPAuth is checkHTTPAuth for S3 clients: they sign their requests
+// (AWS Signature Version 4) instead of passing AIS tokens, and AIS does not have
+// the secret keys to verify the signatures. Hence, when authentication is enabled,
+// only the requests that carry AIS token (e.g., curl) are served
+func (p *proxyrunner) s3CheckHTTPAuth(h http.HandlerFunc) http.HandlerFunc {
+	checked := p.checkHTTPAuth(h)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if cmn.GCO.Get().Auth.Enabled && s3Signed(r) {
+			p.s3InvalMsgHandler(w, r, s3ErrAccessDenied,
+				"S3 request signatures are not supported when authentication is enabled", http.StatusForbidden)
+			return
+		}
+		checked(w, r)
+	}
+}
+
+// [METHOD] /v1/s3[/bucket-name[/object-name]]
+func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
+	bucket, objname, err := s3ParsePath(r.URL.Path)
+	if err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInvalidArgument, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if p.smapowner.get().CountTargets() < 1 {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, "No registered targets yet", http.StatusServiceUnavailable)
+		return
+	}
+	switch {
+	case bucket == "":
+		if r.Method != http.MethodGet {
+			p.s3InvalMsgHandler(w, r, s3ErrMethodNotAllowed, "invalid method for /s3 path", http.StatusMethodNotAllowed)
+			return
+		}
+		p.s3ListBuckets(w, r)
+	case objname == "":
+		switch r.Method {
+		case http.MethodGet:
+			p.s3ListObjects(w, r, bucket)
+		case http.MethodHead:
+			p.s3HeadBucket(w, r, bucket)
+		case http.MethodPut:
+			p.s3CreateBucket(w, r, bucket)
+		case http.MethodDelete:
+			p.s3DeleteBucket(w, r, bucket)
+		default:
+			p.s3InvalMsgHandler(w, r, s3ErrMethodNotAllowed, "invalid method for /s3 bucket path", http.StatusMethodNotAllowed)
+		}
+	default:
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+			p.s3ObjectRequest(w, r, bucket, objname)
+		default:
+			p.s3InvalMsgHandler(w, r, s3ErrMethodNotAllowed, "invalid method for /s3 object path", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// GET /v1/s3
+func (p *proxyrunner) s3ListBuckets(w http.ResponseWriter, r *http.Request) {
+	var (
+		bucketmd = p.bmdowner.get()
+		created  = p.starttime.UTC().Format(s3TimeFormat)
+		result   = &s3ListBucketsResult{
+			Xmlns:   s3Namespace,
+			Owner:   s3Owner{ID: p.si.DaemonID, DisplayName: p.si.DaemonID},
+			Buckets: make([]s3Bucket, 0, len(bucketmd.LBmap)+len(bucketmd.CBmap)),
+		}
+	)
+	for bucket := range bucketmd.LBmap {
+		result.Buckets = append(result.Buckets, s3Bucket{Name: bucket, CreationDate: created})
+	}
+	for bucket := range bucketmd.CBmap {
+		result.Buckets = append(result.Buckets, s3Bucket{Name: bucket, CreationDate: created})
+	}
+	p.s3WriteXML(w, r, result, "s3listbuckets")
+}
+
+// GET /v1/s3/bucket-name?list-type=2&prefix=...&max-keys=...&continuation-token=...
+func (p *proxyrunner) s3ListObjects(w http.ResponseWriter, r *http.Request, bucket string) {
+	var (
+		allentries *cmn.BucketList
+		token      s3ListToken
+		query      = r.URL.Query()
+		result     = &s3ListObjectsResult{
+			Xmlns:             s3Namespace,
+			Name:              bucket,
+			Prefix:            query.Get("prefix"),
+			Delimiter:         query.Get("delimiter"),
+			StartAfter:        query.Get("start-after"),
+			ContinuationToken: query.Get("continuation-token"),
+			MaxKeys:           cmn.DefaultPageSize,
+		}
+	)
+	if s := query.Get("max-keys"); s != "" {
+		maxKeys, err := strconv.Atoi(s)
+		if err != nil || maxKeys < 0 {
+			p.s3InvalMsgHandler(w, r, s3ErrInvalidArgument, fmt.Sprintf("Invalid max-keys %q", s), http.StatusBadRequest)
+			return
+		}
+		if maxKeys < result.MaxKeys {
+			result.MaxKeys = maxKeys
+		}
+	}
+	if result.ContinuationToken != "" {
+		if err := token.decode(result.ContinuationToken); err != nil {
+			p.s3InvalMsgHandler(w, r, s3ErrInvalidArgument, "Invalid continuation-token", http.StatusBadRequest)
+			return
+		}
+	}
+	if result.MaxKeys == 0 {
+		p.s3WriteXML(w, r, result, "s3listobjects")
+		return
+	}
+	bckIsLocal, bucketProvider := p.s3BucketProvider(bucket)
+	msg := cmn.GetMsg{
+		GetProps:      strings.Join([]string{cmn.GetPropsSize, cmn.GetPropsCtime, cmn.GetPropsChecksum}, ","),
+		GetTimeFormat: time.RFC3339,
+		GetPrefix:     result.Prefix,
+		GetPageMarker: token.PageMarker,
+		GetPageSize:   result.MaxKeys,
+	}
+	if result.ContinuationToken == "" {
+		msg.GetPageMarker = result.StartAfter
+	}
+	listmsgjson, err := jsoniter.Marshal(&msg)
+	cmn.AssertNoErr(err)
+	if bckIsLocal {
+		allentries, err = p.getLocalBucketObjects(bucket, bucketProvider, listmsgjson, false /*cached*/)
+	} else {
+		allentries, err = p.getCloudBucketObjects(r, bucket, bucketProvider, listmsgjson)
+	}
+	if err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	lastPrefix := result.addEntries(allentries.Entries, token.Prefix)
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+	if allentries.PageMarker != "" {
+		next := s3ListToken{PageMarker: allentries.PageMarker, Prefix: lastPrefix}
+		result.IsTruncated = true
+		result.NextContinuationToken = next.encode()
+	}
+	p.s3WriteXML(w, r, result, "s3listobjects")
+}
+
+// addEntries adds the listed objects to the result folding the keys that contain
+// the delimiter into common prefixes. The keys of the common prefix that has been
+// reported by the previous page (skipPrefix) are skipped - a common prefix is
+// reported only once. Returns the common prefix of the last entry, if any
+func (result *s3ListObjectsResult) addEntries(entries []*cmn.BucketEntry, skipPrefix string) (lastPrefix string) {
+	for _, entry := range entries {
+		cp := s3CommonPrefixOf(entry.Name, result.Prefix, result.Delimiter)
+		lastPrefix = cp
+		if entry.Status != "" && entry.Status != cmn.ObjStatusOK {
+			continue
+		}
+		if cp != "" {
+			if cp != skipPrefix {
+				result.CommonPrefixes = append(result.CommonPrefixes, s3CommonPrefix{Prefix: cp})
+				skipPrefix = cp // the keys of the prefix are listed consecutively
+			}
+			continue
+		}
+		obj := s3Object{Key: entry.Name, Size: entry.Size, StorageClass: s3StorageClass}
+		if entry.Checksum != "" {
+			obj.ETag = strconv.Quote(entry.Checksum)
+		}
+		if t, err := time.Parse(time.RFC3339, entry.Ctime); err == nil {
+			obj.LastModified = t.UTC().Format(s3TimeFormat)
+		}
+		result.Contents = append(result.Contents, obj)
+	}
+	return
+}
+
+func (token *s3ListToken) encode() string {
+	b, err := jsoniter.Marshal(token)
+	cmn.AssertNoErr(err)
+	return base64.URLEncoding.EncodeToString(b)
+}
+
+func (token *s3ListToken) decode(s string) error {
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal(b, token)
+}
+
+// HEAD /v1/s3/bucket-name
+func (p *proxyrunner) s3HeadBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	bucketmd := p.bmdowner.get()
+	if _, ok := bucketmd.Get(bucket, bucketmd.IsLocal(bucket)); !ok {
+		p.s3InvalMsgHandler(w, r, s3ErrNoSuchBucket, fmt.Sprintf("Bucket %s does not exist", bucket), http.StatusNotFound)
+	}
+}
+
+// PUT /v1/s3/bucket-name
+func (p *proxyrunner) s3CreateBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := cmn.ActionMsg{Action: cmn.ActCreateLB}
+	if p.forwardCP(w, r, &msg, bucket, nil) {
+		return
+	}
+	if p.bmdowner.get().IsLocal(bucket) {
+		p.s3InvalMsgHandler(w, r, s3ErrBucketExists, fmt.Sprintf("Bucket %s already exists", bucket), http.StatusConflict)
+		return
+	}
+	if err := p.createLocalBucket(&msg, bucket); err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Location", "/"+bucket)
+}
+
+// DELETE /v1/s3/bucket-name
+func (p *proxyrunner) s3DeleteBucket(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := cmn.ActionMsg{Action: cmn.ActDestroyLB}
+	if p.forwardCP(w, r, &msg, bucket, nil) {
+		return
+	}
+	if !p.bmdowner.get().IsLocal(bucket) {
+		p.s3InvalMsgHandler(w, r, s3ErrNoSuchBucket, fmt.Sprintf("Local bucket %s does not exist", bucket), http.StatusNotFound)
+		return
+	}
+	if err := p.destroyLocalBucket(&msg, bucket); err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET | HEAD | PUT | DELETE /v1/s3/bucket-name/object-name
+//
+// The request is re-issued against the /v1/objects API of the HRW target and
+// the target's response is translated into its S3 counterpart.
+func (p *proxyrunner) s3ObjectRequest(w http.ResponseWriter, r *http.Request, bucket, objname string) {
+	var (
+		rangeOff, rangeLen int64
+		size               int64 = -1
+		started                  = time.Now()
+		rangeHdr                 = r.Header.Get("Range")
+	)
+	_, bucketProvider := p.s3BucketProvider(bucket)
+	si, errstr := hrwTarget(bucket, objname, p.smapowner.get())
+	if errstr != "" {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, errstr, http.StatusInternalServerError)
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("s3: %s %s/%s => %s", r.Method, bucket, objname, si)
+	}
+
+	// ranged GET: the target needs offset and length, the client needs the total size
+	if r.Method == http.MethodGet && rangeHdr != "" {
+		hdr, status, err := p.s3TargetHead(si, bucket, objname, bucketProvider, started)
+		if err != nil {
+			p.s3InvalTargetResponse(w, r, status, err)
+			return
+		}
+		if size, err = strconv.ParseInt(hdr.Get(cmn.HeaderObjSize), 10, 64); err != nil {
+			p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if rangeOff, rangeLen, err = s3ParseRange(rangeHdr, size); err != nil {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+			p.s3InvalMsgHandler(w, r, s3ErrInvalidRange, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+	}
+	query := url.Values{}
+	query.Add(cmn.URLParamBucketProvider, bucketProvider)
+	if rangeLen > 0 {
+		query.Add(cmn.URLParamOffset, strconv.FormatInt(rangeOff, 10))
+		query.Add(cmn.URLParamLength, strconv.FormatInt(rangeLen, 10))
+	}
+	r.URL.Path = cmn.URLPath(cmn.Version, cmn.Objects, bucket, objname)
+	r.URL.RawQuery = query.Encode()
+	redirecturl := p.redirectURL(r, si.PublicNet.DirectURL, started, bucket)
+
+	req, err := http.NewRequest(r.Method, redirecturl, r.Body)
+	if err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.ContentLength = r.ContentLength
+	for header, values := range r.Header {
+		if header == "Range" || header == "Authorization" {
+			continue
+		}
+		for _, value := range values {
+			req.Header.Add(header, value)
+		}
+	}
+	resp, err := p.httpclientLongTimeout.Do(req)
+	if err != nil {
+		p.s3InvalMsgHandler(w, r, s3ErrInternal, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		p.s3InvalTargetResponse(w, r, resp.StatusCode, errors.New(string(b)))
+		return
+	}
+
+	hdr := w.Header()
+	switch r.Method {
+	case http.MethodGet:
+		s3SetObjectHeaders(hdr, resp.Header)
+		if rangeLen > 0 {
+			hdr.Set("Content-Length", strconv.FormatInt(rangeLen, 10))
+			hdr.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", rangeOff, rangeOff+rangeLen-1, size))
+			w.WriteHeader(http.StatusPartialContent)
+		}
+		if _, err := io.Copy(w, resp.Body); err != nil {
+			glog.Errorf("s3: failed to send %s/%s, err: %v", bucket, objname, err)
+		}
+		p.statsif.Add(stats.GetCount, 1)
+	case http.MethodHead:
+		s3SetObjectHeaders(hdr, resp.Header)
+	case http.MethodPut:
+		if cksum := resp.Header.Get(cmn.HeaderObjCksumVal); cksum != "" {
+			hdr.Set("ETag", strconv.Quote(cksum))
+		}
+		if version := resp.Header.Get(cmn.HeaderObjVersion); version != "" {
+			hdr.Set("x-amz-version-id", version)
+		}
+		p.statsif.Add(stats.PutCount, 1)
+	case http.MethodDelete:
+		w.WriteHeader(http.StatusNoContent)
+		p.statsif.Add(stats.DeleteCount, 1)
+	}
+}
+
+// s3TargetHead returns the properties of an object as reported by its HRW target
+func (p *proxyrunner) s3TargetHead(si *cluster.Snode, bucket, objname, bucketProvider string,
+	started time.Time) (http.Header, int, error) {
+	query := url.Values{}
+	query.Add(cmn.URLParamBucketProvider, bucketProvider)
+	treq := &http.Request{Method: http.MethodHead, URL: &url.URL{
+		Path:     cmn.URLPath(cmn.Version, cmn.Objects, bucket, objname),
+		RawQuery: query.Encode(),
+	}}
+	req, err := http.NewRequest(http.MethodHead, p.redirectURL(treq, si.PublicNet.DirectURL, started, bucket), nil)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	resp, err := p.httpclient.Do(req)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return nil, resp.StatusCode, errors.New(string(b))
+	}
+	return resp.Header, resp.StatusCode, nil
+}
+
+// s3BucketProvider maps S3 bucket onto AIS: local buckets take precedence,
+// everything else is treated as a cloud bucket
+func (p *proxyrunner) s3BucketProvider(bucket string) (bckIsLocal bool, bucketProvider string) {
+	if p.bmdowner.get().IsLocal(bucket) {
+		return true, cmn.LocalBs
+	}
+	return false, cmn.CloudBs
+}
+
+func (p *proxyrunner) s3WriteXML(w http.ResponseWriter, r *http.Request, v interface{}, tag string) {
+	b, err := xml.Marshal(v)
+	cmn.AssertNoErr(err)
+	w.Header().Set("Content-Type", "application/xml")
+	if _, err = w.Write([]byte(xml.Header)); err == nil {
+		_, err = w.Write(b)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to write %s response, err: %v", pname(p.si), tag, err)
+	}
+}
+
+// s3InvalMsgHandler is the S3 counterpart of invalmsghdlr: S3 clients expect XML-formatted errors
+func (p *proxyrunner) s3InvalMsgHandler(w http.ResponseWriter, r *http.Request, code, msg string, status int) {
+	glog.Errorf("s3: %s %s: %s (%d)", r.Method, r.URL.Path, msg, status)
+	b, err := xml.Marshal(&s3Error{Code: code, Message: msg, Resource: r.URL.Path})
+	cmn.AssertNoErr(err)
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	if r.Method != http.MethodHead {
+		w.Write([]byte(xml.Header))
+		w.Write(b)
+	}
+	p.statsif.AddErrorHTTP(r.Method, 1)
+}
+
+func (p *proxyrunner) s3InvalTargetResponse(w http.ResponseWriter, r *http.Request, status int, err error) {
+	code := s3ErrInternal
+	switch status {
+	case http.StatusNotFound:
+		code = s3ErrNoSuchKey
+	case http.StatusBadRequest:
+		code = s3ErrInvalidArgument
+	case http.StatusNotImplemented:
+		code = s3ErrNotImplemented
+	}
+	p.s3InvalMsgHandler(w, r, code, err.Error(), status)
+}
+
+// s3SetObjectHeaders converts AIS object properties returned by a target into S3 object headers.
+// NOTE: ETag is the object's checksum (xxhash) - not the MD5 of the content that S3 returns
+func s3SetObjectHeaders(hdr, thdr http.Header) {
+	if size := thdr.Get(cmn.HeaderObjSize); size != "" {
+		hdr.Set("Content-Length", size)
+	}
+	if cksum := thdr.Get(cmn.HeaderObjCksumVal); cksum != "" {
+		hdr.Set("ETag", strconv.Quote(cksum))
+	}
+	if version := thdr.Get(cmn.HeaderObjVersion); version != "" {
+		hdr.Set("x-amz-version-id", version)
+	}
+	hdr.Set("Accept-Ranges", "bytes")
+	if ct := thdr.Get("Content-Type"); ct != "" {
+		hdr.Set("Content-Type", ct)
+	}
+}
+
+// s3CommonPrefixOf returns the common prefix (ListObjectsV2) the key belongs to,
+// or "" if the key is to be listed as is
+func s3CommonPrefixOf(key, prefix, delimiter string) string {
+	if delimiter == "" {
+		return ""
+	}
+	rest := strings.TrimPrefix(key, prefix)
+	if idx := strings.Index(rest, delimiter); idx >= 0 {
+		return prefix + rest[:idx+len(delimiter)]
+	}
+	return ""
+}
+
+// s3Signed returns true if the request is signed by an S3 client: either via the
+// 'Authorization' header or via the query (presigned URL)
+func s3Signed(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	return strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") || strings.HasPrefix(auth, "AWS ") ||
+		r.URL.Query().Get("X-Amz-Signature") != ""
+}
+
+// s3ParsePath splits /v1/s3/bucket-name/object/name into the bucket and object names;
+// unlike cmn.MatchRESTItems it keeps the slashes inside the object name
+func s3ParsePath(path string) (bucket, objname string, err error) {
+	prefix := cmn.URLPath(cmn.Version, cmn.S3)
+	if !strings.HasPrefix(path, prefix) {
+		return "", "", fmt.Errorf("expected %s in path %s", prefix, path)
+	}
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, prefix), "/")
+	if rest == "" {
+		return
+	}
+	items := strings.SplitN(rest, "/", 2)
+	bucket = items[0]
+	if len(items) > 1 {
+		objname = items[1]
+	}
+	if bucket == "" {
+		err = errors.New("empty bucket name")
+	}
+	return
+}
+
+// s3ParseRange parses a single-range 'Range' header (RFC 7233) for an object of a given size
+// and returns the corresponding offset and length
+func s3ParseRange(hdr string, size int64) (off, length int64, err error) {
+	const unit = "bytes="
+	if !strings.HasPrefix(hdr, unit) {
+		return 0, 0, fmt.Errorf("invalid range %q", hdr)
+	}
+	spec := strings.TrimSpace(hdr[len(unit):])
+	if strings.Contains(spec, ",") {
+		return 0, 0, fmt.Errorf("multiple ranges are not supported: %q", hdr)
+	}
+	dash := strings.Index(spec, "-")
+	if dash < 0 {
+		return 0, 0, fmt.Errorf("invalid range %q", hdr)
+	}
+	start, end := strings.TrimSpace(spec[:dash]), strings.TrimSpace(spec[dash+1:])
+	switch {
+	case start == "" && end == "":
+		return 0, 0, fmt.Errorf("invalid range %q", hdr)
+	case start == "": // suffix: last N bytes
+		var n int64
+		if n, err = strconv.ParseInt(end, 10, 64); err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", hdr)
+		}
+		if n > size {
+			n = size
+		}
+		off, length = size-n, n
+	default:
+		if off, err = strconv.ParseInt(start, 10, 64); err != nil || off < 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", hdr)
+		}
+		last := size - 1
+		if end != "" {
+			if last, err = strconv.ParseInt(end, 10, 64); err != nil || last < off {
+				return 0, 0, fmt.Errorf("invalid range %q", hdr)
+			}
+			if last > size-1 {
+				last = size - 1
+			}
+		}
+		length = last - off + 1
+	}
+	if off >= size || length <= 0 {
+		return 0, 0, fmt.Errorf("range %q not satisfiable for size %d", hdr, size)
+	}
+	return
+}
@@ -0,0 +1,378 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+type nopStatsTracker struct{}
+
+func (nopStatsTracker) Add(string, int64)           {}
+func (nopStatsTracker) AddErrorHTTP(string, int64)  {}
+func (nopStatsTracker) AddMany(...stats.NamedVal64) {}
+func (nopStatsTracker) Register(string, string)     {}
+
+// s3TestTarget simulates a single target serving the /v1/objects and /v1/buckets
+// requests that the S3 layer issues on behalf of its clients
+func s3TestTarget(t *testing.T) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		objects = make(map[string][]byte)
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if strings.HasPrefix(r.URL.Path, cmn.URLPath(cmn.Version, cmn.Buckets)) {
+			list := &cmn.BucketList{}
+			for name, b := range objects {
+				list.Entries = append(list.Entries, &cmn.BucketEntry{Name: name, Size: int64(len(b))})
+			}
+			body, _ := jsoniter.Marshal(list)
+			w.Write(body)
+			return
+		}
+		items, err := cmn.MatchRESTItems(r.URL.Path, 2, false, cmn.Version, cmn.Objects)
+		if err != nil {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		name := items[1]
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			objects[name] = b
+			cksum, _ := cmn.ComputeXXHash(bytes.NewReader(b), nil)
+			w.Header().Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+			w.Header().Set(cmn.HeaderObjCksumVal, cksum)
+		case http.MethodGet, http.MethodHead:
+			b, ok := objects[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set(cmn.HeaderObjSize, strconv.Itoa(len(b)))
+			if r.Method == http.MethodHead {
+				return
+			}
+			query := r.URL.Query()
+			if s := query.Get(cmn.URLParamOffset); s != "" {
+				off, _ := strconv.Atoi(s)
+				length, _ := strconv.Atoi(query.Get(cmn.URLParamLength))
+				b = b[off : off+length]
+			}
+			w.Write(b)
+		}
+	}))
+}
+
+func TestS3Handler(t *testing.T) {
+	const (
+		bucket  = "abc"
+		objname = "dir/obj.txt"
+		content = "0123456789abcdefghij"
+	)
+	target := s3TestTarget(t)
+	defer target.Close()
+
+	p := &proxyrunner{}
+	p.si = newSnode("primary", httpProto, httpProto, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{})
+	p.statsif = nopStatsTracker{}
+	p.httpclient = &http.Client{}
+	p.httpclientLongTimeout = &http.Client{}
+	p.smapowner = &smapowner{}
+	smap := newSmap()
+	smap.addProxy(p.si)
+	smap.ProxySI = p.si
+	smap.addTarget(newSnode("target", httpProto, httpProto, target.Listener.Addr().(*net.TCPAddr), &net.TCPAddr{}, &net.TCPAddr{}))
+	p.smapowner.put(smap)
+	p.bmdowner = &bmdowner{}
+	bucketmd := newBucketMD()
+	bucketmd.add(bucket, true, &cmn.BucketProps{})
+	p.bmdowner.put(bucketmd)
+	config := cmn.GCO.BeginUpdate()
+	config.KeepaliveTracker.Proxy.Name = "heartbeat"
+	cmn.GCO.CommitUpdate(config)
+	p.keepalive = newProxyKeepaliveRunner(p)
+
+	proxy := httptest.NewServer(http.HandlerFunc(p.s3Handler))
+	defer proxy.Close()
+	objURL := proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket, objname)
+
+	// PUT
+	req, _ := http.NewRequest(http.MethodPut, objURL, strings.NewReader(content))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT: expected %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	cksum, _ := cmn.ComputeXXHash(strings.NewReader(content), nil)
+	if etag := resp.Header.Get("ETag"); etag != strconv.Quote(cksum) {
+		t.Errorf("PUT: expected ETag %q, got %q", strconv.Quote(cksum), etag)
+	}
+
+	// GET with a range
+	req, _ = http.NewRequest(http.MethodGet, objURL, nil)
+	req.Header.Set("Range", "bytes=5-9")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf("GET: expected %d, got %d", http.StatusPartialContent, resp.StatusCode)
+	}
+	if string(b) != content[5:10] {
+		t.Errorf("GET: expected %q, got %q", content[5:10], b)
+	}
+	if cr := resp.Header.Get("Content-Range"); cr != "bytes 5-9/20" {
+		t.Errorf("GET: unexpected Content-Range %q", cr)
+	}
+
+	// HEAD
+	resp, err = http.Head(objURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength != int64(len(content)) {
+		t.Errorf("HEAD: unexpected status %d, content length %d", resp.StatusCode, resp.ContentLength)
+	}
+	resp, err = http.Head(proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket, "nonexisting"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("HEAD: expected %d, got %d", http.StatusNotFound, resp.StatusCode)
+	}
+
+	// list
+	resp, err = http.Get(proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket) + "?list-type=2&delimiter=/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	result := &s3ListObjectsResult{}
+	if err := xml.Unmarshal(b, result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Contents) != 0 || len(result.CommonPrefixes) != 1 || result.CommonPrefixes[0].Prefix != "dir/" {
+		t.Errorf("list: unexpected result %+v", result)
+	}
+	resp, err = http.Get(proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket) + "?list-type=2&prefix=dir/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	result = &s3ListObjectsResult{}
+	if err := xml.Unmarshal(b, result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Key != objname || result.Contents[0].Size != int64(len(content)) {
+		t.Errorf("list: unexpected result %+v", result)
+	}
+	resp, err = http.Get(proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket) + "?list-type=2&max-keys=0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	result = &s3ListObjectsResult{}
+	if err := xml.Unmarshal(b, result); err != nil {
+		t.Fatal(err)
+	}
+	if result.MaxKeys != 0 || result.KeyCount != 0 || len(result.Contents) != 0 || result.IsTruncated {
+		t.Errorf("list (max-keys=0): unexpected result %+v", result)
+	}
+	resp, err = http.Get(proxy.URL + cmn.URLPath(cmn.Version, cmn.S3, bucket) + "?list-type=2&continuation-token=dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("list (invalid token): expected %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+// a common prefix spanning several pages is reported once
+func TestS3ListPages(t *testing.T) {
+	var (
+		pages = [][]string{
+			{"a", "dir/1", "dir/2"},
+			{"dir/3", "dir/4"},
+			{"dir/5", "dir2/1", "z"},
+		}
+		token    s3ListToken
+		keys     []string
+		prefixes []string
+	)
+	for i, page := range pages {
+		entries := make([]*cmn.BucketEntry, 0, len(page))
+		for _, name := range page {
+			entries = append(entries, &cmn.BucketEntry{Name: name})
+		}
+		result := &s3ListObjectsResult{Delimiter: "/"}
+		lastPrefix := result.addEntries(entries, token.Prefix)
+		for _, obj := range result.Contents {
+			keys = append(keys, obj.Key)
+		}
+		for _, cp := range result.CommonPrefixes {
+			prefixes = append(prefixes, cp.Prefix)
+		}
+		// via the token, as the client would
+		next := s3ListToken{PageMarker: page[len(page)-1], Prefix: lastPrefix}
+		token = s3ListToken{}
+		if err := token.decode(next.encode()); err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		if token != next {
+			t.Fatalf("page %d: expected token %+v, got %+v", i, next, token)
+		}
+	}
+	if strings.Join(keys, ",") != "a,z" {
+		t.Errorf("unexpected keys %v", keys)
+	}
+	if strings.Join(prefixes, ",") != "dir/,dir2/" {
+		t.Errorf("unexpected common prefixes %v", prefixes)
+	}
+}
+
+func TestS3CheckHTTPAuth(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Enabled = true
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Auth.Enabled = false
+		cmn.GCO.CommitUpdate(config)
+	}()
+	p := &proxyrunner{}
+	p.statsif = nopStatsTracker{}
+	handler := p.s3CheckHTTPAuth(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s %s", r.Method, r.URL)
+	})
+	tests := []struct {
+		name   string
+		url    string
+		auth   string
+		status int
+	}{
+		{name: "sigv4", url: "/v1/s3/abc", auth: "AWS4-HMAC-SHA256 Credential=key/20190101/us-east-1/s3/aws4_request", status: http.StatusForbidden},
+		{name: "presigned", url: "/v1/s3/abc/obj?X-Amz-Signature=abcd", status: http.StatusForbidden},
+		{name: "invalid token", url: "/v1/s3/abc", auth: tokenStart + " token", status: http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, test.url, nil)
+		if test.auth != "" {
+			r.Header.Set("Authorization", test.auth)
+		}
+		w := httptest.NewRecorder()
+		handler(w, r)
+		if w.Code != test.status {
+			t.Errorf("%s: expected %d, got %d", test.name, test.status, w.Code)
+		}
+		if test.status == http.StatusForbidden {
+			s3err := &s3Error{}
+			if err := xml.Unmarshal(w.Body.Bytes(), s3err); err != nil || s3err.Code != s3ErrAccessDenied {
+				t.Errorf("%s: expected %s, got %s (err: %v)", test.name, s3ErrAccessDenied, w.Body.String(), err)
+			}
+		}
+	}
+}
+
+func TestS3ParsePath(t *testing.T) {
+	tests := []struct {
+		path    string
+		bucket  string
+		objname string
+		fail    bool
+	}{
+		{path: "/v1/s3", bucket: "", objname: ""},
+		{path: "/v1/s3/", bucket: "", objname: ""},
+		{path: "/v1/s3/bck", bucket: "bck", objname: ""},
+		{path: "/v1/s3/bck/", bucket: "bck", objname: ""},
+		{path: "/v1/s3/bck/obj", bucket: "bck", objname: "obj"},
+		{path: "/v1/s3/bck/dir/subdir/obj", bucket: "bck", objname: "dir/subdir/obj"},
+		{path: "/v1/s3//obj", fail: true},
+		{path: "/v1/objects/bck/obj", fail: true},
+	}
+	for _, test := range tests {
+		bucket, objname, err := s3ParsePath(test.path)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%s: expected error", test.path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.path, err)
+			continue
+		}
+		if bucket != test.bucket || objname != test.objname {
+			t.Errorf("%s: expected %q/%q, got %q/%q", test.path, test.bucket, test.objname, bucket, objname)
+		}
+	}
+}
+
+func TestS3ParseRange(t *testing.T) {
+	const size = 100
+	tests := []struct {
+		hdr    string
+		off    int64
+		length int64
+		fail   bool
+	}{
+		{hdr: "bytes=0-9", off: 0, length: 10},
+		{hdr: "bytes=10-", off: 10, length: 90},
+		{hdr: "bytes=-10", off: 90, length: 10},
+		{hdr: "bytes=-1000", off: 0, length: 100},
+		{hdr: "bytes=90-1000", off: 90, length: 10},
+		{hdr: "bytes=99-99", off: 99, length: 1},
+		{hdr: "bytes=100-", fail: true},
+		{hdr: "bytes=10-5", fail: true},
+		{hdr: "bytes=-", fail: true},
+		{hdr: "bytes=-0", fail: true},
+		{hdr: "bytes=0-1,5-6", fail: true},
+		{hdr: "items=0-1", fail: true},
+	}
+	for _, test := range tests {
+		off, length, err := s3ParseRange(test.hdr, size)
+		if test.fail {
+			if err == nil {
+				t.Errorf("%s: expected error", test.hdr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.hdr, err)
+			continue
+		}
+		if off != test.off || length != test.length {
+			t.Errorf("%s: expected (%d, %d), got (%d, %d)", test.hdr, test.off, test.length, off, length)
+		}
+	}
+}
@@ -2,6 +2,13 @@ cat > $CONFFILE <<EOL
 {
 	"confdir":       "${CONFDIR}",
 	"cloudprovider": "${CLDPROVIDER}",
+	"posix": {
+		"root": "${POSIX_ROOT}"
+	},
+	"remote_ais": {
+		"url":         "${REMAIS_URL}",
+		"skip_verify": ${REMAIS_SKIP_VERIFY:-false}
+	},
 	"mirror": {
 		"copies":              2,
 		"mirror_burst_buffer": 512,
@@ -97,6 +104,12 @@ cat > $CONFFILE <<EOL
 			"server_key":		"server.key",
 			"max_num_targets":	16,
 			"use_https":		${USE_HTTPS:-false}
+		},
+		"mtls": {
+			"enabled":		${MTLS_ENABLED:-false},
+			"ca_certificate":	"${MTLS_CA_CERT}",
+			"certificate":		"${MTLS_CERT}",
+			"key":			"${MTLS_KEY}"
 		}
 	},
 	"fshc": {
@@ -122,6 +135,15 @@ cat > $CONFFILE <<EOL
 		},
 		"retry_factor":   5,
 		"timeout_factor": 3
+	},
+	"trace": {
+		"enabled":   ${TRACE_ENABLED:-false},
+		"file":      "",
+		"collector": "${TRACE_COLLECTOR}"
+	},
+	"transport": {
+		"max_stream_bandwidth": "${MAX_STREAM_BANDWIDTH:-0}",
+		"max_bundle_bandwidth": "${MAX_BUNDLE_BANDWIDTH:-0}"
 	}
 }
 EOL
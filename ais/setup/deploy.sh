@@ -132,12 +132,25 @@ echo Select Cloud Provider:
 echo  1: Amazon Cloud
 echo  2: Google Cloud
 echo  3: None
+echo  4: Local filesystem \(posix\)
+echo  5: Remote AIS cluster
 echo Enter your choice:
 read cldprovider
 if [ $cldprovider -eq 1 ]; then
 	CLDPROVIDER="aws"
 elif [ $cldprovider -eq 2 ]; then
 	CLDPROVIDER="gcp"
+elif [ $cldprovider -eq 4 ]; then
+	CLDPROVIDER="posix"
+	echo Enter the root directory \(each subdirectory is a bucket\):
+	read POSIX_ROOT
+	if [[ "$POSIX_ROOT" != /* ]]; then
+		echo "Error: '$POSIX_ROOT' is not an absolute path"; exit 1
+	fi
+elif [ $cldprovider -eq 5 ]; then
+	CLDPROVIDER="remais"
+	echo Enter the URL of the remote cluster\'s primary proxy:
+	read REMAIS_URL
 fi
 
 mkdir -p $CONFDIR
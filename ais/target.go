@@ -39,6 +39,7 @@ import (
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/stats/statsd"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/OneOfOne/xxhash"
 	jsoniter "github.com/json-iterator/go"
@@ -73,6 +74,8 @@ type (
 		needVersion  bool
 		needStatus   bool
 		needCopies   bool
+		needUserMeta bool
+		filter       *listFilter
 		atimeRespCh  chan *atime.Response
 	}
 	uxprocess struct {
@@ -143,6 +146,7 @@ type (
 		readahead      readaheader
 		xcopy          *mirror.XactCopy
 		ecmanager      *ecManager
+		mpart          *mpartMgr
 		streams        struct {
 			rebalance *transport.StreamBundle
 		}
@@ -201,6 +205,10 @@ func (t *targetrunner) Run() error {
 		glog.Error(err)
 		os.Exit(1)
 	}
+	if err := fs.CSM.RegisterFileType(mpartType, &mpartContentResolver{}); err != nil {
+		glog.Error(err)
+		os.Exit(1)
+	}
 
 	if err := fs.Mountpaths.CreateBucketDir(cmn.LocalBs); err != nil {
 		glog.Error(err)
@@ -217,6 +225,12 @@ func (t *targetrunner) Run() error {
 		t.cloudif = newAWSProvider(t)
 	} else if config.CloudProvider == cmn.ProviderGoogle {
 		t.cloudif = newGCPProvider(t)
+	} else if config.CloudProvider == cmn.ProviderPosix {
+		t.cloudif = newPosixProvider(t)
+	} else if config.CloudProvider == cmn.ProviderHTTP {
+		t.cloudif = newHTTPProvider(t)
+	} else if config.CloudProvider == cmn.ProviderRemAIS {
+		t.cloudif = newRemAISProvider(t)
 	} else {
 		t.cloudif = newEmptyCloud() // mock
 	}
@@ -256,6 +270,7 @@ func (t *targetrunner) Run() error {
 		networkHandler{r: "/", h: cmn.InvalidHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl, cmn.NetworkIntraData}},
 	}
 	t.registerNetworkHandlers(networkHandlers)
+	t.registerPublicNetHandler(cmn.URLPath(cmn.Metrics), t.metricsHandler) // Prometheus
 
 	if err := t.setupStreams(); err != nil {
 		glog.Error(err)
@@ -270,6 +285,10 @@ func (t *targetrunner) Run() error {
 	ec.Init()
 	t.ecmanager = newECM(t)
 
+	t.mpart = newMpartMgr(t)
+	t.mpart.load(time.Now())
+	go t.mpart.run()
+
 	aborted, _ := t.xactions.isAbortedOrRunningLocalRebalance()
 	if aborted {
 		// resume local rebalance
@@ -280,7 +299,7 @@ func (t *targetrunner) Run() error {
 		go runLocalRebalanceOnce.Do(f) // only once at startup
 	}
 
-	dsort.RegisterNode(t.smapowner, t.si, t, t.rtnamemap)
+	dsort.RegisterNode(t.smapowner, t.bmdowner, t.si, t, t.rtnamemap)
 	if err := t.httprunner.run(); err != nil {
 		return err
 	}
@@ -299,13 +318,14 @@ func (t *targetrunner) setupStreams() error {
 		network = cmn.NetworkPublic
 	}
 
-	if _, err := transport.Register(network, "rebalance", t.recvRebalanceObj); err != nil {
+	if _, err := transport.Register(network, "rebalance", t.recvRebalanceObj, true /*verifyCksum*/); err != nil {
 		return err
 	}
 
 	client := transport.NewDefaultClient()
 	// TODO: stream bundle multiplier (currently default) should be adjustable at runtime (#253)
-	t.streams.rebalance = transport.NewStreamBundle(t.smapowner, t.si, client, network, "rebalance", nil, cluster.Targets, 4)
+	extra := &transport.Extra{ConfigBandwidth: true}
+	t.streams.rebalance = transport.NewStreamBundle(t.smapowner, t.si, client, network, "rebalance", extra, cluster.Targets, 4)
 	return nil
 }
 
@@ -347,6 +367,9 @@ func (t *targetrunner) registerStats() {
 func (t *targetrunner) Stop(err error) {
 	glog.Infof("Stopping %s, err: %v", t.Getname(), err)
 	sleep := t.xactions.abortAll()
+	if t.mpart != nil {
+		t.mpart.stop()
+	}
 	if t.publicServer.s != nil {
 		t.unregister() // ignore errors
 	}
@@ -491,7 +514,10 @@ loop:
 				glog.Errorf("prefetch: bucket %s is local, nothing to do", fwd.bucket)
 			} else {
 				for _, objname := range fwd.objnames {
-					t.prefetchMissing(fwd.ctx, objname, fwd.bucket, fwd.bucketProvider)
+					if size, ok := t.prefetchMissing(fwd.ctx, objname, fwd.bucket, fwd.bucketProvider); ok {
+						xpre.ObjectsAdd(1)
+						xpre.BytesAdd(size)
+					}
 				}
 			}
 			// Signal completion of prefetch
@@ -564,6 +590,23 @@ func (t *targetrunner) objectHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// invalmsghdlr additionally counts the failed object requests of the bucket -
+// but only if the bucket is in the BMD, so that requests to arbitrary
+// (e.g., misspelled) bucket names do not add stats entries
+func (t *targetrunner) invalmsghdlr(w http.ResponseWriter, r *http.Request, msg string, errCode ...int) {
+	t.httprunner.invalmsghdlr(w, r, msg, errCode...)
+	apitems, err := cmn.MatchRESTItems(r.URL.Path, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucketmd := t.bmdowner.get()
+	_, local := bucketmd.LBmap[apitems[0]]
+	_, cloud := bucketmd.CBmap[apitems[0]]
+	if local || cloud {
+		getstorstatsrunner().AddBucketErrorHTTP(apitems[0], r.Method)
+	}
+}
+
 // GET /v1/buckets/bucket-name
 func (t *targetrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 	apitems, err := t.checkRESTItems(w, r, 1, false, cmn.Version, cmn.Buckets)
@@ -606,6 +649,9 @@ func (t *targetrunner) verifyProxyRedirection(w http.ResponseWriter, r *http.Req
 // If the bucket is in the Cloud one and ValidateWarmGet is enabled there is an extra
 // check whether the object exists locally. Version is checked as well if configured.
 func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
+	span := tracing.StartFromRequest(r, "target.get")
+	defer span.Finish()
+	r = r.WithContext(tracing.NewContext(r.Context(), span))
 	var (
 		errcode    int
 		started    time.Time
@@ -655,13 +701,14 @@ func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 		pid := query.Get(cmn.URLParamProxyID)
 		glog.Infof("%s %s <= %s", r.Method, lom, pid)
 	}
+	span.Tag("object", bucket+"/"+objname)
 
 	// 2. under lock: versioning, checksum, restore from cluster
 	t.rtnamemap.Lock(lom.Uname, false)
 	coldGet := !lom.Exists()
 
 	if !coldGet {
-		if errstr = lom.Fill(bucketProvider, cluster.LomVersion|cluster.LomCksum); errstr != "" {
+		if errstr = lom.Fill(bucketProvider, cluster.LomVersion|cluster.LomCksum|cluster.LomUserMeta); errstr != "" {
 			_ = lom.Fill(bucketProvider, cluster.LomFstat)
 			if lom.Exists() {
 				t.rtnamemap.Unlock(lom.Uname, false)
@@ -675,12 +722,17 @@ func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 	// check if dryrun is enabled to stop from getting LOM
 	if coldGet && lom.BckIsLocal && !dryRun.disk && !dryRun.network {
 		// does not exist in the local bucket: restore from neighbors
-		if errstr, errcode = t.restoreObjLBNeigh(lom, r, started); errstr != "" {
+		restoreSpan := span.Child("restore")
+		errstr, errcode = t.restoreObjLBNeigh(lom, r, started)
+		restoreSpan.Finish()
+		if errstr != "" {
 			t.rtnamemap.Unlock(lom.Uname, false)
 			t.invalmsghdlr(w, r, errstr, errcode)
 			return
 		}
+		sendSpan := span.Child("send")
 		t.objGetComplete(w, r, lom, started, rangeOff, rangeLen, false)
+		sendSpan.Finish()
 		t.rtnamemap.Unlock(lom.Uname, false)
 		return
 	}
@@ -719,14 +771,19 @@ func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 	// 3. coldget
 	if coldGet && !dryRun.disk && !dryRun.network {
 		t.rtnamemap.Unlock(lom.Uname, false)
-		if errstr, errcode := t.getCold(ct, lom, false); errstr != "" {
+		coldSpan := span.Child("coldget")
+		errstr, errcode := t.getCold(ct, lom, false)
+		coldSpan.Finish()
+		if errstr != "" {
 			t.invalmsghdlr(w, r, errstr, errcode)
 			return
 		}
 	}
 
 	// 4. finally
+	sendSpan := span.Child("send")
 	t.objGetComplete(w, r, lom, started, rangeOff, rangeLen, coldGet)
+	sendSpan.Finish()
 	t.rtnamemap.Unlock(lom.Uname, false)
 }
 
@@ -787,7 +844,10 @@ func (t *targetrunner) restoreObjLBNeigh(lom *cluster.LOM, r *http.Request, star
 	}
 
 	// restore from existing EC slices if possible
-	if ecErr := t.ecmanager.RestoreObject(lom); ecErr == nil {
+	ecSpan := tracing.FromContext(r.Context()).Child("ec.restore")
+	ecErr := t.ecmanager.RestoreObject(lom, ecSpan)
+	ecSpan.Finish()
+	if ecErr == nil {
 		if glog.FastV(4, glog.SmoduleAIS) {
 			glog.Infof("%s/%s is restored successfully", lom.Bucket, lom.Objname)
 		}
@@ -851,6 +911,7 @@ func (t *targetrunner) objGetComplete(w http.ResponseWriter, r *http.Request, lo
 		hdr.Add(cmn.HeaderObjVersion, lom.Version)
 	}
 	hdr.Add(cmn.HeaderObjSize, strconv.FormatInt(lom.Size, 10))
+	cmn.UserMetaToHeader(hdr, lom.UserMeta)
 
 	// loopback if disk IO is disabled
 	if dryRun.disk {
@@ -861,12 +922,14 @@ func (t *targetrunner) objGetComplete(w http.ResponseWriter, r *http.Request, lo
 				errstr = fmt.Sprintf("dry-run: failed to send random response, err: %v", err)
 				glog.Error(errstr)
 				t.statsif.Add(stats.ErrGetCount, 1)
+				getstorstatsrunner().AddBucketErrorHTTP(lom.Bucket, r.Method)
 				return
 			}
 		}
 
 		delta := time.Since(started)
 		t.statsif.AddMany(stats.NamedVal64{stats.GetCount, 1}, stats.NamedVal64{stats.GetLatency, int64(delta)})
+		getstorstatsrunner().AddBucketGet(lom.Bucket, dryRun.size, delta, coldGet)
 		return
 	}
 	if lom.Size == 0 {
@@ -922,6 +985,7 @@ func (t *targetrunner) objGetComplete(w http.ResponseWriter, r *http.Request, lo
 		glog.Error(errstr)
 		t.fshc(err, fqn)
 		t.statsif.Add(stats.ErrGetCount, 1)
+		getstorstatsrunner().AddBucketErrorHTTP(lom.Bucket, r.Method)
 		return
 	}
 
@@ -942,6 +1006,7 @@ func (t *targetrunner) objGetComplete(w http.ResponseWriter, r *http.Request, lo
 		stats.NamedVal64{Name: stats.GetLatency, Val: int64(delta)},
 		stats.NamedVal64{Name: stats.GetCount, Val: 1},
 	)
+	getstorstatsrunner().AddBucketGet(lom.Bucket, written, delta, coldGet)
 }
 
 func (t *targetrunner) rangeCksum(file *os.File, fqn string, offset, length int64, buf []byte) (
@@ -1013,8 +1078,12 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 		t.invalmsghdlr(w, r, "OOS")
 		return
 	}
+	if query.Get(cmn.URLParamUploadID) != "" {
+		t.mpartPut(w, r, bucket, objname)
+		return
+	}
 
-	if err, errCode := t.doPut(r, bucket, objname); err != nil {
+	if err, errCode := t.doPut(r, bucket, objname, w.Header()); err != nil {
 		t.invalmsghdlr(w, r, err.Error(), errCode)
 	}
 }
@@ -1137,6 +1206,9 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.invalmsghdlr(w, r, s)
 		return
 	}
+	if !evict {
+		getstorstatsrunner().AddBucketDelete(bucket)
+	}
 	// EC cleanup if EC is enabled
 	t.ecmanager.CleanupObject(lom)
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -1224,6 +1296,18 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		}
 		// re-checksum the bucket and return
 		t.runRechecksumBucket(bucket)
+	case cmn.ActCopyBucket:
+		if !t.validatebckname(w, r, bucket) {
+			return
+		}
+		errstr := t.startCopyBucket(t.contextWithAuth(r), bucket, msgInt.Name, bucketProvider, &msgInt)
+		if errstr != "" {
+			t.invalmsghdlr(w, r, errstr)
+		}
+	case cmn.ActCopyBucketAbort:
+		if !t.xactions.abortCopyBucket(bucket) {
+			glog.Infof("%s: no copying of bucket %s in progress", tname(t.si), bucket)
+		}
 	case cmn.ActEraseCopies:
 		bucket := apitems[0]
 		if !t.validatebckname(w, r, bucket) {
@@ -1241,6 +1325,10 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 // POST /v1/objects/bucket-name/object-name
 func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	var msg cmn.ActionMsg
+	if r.URL.Query().Get(cmn.URLParamAction) == cmn.ActAppend {
+		t.appendObject(w, r)
+		return
+	}
 	if cmn.ReadJSON(w, r, &msg) != nil {
 		return
 	}
@@ -1249,6 +1337,8 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		t.renameObject(w, r, msg)
 	case cmn.ActReplicate:
 		t.replicate(w, r, msg)
+	case cmn.ActMPartInit, cmn.ActMPartComplete, cmn.ActMPartAbort:
+		t.mpartPost(w, r, msg)
 	default:
 		t.invalmsghdlr(w, r, "Unexpected action "+msg.Action)
 	}
@@ -1315,6 +1405,9 @@ func (t *targetrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 	hdr.Add(cmn.HeaderNextTierURL, props.NextTierURL)
 	hdr.Add(cmn.HeaderReadPolicy, props.ReadPolicy)
 	hdr.Add(cmn.HeaderWritePolicy, props.WritePolicy)
+	if props.OriginURL != "" {
+		hdr.Add(cmn.HeaderOriginURL, props.OriginURL)
+	}
 	hdr.Add(cmn.HeaderBucketChecksumType, cksumcfg.Checksum)
 	hdr.Add(cmn.HeaderBucketValidateColdGet, strconv.FormatBool(cksumcfg.ValidateColdGet))
 	hdr.Add(cmn.HeaderBucketValidateWarmGet, strconv.FormatBool(cksumcfg.ValidateWarmGet))
@@ -1359,7 +1452,7 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 	}
 	bucketProvider := query.Get(cmn.URLParamBucketProvider)
 	lom := &cluster.LOM{T: t, Bucket: bucket, Objname: objname}
-	if errstr = lom.Fill(bucketProvider, cluster.LomFstat|cluster.LomVersion); errstr != "" { // (doesnotexist -> ok, other)
+	if errstr = lom.Fill(bucketProvider, cluster.LomFstat|cluster.LomVersion|cluster.LomUserMeta); errstr != "" { // (doesnotexist -> ok, other)
 		t.invalmsghdlr(w, r, errstr)
 		return
 	}
@@ -1378,6 +1471,9 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 		objmeta = make(cmn.SimpleKVs)
 		objmeta[cmn.HeaderObjSize] = strconv.FormatInt(lom.Size, 10)
 		objmeta[cmn.HeaderObjVersion] = lom.Version
+		for k, v := range lom.UserMeta {
+			objmeta[cmn.HeaderObjMetaPrefix+k] = v
+		}
 		if glog.FastV(4, glog.SmoduleAIS) {
 			glog.Infof("%s(%s), ver=%s", lom, cmn.B2S(lom.Size, 1), lom.Version)
 		}
@@ -1534,6 +1630,16 @@ func (t *targetrunner) pushHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GET /metrics: stats in the Prometheus text exposition format
+func (t *targetrunner) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /metrics path")
+		return
+	}
+	w.Header().Set("Content-Type", stats.PromContentType)
+	getstorstatsrunner().WritePrometheus(w, t.si.DaemonID, gmem2)
+}
+
 //====================================================================================
 //
 // supporting methods and misc
@@ -1632,7 +1738,9 @@ func (t *targetrunner) checkCloudVersion(ct context.Context, bucket, objname, ve
 }
 
 func (t *targetrunner) getFromNeighbor(r *http.Request, lom *cluster.LOM) (remoteLOM *cluster.LOM, errstr string) {
-	neighsi := t.lookupRemotely(lom)
+	span := tracing.FromContext(r.Context()).Child("neighbor")
+	defer span.Finish()
+	neighsi := t.lookupRemotely(lom, span)
 	if neighsi == nil {
 		errstr = fmt.Sprintf("Failed cluster-wide lookup %s", lom)
 		return
@@ -1656,6 +1764,8 @@ func (t *targetrunner) getFromNeighbor(r *http.Request, lom *cluster.LOM) (remot
 		errstr = fmt.Sprintf("Unexpected failure to create %s request %s, err: %v", http.MethodGet, geturl, err)
 		return
 	}
+	span.Tag("neighbor", neighsi.DaemonID)
+	span.Inject(newr.Header)
 	// Do
 	contextwith, cancel := context.WithTimeout(context.Background(), lom.Config.Timeout.SendFile)
 	defer cancel()
@@ -1675,6 +1785,7 @@ func (t *targetrunner) getFromNeighbor(r *http.Request, lom *cluster.LOM) (remot
 	)
 
 	remoteLOM = lom.Copy(cluster.LOMCopyProps{Cksum: cksum, Version: version})
+	remoteLOM.UserMeta, _ = cmn.UserMetaFromHeader(response.Header)
 
 	roi := &recvObjInfo{
 		t:        t,
@@ -1738,7 +1849,7 @@ func (t *targetrunner) getCold(ct context.Context, lom *cluster.LOM, prefetch bo
 	}
 
 	// refill
-	if errstr = lom.Fill("", cluster.LomFstat|cluster.LomCksum|cluster.LomVersion); errstr != "" {
+	if errstr = lom.Fill("", cluster.LomFstat|cluster.LomCksum|cluster.LomVersion|cluster.LomUserMeta); errstr != "" {
 		glog.Warning(errstr)
 		lom.SetExists(false) // NOTE: in an attempt to fix it
 	}
@@ -1832,17 +1943,17 @@ ret:
 	return
 }
 
-func (t *targetrunner) lookupRemotely(lom *cluster.LOM) *cluster.Snode {
-	res := t.broadcastTo(
-		cmn.URLPath(cmn.Version, cmn.Objects, lom.Bucket, lom.Objname),
-		nil, // query
-		http.MethodHead,
-		nil,
-		t.smapowner.get(),
-		lom.Config.Timeout.MaxKeepalive,
-		cmn.NetworkIntraControl,
-		cluster.Targets,
-	)
+func (t *targetrunner) lookupRemotely(lom *cluster.LOM, span *tracing.Span) *cluster.Snode {
+	res := t.broadcast(bcastCallArgs{
+		req: reqArgs{
+			method: http.MethodHead,
+			path:   cmn.URLPath(cmn.Version, cmn.Objects, lom.Bucket, lom.Objname),
+		},
+		network: cmn.NetworkIntraControl,
+		timeout: lom.Config.Timeout.MaxKeepalive,
+		nodes:   []cluster.NodeMap{t.smapowner.get().Tmap},
+		span:    span,
+	})
 
 	for r := range res {
 		if r.err == nil {
@@ -1874,13 +1985,17 @@ func (t *targetrunner) prepareLocalObjectList(bucket string, msg *cmn.GetMsg) (*
 		err        error
 	}
 
+	filter, err := newListFilter(msg.GetFilter)
+	if err != nil {
+		return nil, err
+	}
 	availablePaths, _ := fs.Mountpaths.Get()
 	ch := make(chan *mresp, len(fs.CSM.RegisteredContentTypes)*len(availablePaths))
 	wg := &sync.WaitGroup{}
 
 	// function to traverse one mountpoint
 	walkMpath := func(dir string) {
-		r := &mresp{t.newFileWalk(bucket, msg), "", nil}
+		r := &mresp{t.newFileWalk(bucket, msg, filter), "", nil}
 		if _, err := os.Stat(dir); err != nil {
 			if !os.IsNotExist(err) {
 				r.failedPath = dir
@@ -2068,7 +2183,7 @@ func (t *targetrunner) listbucket(w http.ResponseWriter, r *http.Request, bucket
 	return
 }
 
-func (t *targetrunner) newFileWalk(bucket string, msg *cmn.GetMsg) *allfinfos {
+func (t *targetrunner) newFileWalk(bucket string, msg *cmn.GetMsg, filter *listFilter) *allfinfos {
 	// Marker is always a file name, so we need to strip filename from path
 	markerDir := ""
 	if msg.GetPageMarker != "" {
@@ -2095,6 +2210,8 @@ func (t *targetrunner) newFileWalk(bucket string, msg *cmn.GetMsg) *allfinfos {
 		needVersion:  strings.Contains(msg.GetProps, cmn.GetPropsVersion),
 		needStatus:   strings.Contains(msg.GetProps, cmn.GetPropsStatus),
 		needCopies:   strings.Contains(msg.GetProps, cmn.GetPropsCopies),
+		needUserMeta: strings.Contains(msg.GetProps, cmn.GetPropsUserMeta),
+		filter:       filter,
 		atimeRespCh:  make(chan *atime.Response, 1),
 	}
 
@@ -2136,6 +2253,7 @@ func (ci *allfinfos) processDir(fqn string) error {
 // Adds an info about cached object to the list if:
 //  - its name starts with prefix (if prefix is set)
 //  - it has not been already returned by previous page request
+//  - it passes the server-side filters (if any)
 //  - this target responses getobj request for the object
 func (ci *allfinfos) lsObject(lom *cluster.LOM, osfi os.FileInfo, objStatus string) error {
 	relname := lom.FQN[ci.rootLength:]
@@ -2145,6 +2263,14 @@ func (ci *allfinfos) lsObject(lom *cluster.LOM, osfi os.FileInfo, objStatus stri
 	if ci.marker != "" && relname <= ci.marker {
 		return nil
 	}
+	if ci.filter != nil {
+		if ci.filter.needAtime() {
+			lom.Fill("", cluster.LomAtime)
+		}
+		if !ci.filter.match(relname, lom, osfi, objStatus) {
+			return nil
+		}
+	}
 	// add the obj to the page
 	ci.fileCount++
 	fileInfo := &cmn.BucketEntry{
@@ -2155,7 +2281,7 @@ func (ci *allfinfos) lsObject(lom *cluster.LOM, osfi os.FileInfo, objStatus stri
 		Copies:   1,
 	}
 	lomAction := 0
-	if ci.needAtime {
+	if ci.needAtime && (ci.filter == nil || !ci.filter.needAtime()) {
 		lomAction |= cluster.LomAtime
 	}
 	if ci.needChkSum {
@@ -2164,6 +2290,9 @@ func (ci *allfinfos) lsObject(lom *cluster.LOM, osfi os.FileInfo, objStatus stri
 	if ci.needVersion {
 		lomAction |= cluster.LomVersion
 	}
+	if ci.needUserMeta {
+		lomAction |= cluster.LomUserMeta
+	}
 	if lomAction != 0 {
 		lom.Fill("", lomAction)
 	}
@@ -2191,6 +2320,9 @@ func (ci *allfinfos) lsObject(lom *cluster.LOM, osfi os.FileInfo, objStatus stri
 	if ci.needCopies && lom.HasCopy() {
 		fileInfo.Copies = 2 // 2-way, or not replicated
 	}
+	if ci.needUserMeta {
+		fileInfo.UserMeta = cmn.EncodeUserMeta(lom.UserMeta)
+	}
 	fileInfo.Size = osfi.Size()
 	ci.files = append(ci.files, fileInfo)
 	ci.lastFilePath = lom.FQN
@@ -2246,13 +2378,18 @@ func (ci *allfinfos) listwalkf(fqn string, osfi os.FileInfo, err error) error {
 // Cloud bucket:
 //  - if the Cloud returns a new version id then save it to xattr
 // In both case a new checksum is saved to xattrs
-func (t *targetrunner) doPut(r *http.Request, bucket, objname string) (err error, errcode int) {
+// When hdr is non-nil it receives the checksum and version of the stored object
+func (t *targetrunner) doPut(r *http.Request, bucket, objname string, hdr http.Header) (err error, errcode int) {
 	var (
 		cksumType  = r.Header.Get(cmn.HeaderObjCksumType)
 		cksumValue = r.Header.Get(cmn.HeaderObjCksumVal)
 		cksum      = cmn.NewCksum(cksumType, cksumValue)
 	)
 
+	userMeta, err := cmn.UserMetaFromHeader(r.Header)
+	if err != nil {
+		return err, http.StatusBadRequest
+	}
 	bucketProvider := r.URL.Query().Get(cmn.URLParamBucketProvider)
 	roi := &recvObjInfo{
 		t:              t,
@@ -2266,8 +2403,23 @@ func (t *targetrunner) doPut(r *http.Request, bucket, objname string) (err error
 	if err := roi.init(); err != nil {
 		return err, http.StatusInternalServerError
 	}
+	roi.lom.UserMeta = userMeta
 
-	return roi.recv()
+	if err, errcode = roi.recv(); err != nil {
+		return
+	}
+	getstorstatsrunner().AddBucketPut(bucket, roi.lom.Size, time.Since(roi.started))
+	if hdr != nil {
+		if roi.lom.Cksum != nil {
+			cksumType, cksumValue := roi.lom.Cksum.Get()
+			hdr.Set(cmn.HeaderObjCksumType, cksumType)
+			hdr.Set(cmn.HeaderObjCksumVal, cksumValue)
+		}
+		if roi.lom.Version != "" {
+			hdr.Set(cmn.HeaderObjVersion, roi.lom.Version)
+		}
+	}
+	return
 }
 
 // TODO: this function is for now unused because replication does not work
@@ -2314,10 +2466,12 @@ func (roi *recvObjInfo) init() error {
 
 func (roi *recvObjInfo) recv() (err error, errCode int) {
 	cmn.Assert(roi.lom != nil)
-	// optimize out if the checksums do match
+	// optimize out if the checksums (and user metadata) do match
 	if roi.lom.Exists() && roi.cksumToCheck != nil {
-		if errstr := roi.lom.Fill(roi.bucketProvider, cluster.LomCksum); errstr == "" {
-			if cmn.EqCksum(roi.lom.Cksum, roi.cksumToCheck) {
+		userMeta := roi.lom.UserMeta
+		if errstr := roi.lom.Fill(roi.bucketProvider, cluster.LomCksum|cluster.LomUserMeta); errstr == "" {
+			if cmn.EqCksum(roi.lom.Cksum, roi.cksumToCheck) &&
+				cmn.EncodeUserMeta(roi.lom.UserMeta) == cmn.EncodeUserMeta(userMeta) {
 				if glog.FastV(4, glog.SmoduleAIS) {
 					glog.Infof("%s is valid %s: PUT is a no-op", roi.lom, roi.cksumToCheck)
 				}
@@ -2325,6 +2479,7 @@ func (roi *recvObjInfo) recv() (err error, errCode int) {
 				return nil, 0
 			}
 		}
+		roi.lom.UserMeta = userMeta
 	}
 
 	if err = roi.writeToFile(); err != nil {
@@ -2380,7 +2535,7 @@ func (roi *recvObjInfo) tryCommit() (errstr string, errCode int) {
 		}
 
 		cmn.Assert(roi.lom.Cksum != nil)
-		roi.lom.Version, errstr, errCode = getcloudif().putobj(roi.ctx, file, roi.lom.Bucket, roi.lom.Objname, roi.lom.Cksum)
+		roi.lom.Version, errstr, errCode = getcloudif().putobj(roi.ctx, file, roi.lom.Bucket, roi.lom.Objname, roi.lom.Cksum, roi.lom.UserMeta)
 		file.Close()
 		if errstr != "" {
 			return
@@ -2461,6 +2616,7 @@ func (t *targetrunner) recvRebalanceObj(w http.ResponseWriter, hdr transport.Hea
 	}
 	roi.lom.Atime = time.Unix(0, hdr.ObjAttrs.Atime)
 	roi.lom.Version = hdr.ObjAttrs.Version
+	roi.lom.UserMeta = cmn.DecodeUserMeta(hdr.ObjAttrs.UserMeta)
 
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("Rebalance %s from %s", roi.lom, hdr.Opaque)
@@ -2591,7 +2747,8 @@ func (t *targetrunner) renameBucketObject(contentType, bucketFrom, objnameFrom,
 	defer file.Close()
 
 	lom := &cluster.LOM{T: t, FQN: fqn}
-	if errstr := lom.Fill("", cluster.LomFstat|cluster.LomVersion|cluster.LomAtime|cluster.LomCksum|cluster.LomCksumMissingRecomp); errstr != "" {
+	if errstr := lom.Fill("", cluster.LomFstat|cluster.LomVersion|cluster.LomAtime|cluster.LomCksum|cluster.LomCksumMissingRecomp|
+		cluster.LomUserMeta); errstr != "" {
 		return errstr
 	}
 	cksumType, cksumValue := lom.Cksum.Get()
@@ -2606,6 +2763,7 @@ func (t *targetrunner) renameBucketObject(contentType, bucketFrom, objnameFrom,
 			CksumType:  cksumType,
 			CksumValue: cksumValue,
 			Version:    lom.Version,
+			UserMeta:   cmn.EncodeUserMeta(lom.UserMeta),
 		},
 	}
 	wg := &sync.WaitGroup{}
@@ -2769,12 +2927,56 @@ func (t *targetrunner) httpdaeput(w http.ResponseWriter, r *http.Request) {
 		}
 	case cmn.ActShutdown:
 		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	case cmn.ActXactStart, cmn.ActXactStop:
+		xactMsg := &cmn.XactionMsg{}
+		b, err := jsoniter.Marshal(msg.Value)
+		if err == nil {
+			err = jsoniter.Unmarshal(b, xactMsg)
+		}
+		if err != nil {
+			t.invalmsghdlr(w, r, fmt.Sprintf("Failed to parse %s message, err: %v", msg.Action, err))
+			return
+		}
+		if msg.Action == cmn.ActXactStop {
+			var cnt int
+			if xactMsg.Kind == cmn.XactionDsort || xactMsg.UUID != "" {
+				cnt = dsort.Managers.Abort(xactMsg.UUID, xactMsg.Bucket)
+			} else {
+				cnt = t.xactions.abortL(xactMsg)
+			}
+			glog.Infof("%s: %s %+v - aborted %d xaction(s)", tname(t.si), msg.Action, *xactMsg, cnt)
+			return
+		}
+		if errstr := t.startXaction(xactMsg); errstr != "" {
+			t.invalmsghdlr(w, r, errstr)
+		}
 	default:
 		s := fmt.Sprintf("Unexpected cmn.ActionMsg <- JSON [%v]", msg)
 		t.invalmsghdlr(w, r, s)
 	}
 }
 
+// startXaction starts an xaction of a given kind; the kinds that require
+// a list of objects or a specification (prefetch, evict/delete, download, dsort, etc.)
+// are started by their respective APIs, while on-demand kinds (EC, putcopies) start themselves
+func (t *targetrunner) startXaction(msg *cmn.XactionMsg) (errstr string) {
+	switch msg.Kind {
+	case cmn.ActLRU:
+		go t.RunLRU()
+	case cmn.ActLocalReb:
+		go t.runLocalRebalance()
+	case cmn.ActRechecksum:
+		if msg.Bucket == "" {
+			return fmt.Sprintf("%s: bucket is required", msg.Kind)
+		}
+		go t.runRechecksumBucket(msg.Bucket)
+	default:
+		errstr = fmt.Sprintf("xaction %q cannot be started via %s, supported kinds: %s",
+			msg.Kind, cmn.ActXactStart, strings.Join(xactStartKinds, ", "))
+	}
+	return
+}
+
 func (t *targetrunner) httpdaesetprimaryproxy(w http.ResponseWriter, r *http.Request, apitems []string) {
 	var (
 		prepare bool
@@ -2845,11 +3047,24 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 			jsbytes = sts.GetRebalanceStats(kindDetails)
 		} else if kind == cmn.ActPrefetch {
 			jsbytes = sts.GetPrefetchStats(kindDetails)
+		} else if kind == cmn.ActCopyBucket {
+			jsbytes = t.getCopyBucketStats()
 		} else {
 			jsbytes, err = jsoniter.Marshal(kindDetails)
 			cmn.AssertNoErr(err)
 		}
 		t.writeJSON(w, r, jsbytes, "httpdaeget-"+getWhat)
+	case cmn.GetWhatBucketStats:
+		jsbytes, err := jsoniter.Marshal(getstorstatsrunner().GetBucketStats())
+		cmn.AssertNoErr(err)
+		t.writeJSON(w, r, jsbytes, "httpdaeget-"+getWhat)
+	case cmn.GetWhatXactionList:
+		query := r.URL.Query()
+		kind, bucket := query.Get(cmn.URLParamProps), query.Get(cmn.URLParamBucket)
+		list := append(t.xactions.listL(kind, bucket), dsortXactions(t.si.DaemonID, kind, bucket)...)
+		jsbytes, err := jsoniter.Marshal(list)
+		cmn.AssertNoErr(err)
+		t.writeJSON(w, r, jsbytes, "httpdaeget-"+getWhat)
 	case cmn.GetWhatMountpaths:
 		mpList := cmn.MountpathList{}
 		availablePaths, disabledPaths := fs.Mountpaths.Get()
@@ -2882,19 +3097,7 @@ func (t *targetrunner) getXactionsByKind(kind string) []stats.XactionDetails {
 	kindDetails := []stats.XactionDetails{}
 	matching := t.xactions.selectL(kind)
 	for _, xaction := range matching {
-		status := cmn.XactionStatusCompleted
-		if !xaction.Finished() {
-			status = cmn.XactionStatusInProgress
-		}
-		details := stats.XactionDetails{ // FIXME: redundant vs XactBase
-			ID:        xaction.ID(),
-			Kind:      xaction.Kind(),
-			Bucket:    xaction.Bucket(),
-			StartTime: xaction.StartTime(),
-			EndTime:   xaction.EndTime(),
-			Status:    status,
-		}
-		kindDetails = append(kindDetails, details)
+		kindDetails = append(kindDetails, xactDetails(xaction))
 	}
 	return kindDetails
 }
@@ -3059,13 +3262,18 @@ func (roi *recvObjInfo) writeToFile() (err error) {
 		hashes = []hash.Hash{saveHash}
 
 		// if configured and the cksum is provied we should also check md5 hash (aws, gcp)
+		// or xxhash (remote AIS cluster)
 		if roi.lom.Cksumcfg.ValidateColdGet && roi.cksumToCheck != nil {
 			expectedCksum = roi.cksumToCheck
 			checkCksumType, _ = expectedCksum.Get()
-			cmn.AssertMsg(checkCksumType == cmn.ChecksumMD5, checkCksumType)
+			cmn.AssertMsg(checkCksumType == cmn.ChecksumMD5 || checkCksumType == cmn.ChecksumXXHash, checkCksumType)
 
-			checkHash = md5.New()
-			hashes = append(hashes, checkHash)
+			if checkCksumType == cmn.ChecksumXXHash {
+				checkHash = saveHash
+			} else {
+				checkHash = md5.New()
+				hashes = append(hashes, checkHash)
+			}
 		}
 	}
 
@@ -3389,6 +3597,7 @@ func (t *targetrunner) receiveBucketMD(newbucketmd *bucketMD, msgInt *actionMsgI
 		}
 	}
 	fs.Mountpaths.CreateDestroyLocalBuckets("receive-bucketmd", false /*false=destroy*/, bucketsToDelete...)
+	getstorstatsrunner().DelBucketStats(bucketsToDelete...)
 
 	// Create buckets that have been added
 	bucketsToCreate := make([]string, 0, len(newbucketmd.LBmap))
@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/dsort/extract"
 	"github.com/NVIDIA/aistore/tutils"
@@ -36,6 +37,7 @@ type dsortFramework struct {
 	outputShardCnt    int
 
 	extension       string
+	outputBucket    string
 	algorithm       *dsort.SortAlgorithm
 	outputShardSize int64
 	maxMemUsage     string
@@ -53,11 +55,15 @@ func (df *dsortFramework) init() {
 	if df.algorithm == nil {
 		df.algorithm = &dsort.SortAlgorithm{}
 	}
+	if df.outputBucket == "" {
+		df.outputBucket = df.m.bucket
+	}
 }
 
 func (df *dsortFramework) gen() dsort.RequestSpec {
 	return dsort.RequestSpec{
 		Bucket:           df.m.bucket,
+		OutputBucket:     df.outputBucket,
 		Extension:        df.extension,
 		IntputFormat:     df.inputTempl,
 		OutputFormat:     df.outputTempl,
@@ -129,7 +135,7 @@ func (df *dsortFramework) checkOutputShards(zeros int) {
 		getOptions := api.GetObjectInput{
 			Writer: &buffer,
 		}
-		_, err := api.GetObject(baseParams, df.m.bucket, shardName, getOptions)
+		_, err := api.GetObject(baseParams, df.outputBucket, shardName, getOptions)
 		if err != nil && df.extension == ".zip" && i > df.outputShardCnt/2 {
 			// We estimated too much output shards to be produced - zip compression
 			// was so good that we could fit more files inside the shard.
@@ -340,6 +346,70 @@ func TestDistributedSort(t *testing.T) {
 	dsortFW.checkOutputShards(5)
 }
 
+func TestDistributedSortWithOutputBucket(t *testing.T) {
+	var (
+		err error
+		m   = &metadata{
+			t:      t,
+			bucket: TestLocalBucketName,
+		}
+		dsortFW = &dsortFramework{
+			m:                 m,
+			inputTempl:        "input-{0..999}",
+			outputTempl:       "output-{00000..01000}",
+			outputBucket:      TestLocalBucketName + "-output",
+			tarballCnt:        1000,
+			fileInTarballCnt:  100,
+			fileInTarballSize: 1024,
+			extension:         ".tar",
+			maxMemUsage:       "99%",
+		}
+	)
+	if testing.Short() {
+		t.Skip(skipping)
+	}
+
+	dsortFW.init()
+
+	// Initialize metadata
+	saveClusterState(m)
+	if m.originalTargetCount < 3 {
+		t.Fatalf("Must have 3 or more targets in the cluster, have only %d", m.originalTargetCount)
+	}
+
+	// Create local buckets
+	tutils.CreateFreshLocalBucket(t, m.proxyURL, m.bucket)
+	defer tutils.DestroyLocalBucket(t, m.proxyURL, m.bucket)
+
+	dsortFW.createInputShards()
+
+	// Output bucket must exist
+	rs := dsortFW.gen()
+	if _, err = tutils.StartDSort(m.proxyURL, rs); err == nil {
+		t.Errorf("expected dsort to fail when output bucket %q does not exist", dsortFW.outputBucket)
+	}
+
+	tutils.CreateFreshLocalBucket(t, m.proxyURL, dsortFW.outputBucket)
+	defer tutils.DestroyLocalBucket(t, m.proxyURL, dsortFW.outputBucket)
+
+	tutils.Logln("started distributed sort...")
+	managerUUID, err := tutils.StartDSort(m.proxyURL, rs)
+	tutils.CheckFatal(err, t)
+
+	_, err = tutils.WaitForDSortToFinish(m.proxyURL, managerUUID)
+	tutils.CheckFatal(err, t)
+	tutils.Logln("finished distributed sort")
+
+	dsortFW.checkOutputShards(5)
+
+	// Input bucket must be left intact
+	objs, err := tutils.ListObjects(m.proxyURL, m.bucket, cmn.LocalBs, "", 0)
+	tutils.CheckFatal(err, t)
+	if len(objs) != dsortFW.tarballCnt {
+		t.Errorf("number of objects in input bucket %d is not same as number of input shards %d", len(objs), dsortFW.tarballCnt)
+	}
+}
+
 // TestDistributedSortParallel runs multiple dSorts in parallel
 func TestDistributedSortParallel(t *testing.T) {
 	var (
@@ -0,0 +1,90 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// max number of finished xactions that each daemon remembers
+const xactHistorySize = 256
+
+//
+// xactHistory: bounded journal of finished xactions persisted under config.Confdir
+// so that operators could audit what ran (and how long it took) across restarts
+//
+
+type xactHistory struct {
+	sync.Mutex
+	path    string
+	entries []stats.XactionDetails // oldest first
+	version int64                  // incremented upon each record
+	saveMtx sync.Mutex             // serializes saving
+	saved   int64                  // version that has been saved (under saveMtx)
+}
+
+func newXactHistory(path string) *xactHistory {
+	h := &xactHistory{path: path}
+	if err := cmn.LocalLoad(path, &h.entries); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("Failed to load xaction history %s, err: %v", path, err)
+	}
+	return h
+}
+
+// record appends the finished (or aborted) xaction to the journal; given that the
+// callers may hold the xactions' lock the journal is persisted asynchronously
+func (h *xactHistory) record(xact cmn.Xact) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	h.entries = append(h.entries, xactDetails(xact))
+	if l := len(h.entries); l > xactHistorySize {
+		h.entries = append(h.entries[:0], h.entries[l-xactHistorySize:]...)
+	}
+	h.version++
+	h.Unlock()
+	go h.save()
+}
+
+// save persists the journal unless the current version has already been saved
+func (h *xactHistory) save() {
+	if h == nil {
+		return
+	}
+	h.saveMtx.Lock()
+	defer h.saveMtx.Unlock()
+	h.Lock()
+	if h.version == h.saved {
+		h.Unlock()
+		return
+	}
+	version, entries := h.version, append([]stats.XactionDetails(nil), h.entries...)
+	h.Unlock()
+	if err := cmn.LocalSave(h.path, entries); err != nil {
+		glog.Errorf("Failed to save xaction history %s, err: %v", h.path, err)
+		return
+	}
+	h.saved = version
+}
+
+// get returns the journal, most recently finished first
+func (h *xactHistory) get() []stats.XactionDetails {
+	list := []stats.XactionDetails{}
+	if h == nil {
+		return list
+	}
+	h.Lock()
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		list = append(list, h.entries[i])
+	}
+	h.Unlock()
+	return list
+}
@@ -18,9 +18,11 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/mirror"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 type (
@@ -29,6 +31,7 @@ type (
 		v       []cmn.Xact
 		nextid  int64
 		cleanup bool
+		history *xactHistory // xactions get recorded when they finish or abort (nil in tests)
 	}
 	xactRebalance struct {
 		cmn.XactBase
@@ -59,6 +62,10 @@ type (
 		cmn.XactBase
 		bucket string
 	}
+	xactCopyBucket struct {
+		cmn.XactBase
+		bucketTo string
+	}
 )
 
 //===================
@@ -72,8 +79,15 @@ func newXs() *xactions {
 	return xs
 }
 
-func (xs *xactions) uniqueid() int64   { xs.nextid++; return xs.nextid } // under lock
-func (xs *xactions) add(xact cmn.Xact) { xs.v = append(xs.v, xact) }     // ditto
+func (xs *xactions) uniqueid() int64 { xs.nextid++; return xs.nextid } // under lock
+
+// add registers the xaction (under lock) and arranges for it to be recorded in the history
+func (xs *xactions) add(xact cmn.Xact) {
+	xs.v = append(xs.v, xact)
+	if xs.history != nil {
+		xact.OnEnd(func() { xs.history.record(xact) })
+	}
+}
 
 func (xs *xactions) findU(kind string) (xact cmn.Xact) {
 	if xs.cleanup {
@@ -310,12 +324,39 @@ func (xs *xactions) renewRechecksum(bucket string) *xactRechecksum {
 		return nil
 	}
 	id := xs.uniqueid()
-	xrcksum := &xactRechecksum{XactBase: *cmn.NewXactBase(id, kind), bucket: bucket}
+	xrcksum := &xactRechecksum{XactBase: *cmn.NewXactBase(id, kind, bucket), bucket: bucket}
 	xs.add(xrcksum)
 	xs.Unlock()
 	return xrcksum
 }
 
+func (xs *xactions) renewCopyBucket(bucketFrom, bucketTo string) *xactCopyBucket {
+	kind := path.Join(cmn.ActCopyBucket, bucketFrom)
+	xs.Lock()
+	defer xs.Unlock()
+	xx := xs.findU(kind)
+	if xx != nil {
+		glog.Errorf("%s is already running, cannot copy %s => %s", xx, bucketFrom, bucketTo)
+		return nil
+	}
+	id := xs.uniqueid()
+	xcopy := &xactCopyBucket{XactBase: *cmn.NewXactBase(id, kind, bucketFrom), bucketTo: bucketTo}
+	xs.add(xcopy)
+	return xcopy
+}
+
+func (xs *xactions) abortCopyBucket(bucketFrom string) (aborted bool) {
+	kind := path.Join(cmn.ActCopyBucket, bucketFrom)
+	xs.Lock()
+	xx := xs.findU(kind)
+	if xx != nil {
+		xx.Abort()
+		aborted = true
+	}
+	xs.Unlock()
+	return
+}
+
 func (xs *xactions) renewPutCopies(lom *cluster.LOM, t *targetrunner) (xcopy *mirror.XactCopy) {
 	kindput := path.Join(cmn.ActPutCopies, lom.Bucket)
 	xs.Lock()
@@ -390,12 +431,12 @@ func (xs *xactions) renewEraseCopies(bucket string, t *targetrunner, bckIsLocal
 	xs.Unlock()
 }
 
-// PutCopies and EraseCopies as those are currently the only bucket-specific xaction we may have
+// PutCopies, EraseCopies, and CopyBucket as those are currently the only bucket-specific xaction we may have
 func (xs *xactions) abortBucketSpecific(bucket string) {
 	xs.Lock()
 	defer xs.Unlock()
 	var (
-		bucketSpecific = []string{cmn.ActPutCopies, cmn.ActEraseCopies}
+		bucketSpecific = []string{cmn.ActPutCopies, cmn.ActEraseCopies, cmn.ActCopyBucket}
 		wg             = &sync.WaitGroup{}
 	)
 	for _, act := range bucketSpecific {
@@ -446,7 +487,9 @@ func (xs *xactions) abortAll() (sleep bool) {
 			sleep = true
 		}
 	}
+	xs._cleanup()
 	xs.Unlock()
+	xs.history.save() // prior to shutting down
 	return
 }
 
@@ -470,3 +513,115 @@ func (xs *xactions) renewEC() *ec.XactEC {
 	xs.Unlock()
 	return xec
 }
+
+//
+// registry: uniform query and abort by ID, kind, and/or bucket
+//
+
+// e.g., "putcopies/bucket-name" matches "putcopies"
+func xactKindMatch(xact cmn.Xact, kind string) bool {
+	return kind == "" || xact.Kind() == kind || path.Dir(xact.Kind()) == kind
+}
+
+func xactDetails(xact cmn.Xact) stats.XactionDetails {
+	status := cmn.XactionStatusCompleted
+	if xact.Aborted() {
+		status = cmn.XactionStatusAborted
+	} else if !xact.Finished() {
+		status = cmn.XactionStatusInProgress
+	}
+	details := stats.XactionDetails{
+		ID:         xact.ID(),
+		Kind:       xact.Kind(),
+		Bucket:     xact.Bucket(),
+		StartTime:  xact.StartTime(),
+		EndTime:    xact.EndTime(),
+		Status:     status,
+		ObjCount:   xact.ObjCount(),
+		BytesCount: xact.BytesCount(),
+		ErrCount:   xact.ErrCount(),
+	}
+	if xact.Finished() {
+		details.Duration = details.EndTime.Sub(details.StartTime)
+	}
+	return details
+}
+
+// xactStartKinds are the kinds of xactions that can be started via cmn.ActXactStart
+var xactStartKinds = []string{cmn.ActGlobalReb, cmn.ActLocalReb, cmn.ActLRU, cmn.ActRechecksum}
+
+// listL returns all xactions (including recently finished) of a given kind and bucket;
+// empty kind and/or bucket match all
+func (xs *xactions) listL(kind, bucket string) []stats.XactionDetails {
+	list := []stats.XactionDetails{}
+	xs.Lock()
+	for _, xact := range xs.v {
+		if xactKindMatch(xact, kind) && (bucket == "" || xact.Bucket() == bucket) {
+			list = append(list, xactDetails(xact))
+		}
+	}
+	xs.Unlock()
+	return list
+}
+
+// dsortXactions returns the dSort jobs known to this target as xactions of the
+// kind cmn.XactionDsort: objects are the created shards, bytes - the extracted ones
+func dsortXactions(daemonID, kind, bucket string) []stats.XactionDetails {
+	if kind != "" && kind != cmn.XactionDsort {
+		return nil
+	}
+	jobs, err := dsort.Managers.List(daemonID)
+	if err != nil {
+		glog.Errorf("Failed to list dSort jobs, err: %v", err)
+		return nil
+	}
+	list := make([]stats.XactionDetails, 0, len(jobs))
+	for _, job := range jobs {
+		if job.RequestSpec == nil || (bucket != "" && job.RequestSpec.Bucket != bucket) {
+			continue
+		}
+		details := stats.XactionDetails{
+			UUID:      job.ID,
+			Kind:      cmn.XactionDsort,
+			Bucket:    job.RequestSpec.Bucket,
+			StartTime: job.StartedTime,
+			EndTime:   job.FinishTime,
+			Status:    cmn.XactionStatusInProgress,
+		}
+		switch job.Status {
+		case dsort.JobAborted:
+			details.Status = cmn.XactionStatusAborted
+		case dsort.JobFinished:
+			details.Status = cmn.XactionStatusCompleted
+			details.Duration = details.EndTime.Sub(details.StartTime)
+		}
+		if metrics, ok := job.Metrics[daemonID]; ok && metrics.Creation != nil && metrics.Extraction != nil {
+			details.ObjCount = int64(metrics.Creation.CreatedCnt)
+			details.BytesCount = metrics.Extraction.ExtractedSize
+		}
+		list = append(list, details)
+	}
+	return list
+}
+
+// abortL aborts the running xaction with a given ID or, if the ID is zero,
+// all running xactions of a given kind and/or bucket; returns the number of aborted
+func (xs *xactions) abortL(msg *cmn.XactionMsg) (cnt int) {
+	xs.Lock()
+	for _, xact := range xs.v {
+		if xact.Finished() {
+			continue
+		}
+		if msg.ID != 0 {
+			if xact.ID() != msg.ID {
+				continue
+			}
+		} else if !xactKindMatch(xact, msg.Kind) || (msg.Bucket != "" && xact.Bucket() != msg.Bucket) {
+			continue
+		}
+		xact.Abort()
+		cnt++
+	}
+	xs.Unlock()
+	return
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+package ais
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestXactionRegistry(t *testing.T) {
+	xs := newXs()
+	xlru := xs.renewLRU()
+	xrcksum := xs.renewRechecksum("b1")
+	xcopy := xs.renewCopyBucket("b2", "b3")
+	xlru.ObjectsAdd(2)
+	xlru.BytesAdd(1024)
+
+	if list := xs.listL("", ""); len(list) != 3 {
+		t.Fatalf("expected 3 xactions, got %+v", list)
+	}
+	list := xs.listL(cmn.ActLRU, "")
+	if len(list) != 1 || list[0].ID != xlru.ID() || list[0].ObjCount != 2 || list[0].BytesCount != 1024 ||
+		list[0].Status != cmn.XactionStatusInProgress {
+		t.Errorf("unexpected LRU details %+v", list)
+	}
+	// "rechecksum/b1" is of kind "rechecksum"
+	if list := xs.listL(cmn.ActRechecksum, "b1"); len(list) != 1 || list[0].ID != xrcksum.ID() {
+		t.Errorf("unexpected rechecksum details %+v", list)
+	}
+	if list := xs.listL("", "b2"); len(list) != 1 || list[0].ID != xcopy.ID() {
+		t.Errorf("unexpected details of bucket b2 %+v", list)
+	}
+
+	// abort by ID and by bucket
+	if cnt := xs.abortL(&cmn.XactionMsg{ID: xrcksum.ID()}); cnt != 1 || !xrcksum.Aborted() || xlru.Finished() {
+		t.Errorf("expected %s to be aborted (%d)", xrcksum, cnt)
+	}
+	if cnt := xs.abortL(&cmn.XactionMsg{ID: xrcksum.ID()}); cnt != 0 {
+		t.Errorf("expected finished %s not to be aborted again", xrcksum)
+	}
+	if cnt := xs.abortL(&cmn.XactionMsg{Bucket: "b2"}); cnt != 1 || !xcopy.Aborted() {
+		t.Errorf("expected %s to be aborted (%d)", xcopy, cnt)
+	}
+
+	xlru.EndTime(time.Now())
+	for _, details := range xs.listL("", "") {
+		expected := cmn.XactionStatusAborted
+		if details.ID == xlru.ID() {
+			expected = cmn.XactionStatusCompleted
+		}
+		if details.Status != expected {
+			t.Errorf("expected %s, got %+v", expected, details)
+		}
+	}
+}
+
+func TestXactionHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xhistory")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, cmn.XactHistoryFile)
+
+	xs := newXs()
+	xs.history = newXactHistory(path)
+	xlru := xs.renewLRU()
+	xlru.ObjectsAdd(10)
+	xlru.EndTime(time.Now())
+	xrcksum := xs.renewRechecksum("b1")
+	xrcksum.ErrorsAdd(1)
+	if history := xs.history.get(); len(history) != 1 || history[0].ID != xlru.ID() {
+		t.Fatalf("expected the finished xaction to be recorded, got %+v", history)
+	}
+	xs.history.save() // (otherwise, saved asynchronously)
+	if history := newXactHistory(path).get(); len(history) != 1 {
+		t.Fatalf("expected the history to be persisted, got %+v", history)
+	}
+	xs.abortAll() // shutdown
+
+	// restart
+	history := newXactHistory(path).get()
+	if len(history) != 2 {
+		t.Fatalf("expected 2 finished xactions, got %+v", history)
+	}
+	if history[0].ID != xrcksum.ID() || history[0].Status != cmn.XactionStatusAborted || history[0].ErrCount != 1 {
+		t.Errorf("unexpected %+v", history[0])
+	}
+	if history[1].ID != xlru.ID() || history[1].Status != cmn.XactionStatusCompleted || history[1].ObjCount != 10 ||
+		history[1].Duration <= 0 {
+		t.Errorf("unexpected %+v", history[1])
+	}
+
+	// bounded
+	h := newXactHistory(path)
+	for i := 0; i < xactHistorySize; i++ {
+		h.record(xlru)
+	}
+	if history = h.get(); len(history) != xactHistorySize || history[xactHistorySize-1].ID != xlru.ID() {
+		t.Errorf("expected history to be bounded by %d, got %d", xactHistorySize, len(history))
+	}
+}
+
+func TestXactStartStopInvalid(t *testing.T) {
+	p := &proxyrunner{}
+	p.statsif = nopStatsTracker{}
+	tests := []struct {
+		name    string
+		action  string
+		xactMsg cmn.XactionMsg
+		errmsg  string
+	}{
+		{name: "start prefetch", action: cmn.ActXactStart, xactMsg: cmn.XactionMsg{Kind: cmn.ActPrefetch}, errmsg: strings.Join(xactStartKinds, ", ")},
+		{name: "start dsort", action: cmn.ActXactStart, xactMsg: cmn.XactionMsg{Kind: cmn.XactionDsort}, errmsg: strings.Join(xactStartKinds, ", ")},
+		{name: "stop nothing", action: cmn.ActXactStop, errmsg: "must be specified"},
+		{name: "stop dsort on target", action: cmn.ActXactStop, xactMsg: cmn.XactionMsg{UUID: "uuid", TargetID: "t1"}, errmsg: "all targets"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPut, cmn.URLPath(cmn.Version, cmn.Cluster), nil)
+		p.xactStartStop(w, r, &cmn.ActionMsg{Action: test.action, Value: test.xactMsg})
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), test.errmsg) {
+			t.Errorf("%s: expected %d %q, got %d %q", test.name, http.StatusBadRequest, test.errmsg, w.Code, w.Body.String())
+		}
+	}
+}
@@ -5,7 +5,9 @@
 package api
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -173,6 +175,7 @@ func HeadBucket(baseParams *BaseParams, bucket string, query ...url.Values) (*cm
 		NextTierURL:   r.Header.Get(cmn.HeaderNextTierURL),
 		ReadPolicy:    r.Header.Get(cmn.HeaderReadPolicy),
 		WritePolicy:   r.Header.Get(cmn.HeaderWritePolicy),
+		OriginURL:     r.Header.Get(cmn.HeaderOriginURL),
 		CksumConf:     cksumconf,
 		LRUConf:       lruprops,
 		MirrorConf:    mirror,
@@ -264,6 +267,40 @@ func RenameLocalBucket(baseParams *BaseParams, oldName, newName string) error {
 	return err
 }
 
+// CopyBucket API
+//
+// CopyBucket starts an extended action (xaction) that copies all objects of the bucket,
+// or only those that satisfy the optional msg filters, to the local bucket toBucket
+// (created if it does not exist). The progress is reported by the xaction stats
+// API (kind cmn.ActCopyBucket)
+func CopyBucket(baseParams *BaseParams, fromBucket, toBucket string, msg *cmn.CopyBucketMsg, query ...url.Values) error {
+	b, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActCopyBucket, Name: toBucket, Value: msg})
+	if err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPost
+	path := cmn.URLPath(cmn.Version, cmn.Buckets, fromBucket)
+	if len(query) > 0 {
+		path += "?" + query[0].Encode()
+	}
+	_, err = DoHTTPRequest(baseParams, path, b)
+	return err
+}
+
+// AbortCopyBucket API
+//
+// AbortCopyBucket aborts copying of the bucket on all targets
+func AbortCopyBucket(baseParams *BaseParams, fromBucket string) error {
+	b, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActCopyBucketAbort})
+	if err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPost
+	path := cmn.URLPath(cmn.Version, cmn.Buckets, fromBucket)
+	_, err = DoHTTPRequest(baseParams, path, b)
+	return err
+}
+
 // ListBucket API
 //
 // ListBucket returns list of objects in a bucket. numObjects is the
@@ -329,6 +366,59 @@ func ListBucket(baseParams *BaseParams, bucket string, msg *cmn.GetMsg, numObjec
 	return reslist, nil
 }
 
+// ListBucketStream API
+//
+// Streams the entire listing of a given bucket (see cmn.GetMsg.GetStream), calling
+// the callback for each listed object, in order. Stops and returns the callback's
+// error if the callback fails
+func ListBucketStream(baseParams *BaseParams, bucket string, msg *cmn.GetMsg, cb func(*cmn.BucketEntry) error,
+	query ...url.Values) error {
+	var querystr = ""
+	baseParams.Method = http.MethodPost
+	if len(query) > 0 {
+		querystr = "?" + query[0].Encode()
+	}
+	path := cmn.URLPath(cmn.Version, cmn.Buckets, bucket) + querystr
+	streamMsg := *msg
+	streamMsg.GetStream = true
+	b, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActListObjects, Value: &streamMsg})
+	if err != nil {
+		return err
+	}
+	optParams := OptionalParams{Header: http.Header{
+		"Content-Type": []string{"application/json"},
+	}}
+	resp, err := doHTTPRequestGetResp(baseParams, path, b, optParams)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// one JSON-encoded entry per line
+	reader := bufio.NewReader(resp.Body)
+	for {
+		var line []byte
+		if line, err = reader.ReadBytes('\n'); err != nil {
+			break
+		}
+		entry := &cmn.BucketEntry{}
+		if err = jsoniter.Unmarshal(line, entry); err != nil {
+			break
+		}
+		if err = cb(entry); err != nil {
+			return err
+		}
+	}
+	if err != io.EOF {
+		return fmt.Errorf("failed to read bucket %s listing, err: %v", bucket, err)
+	}
+	// the trailer is available only after the body is read to the end
+	if errstr := resp.Trailer.Get("Error"); errstr != "" {
+		return fmt.Errorf("failed to list bucket %s, err: %s", bucket, errstr)
+	}
+	return nil
+}
+
 // EraseCopies API
 //
 // EraseCopies starts an extended action (xaction) to reduce redundancy of a given bucket to 1 (single copy)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -49,6 +50,81 @@ func GetClusterMap(baseParams *BaseParams) (cluster.Smap, error) {
 	return smap, nil
 }
 
+// GetClusterBucketStats API
+//
+// GetClusterBucketStats returns per-bucket GET/PUT/DELETE traffic stats summed up
+// across all targets of the cluster (the request must be sent to a proxy)
+func GetClusterBucketStats(baseParams *BaseParams) ([]stats.BucketStats, error) {
+	q := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatBucketStats}}
+	optParams := OptionalParams{Query: q}
+	baseParams.Method = http.MethodGet
+	path := cmn.URLPath(cmn.Version, cmn.Cluster)
+	b, err := DoHTTPRequest(baseParams, path, nil, optParams)
+	if err != nil {
+		return nil, err
+	}
+	var bstats []stats.BucketStats
+	if err = jsoniter.Unmarshal(b, &bstats); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal bucket stats, err: %v", err)
+	}
+	return bstats, nil
+}
+
+// GetClusterXactions API
+//
+// GetClusterXactions returns the xactions of all targets (the request must be sent to a proxy);
+// empty kind and/or bucket select all
+func GetClusterXactions(baseParams *BaseParams, kind, bucket string) ([]stats.XactionDetails, error) {
+	q := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatXactionList}}
+	if kind != "" {
+		q.Set(cmn.URLParamProps, kind)
+	}
+	if bucket != "" {
+		q.Set(cmn.URLParamBucket, bucket)
+	}
+	optParams := OptionalParams{Query: q}
+	baseParams.Method = http.MethodGet
+	path := cmn.URLPath(cmn.Version, cmn.Cluster)
+	b, err := DoHTTPRequest(baseParams, path, nil, optParams)
+	if err != nil {
+		return nil, err
+	}
+	var xactions []stats.XactionDetails
+	if err = jsoniter.Unmarshal(b, &xactions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal xactions, err: %v", err)
+	}
+	return xactions, nil
+}
+
+// StartXaction API
+//
+// StartXaction starts an xaction of a given kind cluster-wide; bucket is required
+// by bucket-specific kinds (e.g., rechecksum) and ignored otherwise
+func StartXaction(baseParams *BaseParams, kind, bucket string) error {
+	return xactAction(baseParams, cmn.ActXactStart, &cmn.XactionMsg{Kind: kind, Bucket: bucket})
+}
+
+// StopXaction API
+//
+// StopXaction aborts the xaction with a given ID on a given target (xaction IDs
+// are unique only within a target) or, if the ID is zero, all running xactions
+// of a given kind and/or bucket - on all targets or on the given target only;
+// dSort jobs are aborted on all targets by UUID (see cmn.XactionMsg)
+func StopXaction(baseParams *BaseParams, xactMsg *cmn.XactionMsg) error {
+	return xactAction(baseParams, cmn.ActXactStop, xactMsg)
+}
+
+func xactAction(baseParams *BaseParams, action string, xactMsg *cmn.XactionMsg) error {
+	msg, err := jsoniter.Marshal(cmn.ActionMsg{Action: action, Value: xactMsg})
+	if err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPut
+	path := cmn.URLPath(cmn.Version, cmn.Cluster)
+	_, err = DoHTTPRequest(baseParams, path, msg)
+	return err
+}
+
 // RegisterTarget API
 //
 // Registers an existing target to the clustermap.
@@ -11,6 +11,7 @@ import (
 	"net/url"
 
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -31,6 +32,24 @@ func GetMountpaths(baseParams *BaseParams) (*cmn.MountpathList, error) {
 	return mpl, err
 }
 
+// GetXactionHistory API
+//
+// Given the direct public URL of a target (or proxy), GetXactionHistory returns its journal
+// of finished xactions, most recently finished first; the journal persists across restarts
+func GetXactionHistory(baseParams *BaseParams) ([]stats.XactionDetails, error) {
+	q := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatXactionHistory}}
+	optParams := OptionalParams{Query: q}
+	baseParams.Method = http.MethodGet
+	path := cmn.URLPath(cmn.Version, cmn.Daemon)
+	b, err := DoHTTPRequest(baseParams, path, nil, optParams)
+	if err != nil {
+		return nil, err
+	}
+	var history []stats.XactionDetails
+	err = json.Unmarshal(b, &history)
+	return history, err
+}
+
 // AddMountpath API
 func AddMountpath(baseParams *BaseParams, mountPath string) error {
 	baseParams.Method = http.MethodPut
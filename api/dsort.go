@@ -0,0 +1,39 @@
+// Package api provides RESTful API to AIS object storage
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort"
+)
+
+// ListDSort API
+//
+// ListDSort returns all dSort jobs known to the cluster (running, finished and aborted)
+// together with their request specs, timings and metrics gathered from all the targets
+func ListDSort(baseParams *BaseParams) ([]*dsort.JobInfo, error) {
+	baseParams.Method = http.MethodGet
+	path := cmn.URLPath(cmn.Version, cmn.Sort, cmn.List)
+	b, err := DoHTTPRequest(baseParams, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []*dsort.JobInfo
+	err = json.Unmarshal(b, &jobs)
+	return jobs, err
+}
+
+// RemoveDSort API
+//
+// RemoveDSort removes the metadata of the finished (or aborted) dSort job from all the targets
+func RemoveDSort(baseParams *BaseParams, managerUUID string) error {
+	baseParams.Method = http.MethodDelete
+	path := cmn.URLPath(cmn.Version, cmn.Sort, cmn.Remove, managerUUID)
+	_, err := DoHTTPRequest(baseParams, path, nil)
+	return err
+}
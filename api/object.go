@@ -43,11 +43,12 @@ type PutObjectArgs struct {
 	Object         string
 	Hash           string
 	Reader         cmn.ReadOpenCloser
+	UserMeta       cmn.SimpleKVs // optional user-defined metadata
 }
 
 // HeadObject API
 //
-// Returns the size and version of the object specified by bucket/object
+// Returns the size, version and user-defined metadata of the object specified by bucket/object
 func HeadObject(baseParams *BaseParams, bucket, bucketProvider, object string) (*cmn.ObjectProps, error) {
 	bucketProviderStr := "?" + cmn.URLParamBucketProvider + "=" + bucketProvider
 	r, err := baseParams.Client.Head(baseParams.URL + cmn.URLPath(cmn.Version, cmn.Objects, bucket, object) + bucketProviderStr)
@@ -70,9 +71,15 @@ func HeadObject(baseParams *BaseParams, bucket, bucketProvider, object string) (
 		return nil, err
 	}
 
+	userMeta, err := cmn.UserMetaFromHeader(r.Header)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cmn.ObjectProps{
-		Size:    size,
-		Version: r.Header.Get(cmn.HeaderObjVersion),
+		Size:     size,
+		Version:  r.Header.Get(cmn.HeaderObjVersion),
+		UserMeta: userMeta,
 	}, nil
 }
 
@@ -223,6 +230,7 @@ func PutObject(args PutObjectArgs, replicateOpts ...ReplicateObjectInput) error
 		req.Header.Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
 		req.Header.Set(cmn.HeaderObjCksumVal, args.Hash)
 	}
+	cmn.UserMetaToHeader(req.Header, args.UserMeta)
 	if len(replicateOpts) > 0 {
 		req.Header.Set(cmn.HeaderObjReplicSrc, replicateOpts[0].SourceURL)
 	}
@@ -254,6 +262,142 @@ func PutObject(args PutObjectArgs, replicateOpts ...ReplicateObjectInput) error
 	return nil
 }
 
+// AppendObject API
+//
+// Appends the content of the reader to the object (local buckets only), creating the object if it does not exist
+func AppendObject(args PutObjectArgs) error {
+	handle, err := args.Reader.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open reader, err: %v", err)
+	}
+	defer handle.Close()
+
+	path := cmn.URLPath(cmn.Version, cmn.Objects, args.Bucket, args.Object)
+	var query = url.Values{}
+	query.Add(cmn.URLParamBucketProvider, args.BucketProvider)
+	query.Add(cmn.URLParamAction, cmn.ActAppend)
+	reqURL := args.BaseParams.URL + path + "?" + query.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, reqURL, handle)
+	if err != nil {
+		return fmt.Errorf("failed to create new HTTP request, err: %v", err)
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return args.Reader.Open()
+	}
+	if args.Hash != "" {
+		req.Header.Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+		req.Header.Set(cmn.HeaderObjCksumVal, args.Hash)
+	}
+	resp, err := args.BaseParams.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s, err: %v", http.MethodPost, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response, err: %v", err)
+		}
+		return fmt.Errorf("HTTP error = %d, message = %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+// InitMultipartUpload API
+//
+// Initiates multipart upload of the object specified by bucket/object and returns the upload ID
+func InitMultipartUpload(baseParams *BaseParams, bucket, bucketProvider, object string) (string, error) {
+	msg, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActMPartInit})
+	if err != nil {
+		return "", err
+	}
+	baseParams.Method = http.MethodPost
+	path := cmn.URLPath(cmn.Version, cmn.Objects, bucket, object) + "?" + cmn.URLParamBucketProvider + "=" + bucketProvider
+	b, err := DoHTTPRequest(baseParams, path, msg)
+	if err != nil {
+		return "", err
+	}
+	mpartMsg := &cmn.MPartMsg{}
+	if err = jsoniter.Unmarshal(b, mpartMsg); err != nil {
+		return "", fmt.Errorf("failed to unmarshal upload ID, err: %v - [%s]", err, string(b))
+	}
+	return mpartMsg.UploadID, nil
+}
+
+// PutObjectPart API
+//
+// Uploads (or re-uploads) part number partNum (starting from 1) of a multipart upload
+func PutObjectPart(args PutObjectArgs, uploadID string, partNum int) error {
+	handle, err := args.Reader.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open reader, err: %v", err)
+	}
+	defer handle.Close()
+
+	path := cmn.URLPath(cmn.Version, cmn.Objects, args.Bucket, args.Object)
+	var query = url.Values{}
+	query.Add(cmn.URLParamBucketProvider, args.BucketProvider)
+	query.Add(cmn.URLParamUploadID, uploadID)
+	query.Add(cmn.URLParamPartNum, strconv.Itoa(partNum))
+	reqURL := args.BaseParams.URL + path + "?" + query.Encode()
+
+	req, err := http.NewRequest(http.MethodPut, reqURL, handle)
+	if err != nil {
+		return fmt.Errorf("failed to create new HTTP request, err: %v", err)
+	}
+	req.GetBody = func() (io.ReadCloser, error) {
+		return args.Reader.Open()
+	}
+	if args.Hash != "" {
+		req.Header.Set(cmn.HeaderObjCksumType, cmn.ChecksumXXHash)
+		req.Header.Set(cmn.HeaderObjCksumVal, args.Hash)
+	}
+	resp, err := args.BaseParams.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to %s, err: %v", http.MethodPut, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response, err: %v", err)
+		}
+		return fmt.Errorf("HTTP error = %d, message = %s", resp.StatusCode, string(b))
+	}
+	return nil
+}
+
+// CompleteMultipartUpload API
+//
+// Assembles the object from the uploaded parts. If specified, parts must match the uploaded ones.
+func CompleteMultipartUpload(baseParams *BaseParams, bucket, bucketProvider, object, uploadID string, parts ...cmn.MPartInfo) error {
+	msg, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActMPartComplete, Value: cmn.MPartMsg{UploadID: uploadID, Parts: parts}})
+	if err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPost
+	path := cmn.URLPath(cmn.Version, cmn.Objects, bucket, object) + "?" + cmn.URLParamBucketProvider + "=" + bucketProvider
+	_, err = DoHTTPRequest(baseParams, path, msg)
+	return err
+}
+
+// AbortMultipartUpload API
+//
+// Discards the multipart upload along with all its uploaded parts
+func AbortMultipartUpload(baseParams *BaseParams, bucket, bucketProvider, object, uploadID string) error {
+	msg, err := jsoniter.Marshal(cmn.ActionMsg{Action: cmn.ActMPartAbort, Value: cmn.MPartMsg{UploadID: uploadID}})
+	if err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPost
+	path := cmn.URLPath(cmn.Version, cmn.Objects, bucket, object) + "?" + cmn.URLParamBucketProvider + "=" + bucketProvider
+	_, err = DoHTTPRequest(baseParams, path, msg)
+	return err
+}
+
 // RenameObject API
 //
 // Creates a cmn.ActionMsg with the new name of the object
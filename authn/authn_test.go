@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	jwt "github.com/dgrijalva/jwt-go"
 )
 
 const (
@@ -40,7 +41,7 @@ func createUsers(mgr *userManager, t *testing.T) {
 	}
 
 	for idx := range users {
-		err = mgr.addUser(users[idx], passs[idx])
+		err = mgr.addUser(users[idx], passs[idx], "", nil)
 		if err != nil {
 			t.Errorf("Failed to create a user %s: %v", users[idx], err)
 		}
@@ -75,7 +76,7 @@ func deleteUsers(mgr *userManager, skipNotExist bool, t *testing.T) {
 }
 
 func testInvalidUser(mgr *userManager, t *testing.T) {
-	err := mgr.addUser(users[0], passs[1])
+	err := mgr.addUser(users[0], passs[1], "", nil)
 	if err == nil || !strings.Contains(err.Error(), "already registered") {
 		t.Errorf("User with the existing name %s was created: %v", users[0], err)
 	}
@@ -108,7 +109,7 @@ func testUserDelete(mgr *userManager, t *testing.T) {
 		username = "newuser"
 		userpass = "newpass"
 	)
-	err := mgr.addUser(username, userpass)
+	err := mgr.addUser(username, userpass, "", nil)
 	if err != nil {
 		t.Errorf("Failed to create a user %s: %v", username, err)
 	}
@@ -271,6 +272,32 @@ func addRemoveCreds(mgr *userManager, t *testing.T) {
 	}
 }
 
+func testPermissions(mgr *userManager, t *testing.T) {
+	if err := mgr.addUser("user4", "pass4", "superuser", nil); err == nil {
+		t.Error("User with invalid role created")
+	}
+	if err := mgr.addUser("user4", "pass4", "", map[string]string{"bck": cmn.AuthAdmin}); err == nil {
+		t.Error("User with invalid bucket permission created")
+	}
+	if role := mgr.Users[users[0]].Role; role != cmn.AuthReadOnly {
+		t.Errorf("Expected default role %q, got %q", cmn.AuthReadOnly, role)
+	}
+
+	buckets := map[string]string{"bck1": cmn.AuthReadWrite, "bck2": cmn.AuthNoAccess}
+	if err := mgr.updatePermissions(users[0], cmn.AuthReadOnly, buckets); err != nil {
+		t.Errorf("Failed to update permissions of %s: %v", users[0], err)
+	}
+	if info := mgr.Users[users[0]]; info.Buckets["bck1"] != cmn.AuthReadWrite || info.Buckets["bck2"] != cmn.AuthNoAccess {
+		t.Errorf("Invalid permissions of %s: %v", users[0], info.Buckets)
+	}
+	if err := mgr.updatePermissions(users[0], cmn.AuthReadWrite, map[string]string{"bck1": "all"}); err == nil {
+		t.Error("Invalid permissions accepted")
+	}
+	if err := mgr.updatePermissions("nonexisting", cmn.AuthAdmin, nil); err == nil {
+		t.Error("Permissions of nonexisting user updated")
+	}
+}
+
 func TestManager(t *testing.T) {
 	proxy := &proxy{}
 	mgr := newUserManager(dbPath, proxy)
@@ -280,6 +307,7 @@ func TestManager(t *testing.T) {
 	createUsers(mgr, t)
 	testInvalidUser(mgr, t)
 	addRemoveCreds(mgr, t)
+	testPermissions(mgr, t)
 	testUserDelete(mgr, t)
 	reloadFromFile(mgr, t)
 	deleteUsers(mgr, false, t)
@@ -355,5 +383,19 @@ func TestToken(t *testing.T) {
 		}
 	}
 
+	// users added before the roles were introduced keep the admin role
+	mgr.Users[users[2]].Role = ""
+	token, err = mgr.issueToken(users[2], passs[2])
+	if err != nil {
+		t.Fatalf("Failed to generate token for %s: %v", users[2], err)
+	}
+	parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return []byte(conf.Auth.Secret), nil })
+	if err != nil {
+		t.Fatalf("Failed to parse token: %v", err)
+	}
+	if role := parsed.Claims.(jwt.MapClaims)["role"]; role != cmn.AuthAdmin {
+		t.Errorf("Expected role %q for the user with no role, got %q", cmn.AuthAdmin, role)
+	}
+
 	deleteUsers(mgr, false, t)
 }
@@ -33,6 +33,14 @@ type loginMsg struct {
 	Password string `json:"password"`
 }
 
+// a message to update user's role and per-bucket permissions
+// PUT: <version>/<pathUsers>/<username>
+//		Body: <permissionsMsg>
+type permissionsMsg struct {
+	Role    string            `json:"role"`
+	Buckets map[string]string `json:"buckets,omitempty"`
+}
+
 // a message to test token validity and to revoke existing token
 // check: GET <version>/<pathTokens>
 //		Body: <tokenMsg>
@@ -200,11 +208,11 @@ func (a *authServ) httpUserPost(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Updates user credentials
+// Updates user credentials or, if the cloud provider is omitted, user permissions
 // If user did not have credentials before updating or the credentials changes
 //   then new user list is saved and sent to the proxy to update the cluster
 func (a *authServ) httpUserPut(w http.ResponseWriter, r *http.Request) {
-	apiItems, err := checkRESTItems(w, r, 2, cmn.Version, pathUsers)
+	apiItems, err := checkRESTItems(w, r, 1, cmn.Version, pathUsers)
 	if err != nil {
 		return
 	}
@@ -212,6 +220,10 @@ func (a *authServ) httpUserPut(w http.ResponseWriter, r *http.Request) {
 		glog.Errorf("Not authorized: %v\n", err)
 		return
 	}
+	if len(apiItems) == 1 {
+		a.userUpdatePermissions(w, r, apiItems[0])
+		return
+	}
 
 	userID := apiItems[0]
 	provider := apiItems[1]
@@ -247,7 +259,7 @@ func (a *authServ) userAdd(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := a.users.addUser(info.UserID, info.Password); err != nil {
+	if err := a.users.addUser(info.UserID, info.Password, info.Role, info.Buckets); err != nil {
 		cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("Failed to add user: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -259,6 +271,24 @@ func (a *authServ) userAdd(w http.ResponseWriter, r *http.Request) {
 	a.writeJSON(w, r, msg, "create user")
 }
 
+// Updates user role and per-bucket permissions
+func (a *authServ) userUpdatePermissions(w http.ResponseWriter, r *http.Request, userID string) {
+	msg := &permissionsMsg{}
+	if err := cmn.ReadJSON(w, r, msg); err != nil {
+		glog.Errorf("Failed to read permissions: %v\n", err)
+		return
+	}
+	if err := a.users.updatePermissions(userID, msg.Role, msg.Buckets); err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("Failed to update permissions: %v", err))
+		return
+	}
+	if glog.V(4) {
+		glog.Infof("Updated permissions of %s: role %s, buckets %v\n", userID, msg.Role, msg.Buckets)
+	}
+
+	a.writeJSON(w, r, []byte("Permissions updated successfully"), "update permissions")
+}
+
 // Checks if the request header contains super-user credentials and they are
 // valid. Super-user is a user created at deployment time that cannot be
 // deleted/created via REST API
@@ -32,6 +32,8 @@ type (
 		UserID          string            `json:"name"`
 		Password        string            `json:"password,omitempty"`
 		Creds           map[string]string `json:"creds,omitempty"`
+		Role            string            `json:"role,omitempty"`    // admin, rw or ro (default)
+		Buckets         map[string]string `json:"buckets,omitempty"` // bucket => permission (rw, ro or none)
 		passwordDecoded string
 	}
 	tokenInfo struct {
@@ -104,11 +106,33 @@ func (m *userManager) saveUsers() (err error) {
 	return err
 }
 
+// Checks the user's role and per-bucket permissions
+func validatePermissions(role string, buckets map[string]string) error {
+	if role != "" && role != cmn.AuthAdmin && role != cmn.AuthReadWrite && role != cmn.AuthReadOnly {
+		return fmt.Errorf("invalid role: %s", role)
+	}
+	for bucket, perm := range buckets {
+		if bucket == "" {
+			return fmt.Errorf("invalid permissions: empty bucket name")
+		}
+		if perm != cmn.AuthReadWrite && perm != cmn.AuthReadOnly && perm != cmn.AuthNoAccess {
+			return fmt.Errorf("invalid permission %q for bucket %s", perm, bucket)
+		}
+	}
+	return nil
+}
+
 // Registers a new user
-func (m *userManager) addUser(userID, userPass string) error {
+func (m *userManager) addUser(userID, userPass, role string, buckets map[string]string) error {
 	if userID == "" || userPass == "" {
 		return fmt.Errorf("invalid credentials")
 	}
+	if err := validatePermissions(role, buckets); err != nil {
+		return err
+	}
+	if role == "" {
+		role = cmn.AuthReadOnly
+	}
 
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
@@ -121,11 +145,41 @@ func (m *userManager) addUser(userID, userPass string) error {
 		passwordDecoded: userPass,
 		Password:        base64.StdEncoding.EncodeToString([]byte(userPass)),
 		Creds:           make(map[string]string, 10),
+		Role:            role,
+		Buckets:         buckets,
 	}
 
 	return m.saveUsers()
 }
 
+// Updates the user's role and per-bucket permissions. The user's token, if
+// any, is revoked as it carries the old permissions
+func (m *userManager) updatePermissions(userID, role string, buckets map[string]string) error {
+	if err := validatePermissions(role, buckets); err != nil {
+		return err
+	}
+	if role == "" {
+		role = cmn.AuthReadOnly
+	}
+
+	m.mtx.Lock()
+	user, ok := m.Users[userID]
+	if !ok {
+		m.mtx.Unlock()
+		return fmt.Errorf("user %s %s", userID, cmn.DoesNotExist)
+	}
+	user.Role, user.Buckets = role, buckets
+	token, ok := m.tokens[userID]
+	delete(m.tokens, userID)
+	err := m.saveUsers()
+	m.mtx.Unlock()
+
+	if ok {
+		go m.sendRevokedTokensToProxy(token.Token)
+	}
+	return err
+}
+
 // Deletes an existing user
 func (m *userManager) delUser(userID string) error {
 	m.mtx.Lock()
@@ -166,6 +220,10 @@ func (m *userManager) issueToken(userID, pwd string) (string, error) {
 	}
 	passwordDecoded := user.passwordDecoded
 	creds := user.Creds
+	role, buckets := user.Role, user.Buckets
+	if role == "" {
+		role = cmn.AuthAdmin // added before the roles were introduced
+	}
 
 	if passwordDecoded != pwd {
 		return "", fmt.Errorf("invalid username or password")
@@ -185,12 +243,15 @@ func (m *userManager) issueToken(userID, pwd string) (string, error) {
 	expires := issued.Add(conf.Auth.ExpirePeriod)
 
 	// put all useful info into token: who owns the token, when it was issued,
-	// when it expires and credentials to log in AWS, GCP etc
+	// when it expires, credentials to log in AWS, GCP etc, and the user's
+	// role and per-bucket permissions (enforced by the proxy)
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"issued":   issued.Format(time.RFC822),
 		"expires":  expires.Format(time.RFC822),
 		"username": userID,
 		"creds":    creds,
+		"role":     role,
+		"buckets":  buckets,
 	})
 	tokenString, err := t.SignedString([]byte(conf.Auth.Secret))
 	if err != nil {
@@ -34,6 +34,7 @@ const (
 	LomCksumMissingRecomp
 	LomCksumPresentRecomp
 	LomCopy
+	LomUserMeta
 )
 
 type (
@@ -66,6 +67,7 @@ type (
 		Atimestr string
 		Size     int64
 		Cksum    cmn.CksumProvider
+		UserMeta cmn.SimpleKVs // user-defined metadata
 		// flags
 		BckIsLocal bool // the bucket (that contains this object) is local
 		BadCksum   bool // this object has a bad checksum
@@ -87,6 +89,7 @@ func (lom *LOM) RestoredReceived(props *LOM) {
 		lom.Size = props.Size
 	}
 	lom.Cksum = props.Cksum
+	lom.UserMeta = props.UserMeta
 	lom.BadCksum = false
 	lom.SetExists(true)
 }
@@ -151,6 +154,22 @@ func (lom *LOM) DelCopy() (errstr string) {
 }
 
 func (lom *LOM) CopyObject(dstFQN string, buf []byte) (err error) {
+	// the copy must carry all the xattrs, whether or not the caller has loaded them
+	action := 0
+	if lom.Version == "" {
+		action |= LomVersion
+	}
+	if lom.Cksum == nil {
+		action |= LomCksum
+	}
+	if lom.UserMeta == nil {
+		action |= LomUserMeta
+	}
+	if action != 0 {
+		if errstr := lom.Fill("", action); errstr != "" {
+			return errors.New(errstr)
+		}
+	}
 	dstLOM := lom.Copy(LOMCopyProps{FQN: dstFQN})
 	if err = cmn.CopyFile(lom.FQN, dstLOM.FQN, buf); err != nil {
 		return
@@ -269,6 +288,13 @@ func (lom *LOM) Fill(bucketProvider string, action int, config ...*cmn.Config) (
 		}
 		lom.CopyFQN = string(copyfqn)
 	}
+	if action&LomUserMeta != 0 {
+		var meta []byte
+		if meta, errstr = fs.GetXattr(lom.FQN, cmn.XattrUserMeta); errstr != "" {
+			return
+		}
+		lom.UserMeta = cmn.DecodeUserMeta(string(meta))
+	}
 	return
 }
 
@@ -290,7 +316,12 @@ func (lom *LOM) Persist() (errstr string) {
 		}
 	}
 	if lom.Version != "" {
-		errstr = fs.SetXattr(lom.FQN, cmn.XattrVersion, []byte(lom.Version))
+		if errstr = fs.SetXattr(lom.FQN, cmn.XattrVersion, []byte(lom.Version)); errstr != "" {
+			return
+		}
+	}
+	if len(lom.UserMeta) != 0 {
+		errstr = fs.SetXattr(lom.FQN, cmn.XattrUserMeta, []byte(cmn.EncodeUserMeta(lom.UserMeta)))
 	}
 	// NOTE: atime is updated explicitly, via UpdateAtime() below
 	//       cmn.XattrCopies is also updated separately by the 2-way mirroring code
@@ -431,6 +431,22 @@ var _ = Describe("LOM", func() {
 				//Check copy contents are corrrect
 				Expect(getTestFileHash(copyFQN)).To(BeEquivalentTo(expectedHash))
 			})
+
+			It("Should copy the xattrs that were not loaded", func() {
+				userMeta := cmn.EncodeUserMeta(cmn.SimpleKVs{"k1": "v1", "k2": "v2"})
+				createTestFile(localFQN, testFileSize)
+				Expect(fs.SetXattr(localFQN, cmn.XattrVersion, []byte(desiredVersion))).To(BeEmpty())
+				Expect(fs.SetXattr(localFQN, cmn.XattrUserMeta, []byte(userMeta))).To(BeEmpty())
+				lom := &cluster.LOM{T: tMock, FQN: localFQN}
+				Expect(lom.Fill("", cluster.LomFstat|cluster.LomCopy)).To(BeEmpty())
+
+				Expect(lom.CopyObject(copyFQN, make([]byte, testFileSize))).ShouldNot(HaveOccurred())
+				var xattr []byte
+				xattr, _ = fs.GetXattr(copyFQN, cmn.XattrVersion)
+				Expect(string(xattr)).To(BeEquivalentTo(desiredVersion))
+				xattr, _ = fs.GetXattr(copyFQN, cmn.XattrUserMeta)
+				Expect(string(xattr)).To(Equal(userMeta))
+			})
 		})
 
 		Describe("SetXcopy", func() {
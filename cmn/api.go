@@ -13,9 +13,10 @@ import (
 // string enum: http header, checksum, versioning
 const (
 	// http header
-	XattrXXHash  = "user.obj.xxhash"
-	XattrVersion = "user.obj.version"
-	XattrCopies  = "user.obj.copies"
+	XattrXXHash   = "user.obj.xxhash"
+	XattrVersion  = "user.obj.version"
+	XattrCopies   = "user.obj.copies"
+	XattrUserMeta = "user.obj.meta"
 	// checksum hash function
 	ChecksumNone   = "none"
 	ChecksumXXHash = "xxhash"
@@ -69,6 +70,23 @@ const (
 	ActEraseCopies  = "erasecopies"
 	ActEC           = "ec" // erasure (en)code objects
 
+	// Start and stop (abort) xactions cluster-wide (PUT /v1/cluster), see XactionMsg
+	ActXactStart = "xactstart"
+	ActXactStop  = "xactstop"
+
+	// Actions for multipart upload (POST /v1/objects/bucket-name/object-name)
+	ActMPartInit     = "mpartinit"
+	ActMPartComplete = "mpartcomplete"
+	ActMPartAbort    = "mpartabort"
+
+	// Append the request body to the object: POST /v1/objects/bucket-name/object-name?action=append
+	ActAppend = "append"
+
+	// Copy all (or selected) objects of a bucket to a new or existing local bucket
+	// (POST /v1/buckets/bucket-name), and abort the copying
+	ActCopyBucket      = "copybucket"
+	ActCopyBucketAbort = "copybucketabort"
+
 	// Actions for manipulating mountpaths (/v1/daemon/mountpaths)
 	ActMountpathEnable  = "enable"
 	ActMountpathDisable = "disable"
@@ -76,11 +94,23 @@ const (
 	ActMountpathRemove  = "remove"
 )
 
+// AuthN user roles and per-bucket permissions: a role grants access to all
+// buckets and may be overridden for individual buckets by a permission
+const (
+	AuthAdmin     = "admin" // role: full access, including cluster and bucket administration
+	AuthReadWrite = "rw"    // role or permission: read, write, and delete objects
+	AuthReadOnly  = "ro"    // role or permission: read objects and list buckets (default)
+	AuthNoAccess  = "none"  // permission: no access to the bucket
+)
+
 // Cloud Provider enum
 const (
 	ProviderAmazon = "aws"
 	ProviderGoogle = "gcp"
 	ProviderAIS    = "ais"
+	ProviderPosix  = "posix"  // local or network filesystem
+	ProviderHTTP   = "http"   // read-only HTTP(S) origin
+	ProviderRemAIS = "remais" // another AIS cluster
 )
 
 // Header Key enum
@@ -100,6 +130,9 @@ const (
 	HeaderReadPolicy  = "read_policy"   // Policy used for reading in a AIStore multi-tier environment
 	HeaderWritePolicy = "write_policy"  // Policy used for writing in a AIStore multi-tier environment
 
+	// http origin
+	HeaderOriginURL = "origin_url" // base URL of the HTTP(S) origin the cloud bucket is bound to
+
 	// bucket props
 	HeaderBucketChecksumType    = "cksum_config-checksum"                   // Checksum type used for objects in the bucket
 	HeaderBucketValidateColdGet = "cksum_config-validate_checksum_cold_get" // Cold get validation policy used for objects in the bucket
@@ -126,6 +159,13 @@ const (
 	HeaderObjReplicSrc = "ObjReplicSrc" // In replication PUT request specifies the source target
 	HeaderObjSize      = "ObjSize"      // Object size (bytes)
 	HeaderObjVersion   = "ObjVersion"   // Object version/generation - local or Cloud
+
+	// user-defined object metadata: one "Ais-Meta-<key>: <value>" header per key
+	HeaderObjMetaPrefix = "Ais-Meta-"
+
+	// distributed tracing: propagated via intra-cluster requests (and may be set by clients)
+	HeaderTraceID = "TraceID" // ID of the trace the request belongs to
+	HeaderSpanID  = "SpanID"  // ID of the caller's (parent) span
 )
 
 // URL Query "?name1=val1&name2=..."
@@ -137,6 +177,10 @@ const (
 	URLParamOffset         = "offset"       // Offset from where the object should be read
 	URLParamLength         = "length"       // the total number of bytes that need to be read from the offset
 	URLParamBucketProvider = "bprovider"    // "local" | "cloud"
+	URLParamUploadID       = "upload_id"    // multipart upload ID (as returned by ActMPartInit)
+	URLParamPartNum        = "part_num"     // multipart upload: part number (1 - MPartMaxParts)
+	URLParamAction         = "action"       // object action that carries data in the request body (e.g., ActAppend)
+	URLParamBucket         = "bucket"       // bucket name, e.g. to select the xactions of a given bucket
 	// internal use
 	URLParamLocal            = "loc" // true: bucket is local
 	URLParamFromID           = "fid" // source target ID
@@ -151,6 +195,8 @@ const (
 	URLParamBMDVersion       = "vbm" // version of the bucket-metadata
 	URLParamUnixTime         = "utm" // Unix time: number of nanoseconds elapsed since 01/01/70 UTC
 	URLParamReadahead        = "rah" // Proxy to target: readeahed
+	URLParamTraceID          = "trc" // ID of the trace the request belongs to
+	URLParamSpanID           = "spn" // ID of the caller's (parent) span
 
 	// dsort
 	URLParamTotalCompressedSize   = "tcs"
@@ -161,12 +207,31 @@ const (
 // TODO: sort and some props are TBD
 // GetMsg represents properties and options for requests which fetch entities
 type GetMsg struct {
-	GetSort       string `json:"sort"`        // "ascending, atime" | "descending, name"
-	GetProps      string `json:"props"`       // e.g. "checksum, size" | "atime, size" | "ctime, iscached" | "bucket, size"
-	GetTimeFormat string `json:"time_format"` // "RFC822" default - see the enum above
-	GetPrefix     string `json:"prefix"`      // object name filter: return only objects which name starts with prefix
-	GetPageMarker string `json:"pagemarker"`  // AWS/GCP: marker
-	GetPageSize   int    `json:"pagesize"`    // maximum number of entries returned by list bucket call
+	GetSort       string      `json:"sort"`             // "ascending, atime" | "descending, name"
+	GetProps      string      `json:"props"`            // e.g. "checksum, size" | "atime, size" | "ctime, iscached" | "bucket, size"
+	GetTimeFormat string      `json:"time_format"`      // "RFC822" default - see the enum above
+	GetPrefix     string      `json:"prefix"`           // object name filter: return only objects which name starts with prefix
+	GetPageMarker string      `json:"pagemarker"`       // AWS/GCP: marker
+	GetPageSize   int         `json:"pagesize"`         // maximum number of entries returned by list bucket call
+	GetStream     bool        `json:"stream,omitempty"` // stream the entire listing, page by page, as newline-delimited JSON
+	GetFilter     *ListFilter `json:"filter,omitempty"` // server-side filters (see ListFilter)
+}
+
+// ListFilter contains server-side filters that targets apply when listing
+// objects: an object is listed only if it satisfies all the specified conditions.
+// Filtering is done on the objects stored in the cluster - for Cloud buckets,
+// a filtered listing includes only the cached objects.
+type ListFilter struct {
+	Regex     string `json:"regex,omitempty"`      // object name must match the (RE2) regular expression
+	MinSize   int64  `json:"min_size,omitempty"`   // minimum object size, in bytes
+	MaxSize   int64  `json:"max_size,omitempty"`   // maximum object size, in bytes (0 - no limit)
+	AtimeFrom string `json:"atime_from,omitempty"` // access time range, RFC3339 (either end can be omitted)
+	AtimeTo   string `json:"atime_to,omitempty"`   //
+	CtimeFrom string `json:"ctime_from,omitempty"` // creation (last modification) time range, RFC3339
+	CtimeTo   string `json:"ctime_to,omitempty"`   //
+	Cached    bool   `json:"cached,omitempty"`     // cached objects only (Cloud buckets; implied by any other filter)
+	Misplaced bool   `json:"misplaced,omitempty"`  // misplaced objects only (objects that are yet to be rebalanced)
+	Copies    bool   `json:"copies,omitempty"`     // only objects that have local (mirrored) copies
 }
 
 // ListRangeMsgBase contains fields common to Range and List operations
@@ -206,14 +271,17 @@ type MountpathList struct {
 
 // URLParamWhat enum
 const (
-	GetWhatConfig     = "config"
-	GetWhatSmap       = "smap"
-	GetWhatBucketMeta = "bucketmd"
-	GetWhatStats      = "stats"
-	GetWhatXaction    = "xaction"
-	GetWhatSmapVote   = "smapvote"
-	GetWhatMountpaths = "mountpaths"
-	GetWhatDaemonInfo = "daemoninfo"
+	GetWhatConfig         = "config"
+	GetWhatSmap           = "smap"
+	GetWhatBucketMeta     = "bucketmd"
+	GetWhatStats          = "stats"
+	GetWhatXaction        = "xaction"
+	GetWhatSmapVote       = "smapvote"
+	GetWhatMountpaths     = "mountpaths"
+	GetWhatDaemonInfo     = "daemoninfo"
+	GetWhatBucketStats    = "bucketstats"
+	GetWhatXactionList    = "xactionlist"    // all xactions, optionally of a given kind (URLParamProps) and bucket
+	GetWhatXactionHistory = "xactionhistory" // journal of finished xactions persisted by the daemon
 )
 
 // GetMsg.GetSort enum
@@ -246,6 +314,7 @@ const (
 	GetTargetURL     = "targetURL"
 	GetPropsStatus   = "status"
 	GetPropsCopies   = "copies"
+	GetPropsUserMeta = "usermeta"
 )
 
 // BucketEntry.Status
@@ -276,6 +345,7 @@ type BucketEntry struct {
 	Status    string `json:"status,omitempty"`    // empty - normal object, it can be "moved", "deleted" etc
 	Copies    int64  `json:"copies"`              // ## copies (non-replicated = 1)
 	IsCached  bool   `json:"iscached"`            // if the file is cached on one of targets
+	UserMeta  string `json:"usermeta,omitempty"`  // user-defined metadata (see EncodeUserMeta)
 }
 
 // BucketList represents the contents of a given bucket - somewhat analogous to the 'ls <bucket-name>'
@@ -284,6 +354,30 @@ type BucketList struct {
 	PageMarker string         `json:"pagemarker"`
 }
 
+// MPartMsg is used in multipart upload requests: ActMPartInit returns
+// the upload ID; ActMPartComplete and ActMPartAbort take it as the value
+type MPartMsg struct {
+	UploadID string      `json:"upload_id"`
+	Parts    []MPartInfo `json:"parts,omitempty"` // ActMPartComplete: if specified, must match the uploaded parts
+}
+
+// CopyBucketMsg is the value of the ActCopyBucket request; the destination
+// local bucket is specified by ActionMsg.Name and gets created if need be
+type CopyBucketMsg struct {
+	Prefix string `json:"prefix,omitempty"` // copy only the objects with names that start with the prefix
+	Regex  string `json:"regex,omitempty"`  // copy only the objects with names that match the (RE2) regular expression
+}
+
+// MPartInfo describes a single uploaded part
+type MPartInfo struct {
+	PartNum int    `json:"part_num"`
+	Size    int64  `json:"size,omitempty"`
+	Cksum   string `json:"cksum,omitempty"` // xxhash
+}
+
+// MPartMaxParts is the maximum number of parts of a multipart upload
+const MPartMaxParts = 10000
+
 // BucketNames is used to transfer all bucket names known to the system
 type BucketNames struct {
 	Cloud []string `json:"cloud"`
@@ -311,6 +405,7 @@ const (
 	Health    = "health"
 	Vote      = "vote"
 	Transport = "transport"
+	S3        = "s3" // S3 compatibility layer
 	// l3
 	SyncSmap   = "syncsmap"
 	Keepalive  = "keepalive"
@@ -327,8 +422,11 @@ const (
 	Start       = "start"
 	Abort       = "abort"
 	Metrics     = "metrics"
+	List        = "list"
+	Remove      = "remove"
 	Records     = "records"
 	Shards      = "shards"
+	Release     = "release"
 	FinishedAck = "finished-ack"
 
 	//====== download endpoint (l3) =======
@@ -400,11 +498,29 @@ const (
 	XactionRebalance = ActGlobalReb
 	XactionPrefetch  = ActPrefetch
 	XactionDownload  = ActDownload
+	XactionDsort     = "dsort" // dSort jobs, identified by UUIDs (see stats.XactionDetails)
 
 	// Denote the status of an Xaction
 	XactionStatusInProgress = "InProgress"
 	XactionStatusCompleted  = "Completed"
+	XactionStatusAborted    = "Aborted"
 )
+
+// XactionMsg is the ActionMsg.Value of ActXactStart and ActXactStop: the former
+// starts an xaction of a given kind (and bucket, if the kind requires one);
+// the latter aborts the xaction with a given ID or, if ID is zero, all running
+// xactions of a given kind and/or bucket. Xaction IDs are unique only within
+// a target, and so stopping by ID requires the target ID as well; when set,
+// the target ID limits both actions to the given target. dSort jobs (kind
+// XactionDsort) are stopped by UUID or, if the UUID is empty, all at once
+type XactionMsg struct {
+	ID       int64  `json:"id,omitempty"`
+	UUID     string `json:"uuid,omitempty"`
+	Kind     string `json:"kind,omitempty"`
+	Bucket   string `json:"bucket,omitempty"`
+	TargetID string `json:"target_id,omitempty"`
+}
+
 const (
 	RWPolicyCloud    = "cloud"
 	RWPolicyNextTier = "next_tier"
@@ -437,6 +553,10 @@ type BucketProps struct {
 	// specified by NextTierURL. Default: "cloud"
 	WritePolicy string `json:"write_policy,omitempty"`
 
+	// OriginURL is the base URL of the HTTP(S) server a cloud bucket is bound to
+	// when the cluster's cloud provider is "http": bucket/object maps to OriginURL/object
+	OriginURL string `json:"origin_url,omitempty"`
+
 	// CksumConf is the embedded struct of the same name
 	CksumConf `json:"cksum_config"`
 
@@ -460,6 +580,7 @@ type ECConf struct {
 
 // ObjectProps
 type ObjectProps struct {
-	Size    int
-	Version string
+	Size     int
+	Version  string
+	UserMeta SimpleKVs
 }
@@ -5,9 +5,12 @@
 package cmn
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -28,6 +31,7 @@ const (
 	SmapBackupFile       = "smap.json"
 	BucketmdBackupFile   = "bucket-metadata" // base name of the config file; not to confuse with config.Localbuckets mpath
 	MountpathBackupFile  = "mpaths"          // base name to persist fs.Mountpaths
+	XactHistoryFile      = "xaction-history.json"
 	RebalanceMarker      = ".rebalancing"
 	LocalRebalanceMarker = ".localrebalancing"
 )
@@ -66,6 +70,7 @@ type (
 		BeginUpdate() *Config
 		CommitUpdate(config *Config)
 		Subscribe(cl ConfigListener)
+		Unsubscribe(cl ConfigListener)
 	}
 
 	// ConfigListener is interface for listeners which require to be notified
@@ -153,12 +158,27 @@ func (gco *globalConfigOwner) Subscribe(cl ConfigListener) {
 	gco.lmtx.Unlock()
 }
 
+// Unsubscribe removes the listener - should be called by the short-lived
+// listeners (e.g., stream bundles) when they are done.
+func (gco *globalConfigOwner) Unsubscribe(cl ConfigListener) {
+	gco.lmtx.Lock()
+	for i, listener := range gco.listeners {
+		if listener == cl {
+			gco.listeners = append(gco.listeners[:i], gco.listeners[i+1:]...)
+			break
+		}
+	}
+	gco.lmtx.Unlock()
+}
+
 //
 // CONFIGURATION
 //
 type Config struct {
 	Confdir          string          `json:"confdir"`
 	CloudProvider    string          `json:"cloudprovider"`
+	Posix            PosixConf       `json:"posix"`
+	RemAIS           RemAISConf      `json:"remote_ais"`
 	Mirror           MirrorConf      `json:"mirror"`
 	Readahead        RahConf         `json:"readahead"`
 	Log              LogConf         `json:"log"`
@@ -177,6 +197,21 @@ type Config struct {
 	FSHC             FSHCConf        `json:"fshc"`
 	Auth             AuthConf        `json:"auth"`
 	KeepaliveTracker KeepaliveConf   `json:"keepalivetracker"`
+	Trace            TraceConf       `json:"trace"`
+	Transport        TransportConf   `json:"transport"`
+}
+
+// PosixConf configures the "posix" cloud provider: a directory tree (e.g., an NFS mount)
+// where each top-level subdirectory is a bucket and each file underneath is an object
+type PosixConf struct {
+	Root string `json:"root"` // absolute path of the directory tree
+}
+
+// RemAISConf configures the "remais" cloud provider: another AIS cluster
+// whose buckets are cached by this one
+type RemAISConf struct {
+	URL        string `json:"url"`         // public URL of the remote cluster's primary proxy (any proxy will do)
+	SkipVerify bool   `json:"skip_verify"` // do not verify the remote cluster's certificate (https:// URL only)
 }
 
 type MirrorConf struct {
@@ -334,6 +369,7 @@ type NetConf struct {
 	UseIntraData     bool     `json:"-"`
 	L4               L4Conf   `json:"l4"`
 	HTTP             HTTPConf `json:"http"`
+	MTLS             MTLSConf `json:"mtls"`
 }
 
 type L4Conf struct {
@@ -356,6 +392,14 @@ type HTTPConf struct {
 	UseHTTPS      bool   `json:"use_https"`          // use HTTPS instead of HTTP
 }
 
+// MTLSConf configures mutual TLS for the intra-cluster (control and data) networks
+type MTLSConf struct {
+	Enabled     bool   `json:"enabled"`
+	CACert      string `json:"ca_certificate"` // cluster CA that issues the node certificates (PEM)
+	Certificate string `json:"certificate"`    // node certificate (PEM) - common name must be the daemon ID
+	Key         string `json:"key"`            // private key of the node certificate (PEM)
+}
+
 type FSHCConf struct {
 	Enabled       bool `json:"fshc_enabled"`
 	TestFileCount int  `json:"fshc_test_files"`  // the number of files to read and write during a test
@@ -368,6 +412,23 @@ type AuthConf struct {
 	CredDir string `json:"creddir"`
 }
 
+// TraceConf configures distributed request tracing
+type TraceConf struct {
+	Enabled   bool   `json:"enabled"`
+	File      string `json:"file"`      // local file to append the spans to (default: trace.json in the log dir)
+	Collector string `json:"collector"` // Zipkin-compatible collector, e.g. http://localhost:9411/api/v2/spans
+}
+
+// TransportConf limits the bandwidth of the intra-cluster streams (rebalance, dSort, EC)
+// so that the background traffic does not starve client I/O; the limits are in bytes
+// per second (e.g., "100MiB"), empty or zero - unlimited
+type TransportConf struct {
+	MaxStreamBandwidthStr string `json:"max_stream_bandwidth"` // per stream
+	MaxStreamBandwidth    int64  `json:"-"`                    //
+	MaxBundleBandwidthStr string `json:"max_bundle_bandwidth"` // per stream bundle (all streams combined)
+	MaxBundleBandwidth    int64  `json:"-"`                    //
+}
+
 // config for one keepalive tracker
 // all type of trackers share the same struct, not all fields are used by all trackers
 type KeepaliveTrackerConf struct {
@@ -436,6 +497,14 @@ func LoadConfig(configFile string, statsTime time.Duration, proxyURL string, log
 	if config.Net.IPv4IntraData != "" && config.Net.L4.PortIntraData != 0 && (differentIPs || differentPorts) {
 		config.Net.UseIntraData = true
 	}
+	if mtls := &config.Net.MTLS; mtls.Enabled {
+		if !config.Net.UseIntraControl || !config.Net.UseIntraData {
+			return errors.New("mtls requires separate intra-cluster control and data networks")
+		}
+		if mtls.CACert == "" || mtls.Certificate == "" || mtls.Key == "" {
+			return fmt.Errorf("invalid mtls configuration %+v: CA certificate, certificate, and key are required", *mtls)
+		}
+	}
 
 	// CLI override
 	if statsTime != 0 {
@@ -503,6 +572,13 @@ func validateConfig(config *Config) (err error) {
 	if config.Rebalance.DestRetryTime, err = time.ParseDuration(config.Rebalance.DestRetryTimeStr); err != nil {
 		return fmt.Errorf(badfmt, config.Rebalance.DestRetryTimeStr, err)
 	}
+	// sizes
+	if config.Transport.MaxStreamBandwidth, err = S2B(config.Transport.MaxStreamBandwidthStr); err != nil || config.Transport.MaxStreamBandwidth < 0 {
+		return fmt.Errorf(badfmt, config.Transport.MaxStreamBandwidthStr, err)
+	}
+	if config.Transport.MaxBundleBandwidth, err = S2B(config.Transport.MaxBundleBandwidthStr); err != nil || config.Transport.MaxBundleBandwidth < 0 {
+		return fmt.Errorf(badfmt, config.Transport.MaxBundleBandwidthStr, err)
+	}
 
 	hwm, lwm, oos := lru.HighWM, lru.LowWM, lru.OOS
 	if hwm <= 0 || lwm <= 0 || oos <= 0 || hwm < lwm || oos < hwm || lwm > 100 || hwm > 100 || oos > 100 {
@@ -526,6 +602,14 @@ func validateConfig(config *Config) (err error) {
 	if err := ValidateVersion(config.Ver.Versioning); err != nil {
 		return err
 	}
+	if config.CloudProvider == ProviderPosix && !filepath.IsAbs(config.Posix.Root) {
+		return fmt.Errorf("invalid %s configuration: root %q must be an absolute path", ProviderPosix, config.Posix.Root)
+	}
+	if config.CloudProvider == ProviderRemAIS {
+		if _, err := url.ParseRequestURI(config.RemAIS.URL); err != nil {
+			return fmt.Errorf("invalid %s configuration: bad URL %q, err: %v", ProviderRemAIS, config.RemAIS.URL, err)
+		}
+	}
 	if timeout.Default, err = time.ParseDuration(timeout.DefaultStr); err != nil {
 		return fmt.Errorf(badfmt, timeout.DefaultStr, err)
 	}
@@ -15,6 +15,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -180,3 +181,78 @@ func ReqWithContext(method, url string, body []byte) (*http.Request, context.Con
 	req = req.WithContext(ctx)
 	return req, ctx, cancel, nil
 }
+
+// MaxUserMetaSize limits the encoded size of user-defined object metadata
+// (the metadata is stored in a single xattr)
+const MaxUserMetaSize = 1000
+
+// UserMetaFromHeader returns user-defined object metadata carried by the
+// HeaderObjMetaPrefix-prefixed headers (nil if there's none); the keys are lowercased
+func UserMetaFromHeader(hdr http.Header) (meta SimpleKVs, err error) {
+	plen := len(HeaderObjMetaPrefix)
+	for key, vals := range hdr {
+		if len(key) < plen || !strings.EqualFold(key[:plen], HeaderObjMetaPrefix) {
+			continue
+		}
+		k := strings.ToLower(key[plen:])
+		if !ValidUserMeta(k, strings.Join(vals, ",")) {
+			return nil, fmt.Errorf("invalid user metadata header %q", key)
+		}
+		if meta == nil {
+			meta = make(SimpleKVs, 4)
+		}
+		meta[k] = strings.Join(vals, ",")
+	}
+	if size := len(EncodeUserMeta(meta)); size > MaxUserMetaSize {
+		return nil, fmt.Errorf("user metadata is too large (%d > %d)", size, MaxUserMetaSize)
+	}
+	return
+}
+
+// UserMetaToHeader is the reverse of UserMetaFromHeader
+func UserMetaToHeader(hdr http.Header, meta SimpleKVs) {
+	for k, v := range meta {
+		hdr.Set(HeaderObjMetaPrefix+k, v)
+	}
+}
+
+// ValidUserMeta returns true if the key/value pair can be stored as is
+func ValidUserMeta(k, v string) bool {
+	return k != "" && !strings.ContainsAny(k, "=\r\n") && !strings.ContainsAny(v, "\r\n")
+}
+
+// EncodeUserMeta serializes user-defined object metadata as sorted "key=value"
+// lines - the form in which the metadata is stored (xattr) and replicated
+func EncodeUserMeta(meta SimpleKVs) string {
+	if len(meta) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(meta[k])
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// DecodeUserMeta is the reverse of EncodeUserMeta
+func DecodeUserMeta(s string) (meta SimpleKVs) {
+	for _, line := range strings.Split(s, "\n") {
+		idx := strings.IndexByte(line, '=')
+		if idx <= 0 {
+			continue
+		}
+		if meta == nil {
+			meta = make(SimpleKVs, 4)
+		}
+		meta[line[:idx]] = line[idx+1:]
+	}
+	return
+}
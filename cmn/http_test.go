@@ -5,6 +5,8 @@
 package cmn
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -61,3 +63,58 @@ func TestMatchRESTItemsTooShort(t *testing.T) {
 		t.Errorf("expected error, apiItems returned: %v", apiItems)
 	}
 }
+
+func TestUserMetaHeader(t *testing.T) {
+	hdr := http.Header{}
+	hdr.Set("Ais-Meta-Owner", "alice")
+	hdr.Add("ais-meta-tags", "a")
+	hdr.Add("ais-meta-tags", "b")
+	hdr.Set(HeaderObjSize, "10")
+	meta, err := UserMetaFromHeader(hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta) != 2 || meta["owner"] != "alice" || meta["tags"] != "a,b" {
+		t.Errorf("unexpected user metadata: %v", meta)
+	}
+
+	out := http.Header{}
+	UserMetaToHeader(out, meta)
+	if back, err := UserMetaFromHeader(out); err != nil || len(back) != 2 || back["tags"] != "a,b" {
+		t.Errorf("round trip failed: %v (%v)", back, err)
+	}
+
+	if meta, err := UserMetaFromHeader(http.Header{HeaderObjSize: []string{"10"}}); err != nil || meta != nil {
+		t.Errorf("expected no user metadata, got %v (%v)", meta, err)
+	}
+	if _, err := UserMetaFromHeader(http.Header{"Ais-Meta-": []string{"x"}}); err == nil {
+		t.Error("expected empty key error")
+	}
+	if _, err := UserMetaFromHeader(http.Header{"Ais-Meta-A=b": []string{"x"}}); err == nil {
+		t.Error("expected invalid key error")
+	}
+	hdr.Set("Ais-Meta-Big", strings.Repeat("x", MaxUserMetaSize))
+	if _, err := UserMetaFromHeader(hdr); err == nil {
+		t.Error("expected size limit error")
+	}
+}
+
+func TestUserMetaEncode(t *testing.T) {
+	meta := SimpleKVs{"b": "2=3", "a": "", "c": "x,y"}
+	s := EncodeUserMeta(meta)
+	if s != "a=\nb=2=3\nc=x,y\n" {
+		t.Errorf("unexpected encoding %q", s)
+	}
+	back := DecodeUserMeta(s)
+	if len(back) != len(meta) {
+		t.Fatalf("expected %v, got %v", meta, back)
+	}
+	for k, v := range meta {
+		if back[k] != v {
+			t.Errorf("%s: expected %q, got %q", k, v, back[k])
+		}
+	}
+	if EncodeUserMeta(nil) != "" || DecodeUserMeta("") != nil {
+		t.Error("expected empty metadata")
+	}
+}
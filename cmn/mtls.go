@@ -0,0 +1,93 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+// intra-cluster mTLS config shared by all the intra-cluster clients and servers
+// of the node (see SetIntraClusterTLS)
+var intraClusterTLS *tls.Config
+
+// NewMTLSConfig returns TLS config for the intra-cluster (control and data) networks.
+// Both sides of each connection present certificates issued by the cluster CA.
+// Since the nodes are identified by their daemon IDs rather than host names, the
+// peer certificate's common name (the daemon ID) is then checked by verifyPeer
+// (e.g., against the cluster map).
+func NewMTLSConfig(conf *MTLSConf, verifyPeer func(daemonID string) error) (*tls.Config, error) {
+	caPEM, err := ioutil.ReadFile(conf.CACert)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no CA certificates found in %q", conf.CACert)
+	}
+	cert, err := tls.LoadX509KeyPair(conf.Certificate, conf.Key)
+	if err != nil {
+		return nil, err
+	}
+	verify := func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("mtls: peer did not present a certificate")
+		}
+		opts := x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: x509.NewCertPool(),
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			if certs[i], err = x509.ParseCertificate(raw); err != nil {
+				return err
+			}
+			if i > 0 {
+				opts.Intermediates.AddCert(certs[i])
+			}
+		}
+		if _, err := certs[0].Verify(opts); err != nil {
+			return fmt.Errorf("mtls: %v", err)
+		}
+		if err := verifyPeer(certs[0].Subject.CommonName); err != nil {
+			return fmt.Errorf("mtls: %v", err)
+		}
+		return nil
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		// both are verified by VerifyPeerCertificate (above)
+		ClientAuth:            tls.RequireAnyClientCert,
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: verify,
+		MinVersion:            tls.VersionTLS12,
+	}, nil
+}
+
+// MTLSDaemonID returns the daemon ID that the node's certificate is issued for.
+func MTLSDaemonID(conf *MTLSConf) (string, error) {
+	cert, err := tls.LoadX509KeyPair(conf.Certificate, conf.Key)
+	if err != nil {
+		return "", err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return "", err
+	}
+	if leaf.Subject.CommonName == "" {
+		return "", fmt.Errorf("certificate %q has no common name (daemon ID)", conf.Certificate)
+	}
+	return leaf.Subject.CommonName, nil
+}
+
+// SetIntraClusterTLS is called once, at startup, when mTLS is enabled.
+func SetIntraClusterTLS(conf *tls.Config) { intraClusterTLS = conf }
+
+// IntraClusterTLS returns the node's mTLS config or nil if mTLS is disabled.
+func IntraClusterTLS() *tls.Config { return intraClusterTLS }
@@ -0,0 +1,162 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, filepath.Join(dir, name+".crt"), "CERTIFICATE", der)
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue creates the node certificate and returns the mTLS config that points to it
+func (ca *testCA) issue(t *testing.T, dir, daemonID string) *MTLSConf {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: daemonID},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := &MTLSConf{
+		Enabled:     true,
+		CACert:      filepath.Join(dir, "ca.crt"),
+		Certificate: filepath.Join(dir, ca.cert.Subject.CommonName+"-"+daemonID+".crt"),
+		Key:         filepath.Join(dir, ca.cert.Subject.CommonName+"-"+daemonID+".key"),
+	}
+	writePEM(t, conf.Certificate, "CERTIFICATE", der)
+	writePEM(t, conf.Key, "EC PRIVATE KEY", keyDER)
+	return conf
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mtls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		ca      = newTestCA(t, dir, "ca")
+		rogueCA = newTestCA(t, dir, "rogue")
+		members = map[string]bool{"t1": true, "t2": true}
+	)
+	verifyPeer := func(daemonID string) error {
+		if !members[daemonID] {
+			return fmt.Errorf("%s is not a member", daemonID)
+		}
+		return nil
+	}
+
+	serverConf := ca.issue(t, dir, "t1")
+	if id, err := MTLSDaemonID(serverConf); err != nil || id != "t1" {
+		t.Fatalf("expected daemon ID t1, got %q (err: %v)", id, err)
+	}
+	tlsConf, err := NewMTLSConfig(serverConf, verifyPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = tlsConf
+	ts.StartTLS()
+	defer ts.Close()
+
+	tests := []struct {
+		name    string
+		conf    *MTLSConf
+		success bool
+	}{
+		{name: "member", conf: ca.issue(t, dir, "t2"), success: true},
+		{name: "not a member", conf: ca.issue(t, dir, "t3"), success: false},
+		{name: "issued by other CA", conf: rogueCA.issue(t, dir, "t2"), success: false},
+	}
+	for _, test := range tests {
+		test.conf.CACert = serverConf.CACert // trusting the cluster CA
+		clientConf, err := NewMTLSConfig(test.conf, verifyPeer)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		client := NewClient(ClientArgs{Timeout: 10 * time.Second, TLSConfig: clientConf})
+		resp, err := client.Get(ts.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if test.success && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if !test.success && err == nil {
+			t.Errorf("%s: expected the connection to be rejected", test.name)
+		}
+	}
+
+	// the client, in turn, verifies the server
+	delete(members, "t1")
+	clientConf, err := NewMTLSConfig(ca.issue(t, dir, "t2"), verifyPeer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := NewClient(ClientArgs{Timeout: 10 * time.Second, TLSConfig: clientConf})
+	if resp, err := client.Get(ts.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected the server that is not a member to be rejected")
+	}
+}
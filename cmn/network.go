@@ -28,7 +28,10 @@ type (
 		DialTimeout      time.Duration
 		Timeout          time.Duration
 		IdleConnsPerHost int
-		UseHTTPS         bool
+		UseHTTPS         bool        // HTTPS without verifying the server's certificate
+		TLSConfig        *tls.Config // intra-cluster mTLS (see IntraClusterTLS) - only for the intra-cluster URLs; takes precedence over UseHTTPS
+		// time to wait for the response headers - unlike Timeout, does not limit reading the body
+		ResponseHeaderTimeout time.Duration
 	}
 )
 
@@ -70,10 +73,13 @@ func NewTransport(args ClientArgs) *http.Transport {
 		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
 		TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout,
 		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		ResponseHeaderTimeout: args.ResponseHeaderTimeout,
 		MaxIdleConnsPerHost:   idleConnsPerHost,
 		MaxIdleConns:          0, // no limit
 	}
-	if args.UseHTTPS {
+	if args.TLSConfig != nil {
+		transport.TLSClientConfig = args.TLSConfig.Clone()
+	} else if args.UseHTTPS {
 		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 	return transport
@@ -6,6 +6,7 @@ package cmn
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -27,14 +28,34 @@ type (
 		Abort()
 		ChanAbort() <-chan struct{}
 		Finished() bool
+		Aborted() bool
+		// progress: number of objects and bytes processed so far, and errors
+		ObjectsAdd(cnt int64)
+		BytesAdd(size int64)
+		ErrorsAdd(cnt int64)
+		ObjCount() int64
+		BytesCount() int64
+		ErrCount() int64
+		// OnEnd registers a callback that gets called once - when the xaction
+		// finishes or is aborted (or right away if it already has)
+		OnEnd(f func())
 	}
 	XactBase struct {
-		id     int64
-		sutime int64
-		eutime int64
-		kind   string
-		bucket string
-		abrt   chan struct{}
+		id      int64
+		sutime  int64
+		eutime  int64
+		objects int64
+		bytes   int64
+		errors  int64
+		onEnd   *xactOnEnd // OnEnd callbacks
+		kind    string
+		bucket  string
+		abrt    chan struct{}
+	}
+	xactOnEnd struct {
+		sync.Mutex
+		callbacks []func()
+		ended     bool // the callbacks have been called
 	}
 	//
 	// xaction that self-terminates after staying idle for a while
@@ -70,7 +91,7 @@ var _ Xact = &XactBase{}
 
 func NewXactBase(id int64, kind string, bucket ...string) *XactBase {
 	stime := time.Now()
-	xact := &XactBase{id: id, kind: kind, abrt: make(chan struct{})}
+	xact := &XactBase{id: id, kind: kind, abrt: make(chan struct{}), onEnd: &xactOnEnd{}}
 	if len(bucket) > 0 {
 		xact.bucket = bucket[0]
 	}
@@ -91,6 +112,12 @@ func (xact *XactBase) Aborted() bool {
 		return false
 	}
 }
+func (xact *XactBase) ObjectsAdd(cnt int64) { atomic.AddInt64(&xact.objects, cnt) }
+func (xact *XactBase) BytesAdd(size int64)  { atomic.AddInt64(&xact.bytes, size) }
+func (xact *XactBase) ErrorsAdd(cnt int64)  { atomic.AddInt64(&xact.errors, cnt) }
+func (xact *XactBase) ObjCount() int64      { return atomic.LoadInt64(&xact.objects) }
+func (xact *XactBase) BytesCount() int64    { return atomic.LoadInt64(&xact.bytes) }
+func (xact *XactBase) ErrCount() int64      { return atomic.LoadInt64(&xact.errors) }
 
 func (xact *XactBase) String() string {
 	stime := xact.StartTime()
@@ -127,6 +154,7 @@ func (xact *XactBase) EndTime(e ...time.Time) time.Time {
 	etime := e[0]
 	atomic.StoreInt64(&xact.eutime, etime.UnixNano())
 	glog.Infoln(xact.String())
+	xact.notifyEnd()
 	return etime
 }
 
@@ -134,6 +162,33 @@ func (xact *XactBase) Abort() {
 	atomic.StoreInt64(&xact.eutime, time.Now().UnixNano())
 	close(xact.abrt)
 	glog.Infof("ABORT: " + xact.String())
+	xact.notifyEnd()
+}
+
+func (xact *XactBase) OnEnd(f func()) {
+	xact.onEnd.Lock()
+	if !xact.onEnd.ended {
+		xact.onEnd.callbacks = append(xact.onEnd.callbacks, f)
+		xact.onEnd.Unlock()
+		return
+	}
+	xact.onEnd.Unlock()
+	f()
+}
+
+// notifyEnd calls all the OnEnd callbacks, only once
+func (xact *XactBase) notifyEnd() {
+	xact.onEnd.Lock()
+	if xact.onEnd.ended {
+		xact.onEnd.Unlock()
+		return
+	}
+	callbacks := xact.onEnd.callbacks
+	xact.onEnd.callbacks, xact.onEnd.ended = nil, true
+	xact.onEnd.Unlock()
+	for _, f := range callbacks {
+		f()
+	}
 }
 
 //
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestXactOnEnd(t *testing.T) {
+	var (
+		xact  = cmn.NewXactBase(1, "test")
+		calls = make([]int, 3)
+	)
+	xact.OnEnd(func() { calls[0]++ })
+	xact.OnEnd(func() { calls[1]++ })
+	xact.EndTime(time.Now())
+	xact.Abort() // ends only once
+	xact.OnEnd(func() { calls[2]++ })
+	for i, cnt := range calls {
+		if cnt != 1 {
+			t.Errorf("callback %d: expected to be called once, called %d times", i, cnt)
+		}
+	}
+}
@@ -668,6 +668,8 @@ func (t *task) download() {
 		return
 	}
 
+	t.parent.ObjectsAdd(1)
+	t.parent.BytesAdd(t.currentSize)
 	t.parent.stats.AddMany(
 		stats.NamedVal64{Name: stats.DownloadSize, Val: t.currentSize},
 		stats.NamedVal64{Name: stats.DownloadLatency, Val: int64(time.Since(started))},
@@ -685,6 +687,7 @@ func (t *task) cancel() {
 
 func (t *task) abort(err error) {
 	t.parent.stats.Add(stats.ErrDownloadCount, 1)
+	t.parent.ErrorsAdd(1)
 	t.finishedCh <- err
 }
 
@@ -0,0 +1,226 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+
+// Package dsort provides APIs for distributed archive file shuffling.
+package dsort
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	"github.com/OneOfOne/xxhash"
+)
+
+// checkpointDir is the directory (relative to config.Confdir) where the
+// checkpoints of dSort jobs are stored.
+const checkpointDir = "dsort"
+
+type (
+	// checkpoint is a journal of output shards which have been created by this
+	// target as part of the dSort job. The journal outlives the job when the
+	// job is aborted (eg. because one of the targets died) so that the job can
+	// be re-run with `resume` and the shards which already exist with matching
+	// content are not created again.
+	//
+	// The journal is identified by the fingerprint of the request spec, that is
+	// why the same request must be used to resume the job.
+	//
+	// Only the created shards are checkpointed, not the extracted records: the
+	// records extracted to memory are lost with the target anyway, so the
+	// extraction and sorting phases are always re-run when resuming.
+	checkpoint struct {
+		mu      sync.Mutex
+		path    string
+		file    *os.File
+		created map[string]*createdShard
+	}
+
+	// createdShard describes single entry in the journal.
+	createdShard struct {
+		Name      string `json:"name"`
+		Signature string `json:"signature"` // see: shardSignature
+		Size      int64  `json:"size"`
+		Cksum     string `json:"cksum,omitempty"`
+	}
+)
+
+// newCheckpoint opens the journal of the job described by rs. The entries
+// from the previous runs are loaded only when the job is resumed, otherwise
+// the journal is truncated. Returns nil checkpoint when there is no place
+// where the journal could be stored.
+func newCheckpoint(rs *ParsedRequestSpec) (*checkpoint, error) {
+	path, err := checkpointPath(rs)
+	if path == "" || err != nil {
+		return nil, err
+	}
+	c := &checkpoint{
+		path:    path,
+		created: make(map[string]*createdShard),
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	if rs.Resume {
+		if err := c.load(); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		glog.Infof("resuming dsort, %d shard(s) have been already created (checkpoint: %s)", len(c.created), c.path)
+	} else {
+		flags |= os.O_TRUNC
+	}
+
+	if err := cmn.CreateDir(filepath.Dir(c.path)); err != nil {
+		return nil, err
+	}
+	if c.file, err = os.OpenFile(c.path, flags, 0644); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// checkpointPath returns the path of the journal of the job described by rs
+// (empty if there is no place where it could be stored).
+func checkpointPath(rs *ParsedRequestSpec) (string, error) {
+	confdir := cmn.GCO.Get().Confdir
+	if confdir == "" {
+		return "", nil
+	}
+	fingerprint, err := jobFingerprint(rs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(confdir, checkpointDir, fingerprint+".json"), nil
+}
+
+// removeCheckpoint removes the journal of the job described by rs, eg. when
+// the metadata of the aborted job is removed and so the job will not be resumed.
+func removeCheckpoint(rs *ParsedRequestSpec) {
+	path, err := checkpointPath(rs)
+	if err != nil {
+		glog.Error(err)
+		return
+	}
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		glog.Error(err)
+	}
+}
+
+func (c *checkpoint) load() error {
+	file, err := os.Open(c.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		entry := &createdShard{}
+		if err := js.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// The last entry could have been written partially when the
+			// target died - all the previous entries are still valid.
+			glog.Warningf("failed to read checkpoint %s entry, err: %v", c.path, err)
+			break
+		}
+		c.created[entry.Name] = entry
+	}
+	return scanner.Err()
+}
+
+// lookup returns the entry of already created shard if its signature matches.
+func (c *checkpoint) lookup(name, signature string) (*createdShard, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	entry, ok := c.created[name]
+	c.mu.Unlock()
+	if !ok || entry.Signature != signature {
+		return nil, false
+	}
+	return entry, true
+}
+
+// add appends the entry to the journal.
+func (c *checkpoint) add(entry *createdShard) error {
+	if c == nil {
+		return nil
+	}
+	b, err := js.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.created[entry.Name] = entry
+	_, err = c.file.Write(append(b, '\n'))
+	return err
+}
+
+func (c *checkpoint) close() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	if err := c.file.Close(); err != nil {
+		glog.Error(err)
+	}
+	c.mu.Unlock()
+}
+
+// remove removes the journal - should be called once the job has finished
+// successfully on all the targets.
+func (c *checkpoint) remove() {
+	if c == nil {
+		return
+	}
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		glog.Error(err)
+	}
+}
+
+// jobFingerprint identifies the dSort job across the runs. Only the fields
+// which determine the content of output shards are taken into account, so the
+// resources (eg. memory, concurrency) can be adjusted when resuming.
+func jobFingerprint(rs *ParsedRequestSpec) (string, error) {
+	spec := *rs
+	spec.TargetOrderSalt = nil
+	spec.MaxMemUsage = nil
+	spec.ExtractConcLimit, spec.CreateConcLimit = 0, 0
+	spec.ExtendedMetrics = false
+	spec.Resume = false
+
+	b, err := js.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	h := xxhash.New64()
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// shardSignature describes the content of the shard: its records - their keys
+// and content paths (which are derived from the input shards and the names of
+// the records, since the keys alone may repeat, eg. when extracted from the
+// content) - and their objects. The same input and sort algorithm results in
+// the same signature (note that shuffle must be given the seed).
+func shardSignature(s *extract.Shard) string {
+	h := xxhash.New64()
+	for _, rec := range s.Records.All() {
+		fmt.Fprintf(h, "%v\x00%s\x00%s\x00", rec.Key, rec.DaemonID, rec.ContentPath)
+		for _, obj := range rec.Objects {
+			fmt.Fprintf(h, "%s\x00%d\x00%d\x00", obj.Extension, obj.Size, obj.MetadataSize)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,123 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dsort/extract"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	newSpec := func() *ParsedRequestSpec {
+		return &ParsedRequestSpec{
+			Bucket:           "bucket",
+			Extension:        extTar,
+			Algorithm:        &SortAlgorithm{Kind: SortKindShuffle, Seed: "10"},
+			TargetOrderSalt:  []byte("salt"),
+			ExtractConcLimit: 10,
+			CreateConcLimit:  10,
+		}
+	}
+
+	newShard := func(keys ...string) *extract.Shard {
+		s := &extract.Shard{Records: extract.NewRecords(len(keys))}
+		for _, key := range keys {
+			s.Records.Insert(&extract.Record{
+				Key:         key,
+				ContentPath: key,
+				Objects:     []*extract.RecordObj{{Size: 100, Extension: ".txt"}},
+			})
+		}
+		return s
+	}
+
+	BeforeEach(func() {
+		err := os.MkdirAll(testingConfigDir, 0750)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		config := cmn.GCO.BeginUpdate()
+		config.Confdir = testingConfigDir
+		cmn.GCO.CommitUpdate(config)
+	})
+
+	AfterEach(func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Confdir = ""
+		cmn.GCO.CommitUpdate(config)
+
+		err := os.RemoveAll(testingConfigDir)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should ignore resources when computing fingerprint", func() {
+		rs := newSpec()
+		fp, err := jobFingerprint(rs)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		other := newSpec()
+		other.TargetOrderSalt = []byte("other salt")
+		other.CreateConcLimit = 20
+		other.Resume = true
+		otherFp, err := jobFingerprint(other)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(otherFp).To(Equal(fp))
+
+		other.Algorithm = &SortAlgorithm{Kind: SortKindShuffle, Seed: "11"}
+		otherFp, err = jobFingerprint(other)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(otherFp).NotTo(Equal(fp))
+	})
+
+	It("should compute signature based on records", func() {
+		Expect(shardSignature(newShard("a", "b"))).To(Equal(shardSignature(newShard("a", "b"))))
+		Expect(shardSignature(newShard("a", "b"))).NotTo(Equal(shardSignature(newShard("b", "a"))))
+		Expect(shardSignature(newShard("a", "b"))).NotTo(Equal(shardSignature(newShard("a"))))
+
+		// the same keys (eg. extracted from the content) of different records
+		other := newShard("a", "b")
+		other.Records.All()[1].ContentPath = "c"
+		Expect(shardSignature(newShard("a", "b"))).NotTo(Equal(shardSignature(other)))
+	})
+
+	It("should load created shards only when resuming", func() {
+		rs := newSpec()
+		c, err := newCheckpoint(rs)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(c.add(&createdShard{Name: "shard-1", Signature: "sig1", Size: 10})).To(Succeed())
+		Expect(c.add(&createdShard{Name: "shard-2", Signature: "sig2", Size: 20})).To(Succeed())
+		c.close()
+
+		rs.Resume = true
+		c, err = newCheckpoint(rs)
+		Expect(err).ShouldNot(HaveOccurred())
+		entry, ok := c.lookup("shard-2", "sig2")
+		Expect(ok).To(BeTrue())
+		Expect(entry.Size).To(BeEquivalentTo(20))
+		_, ok = c.lookup("shard-1", "other")
+		Expect(ok).To(BeFalse())
+		_, ok = c.lookup("shard-3", "sig3")
+		Expect(ok).To(BeFalse())
+		c.close()
+
+		rs.Resume = false
+		c, err = newCheckpoint(rs)
+		Expect(err).ShouldNot(HaveOccurred())
+		c.close()
+
+		rs.Resume = true
+		c, err = newCheckpoint(rs)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, ok = c.lookup("shard-2", "sig2")
+		Expect(ok).To(BeFalse())
+		c.close()
+		c.remove()
+		_, err = os.Stat(c.path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -300,7 +300,7 @@ ExtractAllShards:
 	//
 	// Checking if all records have keys (keys are not nil). Algorithms other
 	// than content kind should have keys, it is a bug if they don't.
-	if m.rs.Algorithm.Kind == SortKindContent {
+	if m.rs.Algorithm.Kind == SortKindContent || m.rs.Algorithm.Kind == SortKindRegex {
 		if err := m.recManager.Records.EnsureKeys(); err != nil {
 			return err
 		}
@@ -324,13 +324,15 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 
 		// object related variables
 		shardName  = m.genShardName(s)
-		bucket     = m.rs.Bucket
-		bckIsLocal = m.rs.IsLocalBucket
+		bucket     = m.rs.OutputBucket
+		bckIsLocal = m.rs.OutputIsLocal
 
 		// variables which may be not set, depending on context
 		h          hash.Hash
 		cksumType  string
 		cksumValue string
+		shardSize  int64
+		signature  = shardSignature(s)
 	)
 
 	if m.Metrics.extended {
@@ -351,6 +353,20 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	default:
 	}
 
+	if m.rs.Resume {
+		if entry, ok := m.checkpoint.lookup(shardName, signature); ok && shardCreated(lom, bckProvider, entry) {
+			glog.V(4).Infof("shard %s has been already created, skipping", lom)
+			if err := m.releaseShard(s); err != nil {
+				return err
+			}
+			metrics.Lock()
+			metrics.CreatedCnt++
+			metrics.SkippedCnt++
+			metrics.Unlock()
+			return nil
+		}
+	}
+
 	m.acquireCreateSema()
 	defer m.releaseCreateSema()
 
@@ -387,6 +403,9 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	if err := cmn.MvFile(workFQN, lom.FQN); err != nil {
 		return err
 	}
+	if fi, err := os.Stat(lom.FQN); err == nil {
+		shardSize = fi.Size()
+	}
 
 	if errStr := lom.Persist(); errStr != "" {
 		return errors.New(errStr)
@@ -448,19 +467,43 @@ func (m *Manager) createShard(s *extract.Shard) (err error) {
 	}
 
 exit:
+	if err := m.checkpoint.add(&createdShard{Name: shardName, Signature: signature, Size: shardSize, Cksum: cksumValue}); err != nil {
+		glog.Errorf("failed to checkpoint shard %s, err: %v", lom, err)
+	}
+
+	metrics.Lock()
+	metrics.CreatedCnt++
 	if m.Metrics.extended {
-		metrics.Lock()
-		metrics.CreatedCnt++
 		metrics.ShardCreationStats.update(time.Since(beforeCreation))
 		if si.DaemonID != m.ctx.node.DaemonID {
 			metrics.MovedShardCnt++
 		}
-		metrics.Unlock()
 	}
+	metrics.Unlock()
 
 	return nil
 }
 
+// shardCreated checks if the shard, which according to the checkpoint has
+// been already created, still exists and has not changed since.
+func shardCreated(lom *cluster.LOM, bckProvider string, entry *createdShard) bool {
+	if !lom.Exists() || lom.Size != entry.Size {
+		return false
+	}
+	if entry.Cksum == "" {
+		return true
+	}
+	if errStr := lom.Fill(bckProvider, cluster.LomCksum|cluster.LomCksumPresentRecomp); errStr != "" {
+		glog.Warningf("shard %s will be created again, err: %s", lom, errStr)
+		return false
+	}
+	if lom.Cksum == nil {
+		return false
+	}
+	_, value := lom.Cksum.Get()
+	return value == entry.Cksum
+}
+
 // createShardsLocally waits until it's given the signal to start creating
 // shards, then creates shards in parallel.
 func (m *Manager) createShardsLocally() (err error) {
@@ -679,7 +722,7 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 			// correct HRW target as opposed to constructing it on the target
 			// with optimal file content locality and then sent to the correct
 			// target.
-			si, errStr := cluster.HrwTarget(m.rs.Bucket, m.genShardName(shard), m.smap)
+			si, errStr := cluster.HrwTarget(m.rs.OutputBucket, m.genShardName(shard), m.smap)
 			if errStr != "" {
 				return errors.New(errStr)
 			}
@@ -717,7 +760,7 @@ func (m *Manager) distributeShardRecords(maxSize int64) error {
 			u += fmt.Sprintf(
 				"%s?%s=%t",
 				cmn.URLPath(cmn.Version, cmn.Sort, cmn.Shards, m.ManagerUUID),
-				cmn.URLParamLocal, m.rs.IsLocalBucket,
+				cmn.URLParamLocal, m.rs.OutputIsLocal,
 			)
 			if _, err = m.doWithAbort(http.MethodPost, u, body, nil); err != nil {
 				errCh <- err
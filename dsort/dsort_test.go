@@ -518,6 +518,8 @@ var _ = Describe("Distributed Sort", func() {
 					Extension:     extTar,
 					Bucket:        testBucket,
 					IsLocalBucket: true,
+					OutputBucket:  testBucket,
+					OutputIsLocal: true,
 					OutputFormat: &parsedOutputTemplate{
 						Prefix: "superprefix",
 						Suffix: "supersuffix",
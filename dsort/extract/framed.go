@@ -0,0 +1,138 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+var (
+	_ ExtractCreator = &framedExtractCreator{}
+)
+
+type (
+	// recordFraming describes how single record is framed in record-oriented
+	// shard (eg. TFRecord or RecordIO): what precedes and what follows the
+	// record's data. Implementations are stateful (they usually compute
+	// checksum of the data) so new instance is required for each shard.
+	recordFraming interface {
+		// readHeader reads the header which precedes the data and returns the
+		// size of the data. It returns io.EOF when there are no more records.
+		readHeader(r io.Reader) (int64, error)
+		// dataReader wraps the reader of the data.
+		dataReader(r io.Reader) io.Reader
+		// readFooter reads and validates the footer which follows the data.
+		readFooter(r io.Reader) error
+
+		writeHeader(w io.Writer, size int64) error
+		dataWriter(w io.Writer) io.Writer
+		writeFooter(w io.Writer) error
+	}
+
+	// framedExtractCreator extracts and creates shards which are sequences of
+	// framed (length-prefixed) records. Records do not have names nor any
+	// metadata so they are named after the shard and their position in it.
+	framedExtractCreator struct {
+		ext        string
+		newFraming func() recordFraming
+	}
+
+	// framedRecordWriter frames the data of a single record.
+	framedRecordWriter struct {
+		framing recordFraming
+		writer  io.Writer
+	}
+)
+
+func (fw *framedRecordWriter) reinit(w io.Writer, size int64) error {
+	if err := fw.framing.writeHeader(w, size); err != nil {
+		return err
+	}
+	fw.writer = fw.framing.dataWriter(w)
+	return nil
+}
+
+func (fw *framedRecordWriter) Write(p []byte) (int, error) {
+	return fw.writer.Write(p)
+}
+
+// recordName returns name of i-th record in the shard. The name must not have
+// an extension, otherwise it would be treated as separate object of a record.
+func (f *framedExtractCreator) recordName(fqn string, i int) string {
+	shardName := strings.TrimSuffix(filepath.Base(fqn), f.ext)
+	return fmt.Sprintf("%s/%08d", shardName, i)
+}
+
+// ExtractShard reads all the records one by one and extracts their data.
+func (f *framedExtractCreator) ExtractShard(fqn string, r *io.SectionReader, extractor RecordExtractor, toDisk bool) (extractedSize int64, extractedCount int, err error) {
+	var (
+		size    int64
+		framing = f.newFraming()
+		br      = bufio.NewReaderSize(r, 64*cmn.KiB)
+	)
+
+	buf, slab := mem.AllocFromSlab2(cmn.MiB)
+	defer slab.Free(buf)
+	for {
+		dataSize, err := framing.readHeader(br)
+		if err == io.EOF {
+			return extractedSize, extractedCount, nil
+		} else if err != nil {
+			return extractedSize, extractedCount, err
+		}
+
+		name := f.recordName(fqn, extractedCount)
+		data := cmn.NewSizedReader(framing.dataReader(io.LimitReader(br, dataSize)), dataSize)
+		if size, err = extractor.ExtractRecordWithBuffer(fqn, name, data, nil, toDisk, buf); err != nil {
+			return extractedSize, extractedCount, err
+		}
+		if size != dataSize {
+			return extractedSize, extractedCount, fmt.Errorf("record %s is truncated: expected %d bytes, got %d", name, dataSize, size)
+		}
+		if err := framing.readFooter(br); err != nil {
+			return extractedSize, extractedCount, fmt.Errorf("record %s: %v", name, err)
+		}
+
+		extractedSize += size
+		extractedCount++
+	}
+}
+
+// CreateShard creates a new shard locally based on the Shard. Each record is
+// framed anew so the checksums are computed over the (possibly remote) data.
+func (f *framedExtractCreator) CreateShard(s *Shard, w io.Writer, loadContent LoadContentFunc) (written int64, err error) {
+	var n int64
+	fw := &framedRecordWriter{framing: f.newFraming()}
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			if err = fw.reinit(w, obj.Size); err != nil {
+				return written, err
+			}
+			if n, err = loadContent(fw, rec, obj); err != nil {
+				return written + n, err
+			}
+			if err = fw.framing.writeFooter(w); err != nil {
+				return written + n, err
+			}
+
+			written += n
+		}
+	}
+	return written, nil
+}
+
+func (f *framedExtractCreator) UsingCompression() bool {
+	return false
+}
+
+func (f *framedExtractCreator) MetadataSize() int64 {
+	return 0 // records do not have any metadata
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// memRecordExtractor keeps extracted records in memory.
+type memRecordExtractor struct {
+	names []string
+	data  [][]byte
+}
+
+func (e *memRecordExtractor) ExtractRecord(fqn, name string, r cmn.ReadSizer, metadata []byte, toDisk bool) (int64, error) {
+	return e.ExtractRecordWithBuffer(fqn, name, r, metadata, toDisk, nil)
+}
+
+func (e *memRecordExtractor) ExtractRecordWithBuffer(fqn, name string, r cmn.ReadSizer, metadata []byte, toDisk bool, buf []byte) (int64, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+	e.names = append(e.names, name)
+	e.data = append(e.data, b)
+	return int64(len(b)), nil
+}
+
+var _ = Describe("Framed shards", func() {
+	// records of different sizes, so that RecordIO padding is exercised
+	contents := [][]byte{
+		[]byte("first record"),
+		{},
+		[]byte("3rd"),
+		bytes.Repeat([]byte("x"), 100*cmn.KiB+1),
+	}
+
+	createShard := func(ec ExtractCreator) []byte {
+		shard := &Shard{Records: NewRecords(len(contents))}
+		for i, content := range contents {
+			shard.Records.Insert(&Record{
+				ContentPath: fmt.Sprintf("%d", i),
+				Objects:     []*RecordObj{{Size: int64(len(content))}},
+			})
+		}
+		loadContent := func(w io.Writer, rec *Record, obj *RecordObj) (int64, error) {
+			var i int
+			fmt.Sscanf(rec.ContentPath, "%d", &i)
+			return io.Copy(w, bytes.NewReader(contents[i]))
+		}
+
+		buf := &bytes.Buffer{}
+		written, err := ec.CreateShard(shard, buf, loadContent)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(written).To(BeEquivalentTo(100*cmn.KiB + 1 + 15))
+		return buf.Bytes()
+	}
+
+	extractShard := func(ec ExtractCreator, b []byte) (*memRecordExtractor, error) {
+		extractor := &memRecordExtractor{}
+		_, count, err := ec.ExtractShard("/path/to/shard-01"+extTestExt(ec), io.NewSectionReader(bytes.NewReader(b), 0, int64(len(b))), extractor, false)
+		if err == nil {
+			Expect(count).To(Equal(len(contents)))
+		}
+		return extractor, err
+	}
+
+	for _, ec := range []ExtractCreator{NewTFRecordExtractCreator(".tfrecord"), NewRecordIOExtractCreator(".rec")} {
+		ec := ec
+
+		Context(extTestExt(ec), func() {
+			It("should extract records from created shard", func() {
+				b := createShard(ec)
+				extractor, err := extractShard(ec, b)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(extractor.data).To(HaveLen(len(contents)))
+				for i, content := range contents {
+					Expect(extractor.names[i]).To(Equal(fmt.Sprintf("shard-01/%08d", i)))
+					Expect(extractor.data[i]).To(Equal(content))
+				}
+			})
+
+			It("should fail to extract truncated shard", func() {
+				b := createShard(ec)
+				_, err := extractShard(ec, b[:len(b)-5])
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+	}
+
+	It("should detect corrupted tfrecord", func() {
+		ec := NewTFRecordExtractCreator(".tfrecord")
+		b := createShard(ec)
+		b[tfRecordHeaderSize] ^= 0xff // first byte of the first record's data
+		_, err := extractShard(ec, b)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(errTFRecordDataCRC.Error()))
+	})
+
+	It("should reject split recordio records", func() {
+		ec := NewRecordIOExtractCreator(".rec")
+		b := createShard(ec)
+		b[7] |= 1 << 5 // continuation flag of the first record
+		_, err := extractShard(ec, b)
+		Expect(err).To(Equal(errRecordIOSplit))
+	})
+})
+
+func extTestExt(ec ExtractCreator) string {
+	return ec.(*framedExtractCreator).ext
+}
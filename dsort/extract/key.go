@@ -7,13 +7,18 @@ package extract
 import (
 	"bytes"
 	"crypto/md5"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"io/ioutil"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -26,6 +31,11 @@ var (
 	supportedFormatTypes = []string{FormatTypeInt, FormatTypeFloat, FormatTypeString}
 
 	errInvalidAlgorithmFormatTypes = fmt.Errorf("invalid algorithm format type provided, shoule be one of: %+v", supportedFormatTypes)
+	errInvalidRegexGroups          = errors.New("regex must contain at least one capture group")
+
+	// jsNumber decodes JSON numbers as json.Number so the int keys do not
+	// lose precision by being parsed as float64.
+	jsNumber = jsoniter.Config{UseNumber: true}.Froze()
 )
 
 type (
@@ -49,6 +59,14 @@ type (
 	contentKeyExtractor struct {
 		ty  string // type of key extracted, supported: supportedFormatTypes
 		ext string // extension of object record whose content will be read
+		// jsonPath, when set, means that the content is JSON document and the
+		// key is the value under given path (eg. ["labels", "0", "id"]).
+		jsonPath []string
+	}
+
+	regexKeyExtractor struct {
+		ty string // type of key extracted, supported: supportedFormatTypes
+		re *regexp.Regexp
 	}
 )
 
@@ -78,12 +96,20 @@ func (ke *nameKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{}, er
 	return ske.name, nil
 }
 
-func NewContentKeyExtractor(ty, ext string) (KeyExtractor, error) {
+// NewContentKeyExtractor returns extractor which reads the key from the content
+// of the record object with given extension. When jsonPath (dot separated, eg.
+// "labels.0.id") is provided, the content is parsed as JSON document and the
+// value under the path is used as the key.
+func NewContentKeyExtractor(ty, ext, jsonPath string) (KeyExtractor, error) {
 	if err := ValidateAlgorithmFormatType(ty); err != nil {
 		return nil, err
 	}
 
-	return &contentKeyExtractor{ty: ty, ext: ext}, nil
+	ke := &contentKeyExtractor{ty: ty, ext: ext}
+	if jsonPath != "" {
+		ke.jsonPath = strings.Split(jsonPath, ".")
+	}
+	return ke, nil
 }
 
 func (ke *contentKeyExtractor) PrepareExtractor(name string, r cmn.ReadSizer, ext string) (cmn.ReadSizer, *SingleKeyExtractor) {
@@ -107,8 +133,71 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 		return nil, err
 	}
 
-	key := string(b)
-	switch ke.ty {
+	if ke.jsonPath == nil {
+		return parseKey(string(b), ke.ty)
+	}
+
+	var v interface{}
+	if err := jsNumber.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("failed to parse %q as JSON, err: %v", ske.name, err)
+	}
+	for _, field := range ke.jsonPath {
+		switch node := v.(type) {
+		case map[string]interface{}:
+			v = node[field]
+		case []interface{}:
+			idx, err := strconv.Atoi(field)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, fmt.Errorf("invalid index %q of JSON array in %q", field, ske.name)
+			}
+			v = node[idx]
+		default:
+			v = nil
+		}
+		if v == nil {
+			return nil, fmt.Errorf("JSON path %q does not exist in %q", strings.Join(ke.jsonPath, "."), ske.name)
+		}
+	}
+
+	switch value := v.(type) {
+	case string:
+		return parseKey(value, ke.ty)
+	case json.Number:
+		return parseKey(value.String(), ke.ty)
+	default:
+		return nil, fmt.Errorf("value under JSON path %q in %q is neither string nor number", strings.Join(ke.jsonPath, "."), ske.name)
+	}
+}
+
+// NewRegexKeyExtractor returns extractor which matches the regex against the
+// name of the record (without extension) and uses the first capture group as
+// the key.
+func NewRegexKeyExtractor(ty, expr string) (KeyExtractor, error) {
+	if err := ValidateAlgorithmFormatType(ty); err != nil {
+		return nil, err
+	}
+	re, err := ValidateAlgorithmRegex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &regexKeyExtractor{ty: ty, re: re}, nil
+}
+
+func (ke *regexKeyExtractor) PrepareExtractor(name string, r cmn.ReadSizer, ext string) (cmn.ReadSizer, *SingleKeyExtractor) {
+	return r, &SingleKeyExtractor{name: strings.TrimSuffix(name, ext)}
+}
+
+func (ke *regexKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{}, error) {
+	matches := ke.re.FindStringSubmatch(ske.name)
+	if matches == nil {
+		return nil, fmt.Errorf("record name %q does not match regex %q", ske.name, ke.re)
+	}
+	return parseKey(matches[1], ke.ty)
+}
+
+func parseKey(key, ty string) (interface{}, error) {
+	switch ty {
 	case FormatTypeInt:
 		return strconv.ParseInt(key, 10, 64)
 	case FormatTypeFloat:
@@ -116,7 +205,7 @@ func (ke *contentKeyExtractor) ExtractKey(ske *SingleKeyExtractor) (interface{},
 	case FormatTypeString:
 		return key, nil
 	default:
-		return nil, fmt.Errorf("not implemented extractor type: %s", ke.ty)
+		return nil, fmt.Errorf("not implemented extractor type: %s", ty)
 	}
 }
 
@@ -127,3 +216,16 @@ func ValidateAlgorithmFormatType(ty string) error {
 
 	return nil
 }
+
+// ValidateAlgorithmRegex compiles the regex and checks if it can be used to
+// extract the key (contains capture group).
+func ValidateAlgorithmRegex(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	if re.NumSubexp() < 1 {
+		return nil, errInvalidRegexGroups
+	}
+	return re, nil
+}
@@ -0,0 +1,102 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyExtractor", func() {
+	extractKey := func(ke KeyExtractor, name, ext string, content []byte) (interface{}, error) {
+		r, ske := ke.PrepareExtractor(name, cmn.NewSizedReader(bytes.NewReader(content), int64(len(content))), ext)
+		_, err := ioutil.ReadAll(r)
+		Expect(err).ShouldNot(HaveOccurred())
+		return ke.ExtractKey(ske)
+	}
+
+	Context("content", func() {
+		doc := []byte(`{"speaker": "abc", "timestamp": 1550000000123, "labels": [{"score": 0.5}, {"score": 1.25}]}`)
+
+		It("should extract key from the whole content", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeInt, ".cls", "")
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err := extractKey(ke, "record.cls", ".cls", []byte("12"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal(int64(12)))
+		})
+
+		It("should not extract key from objects with other extension", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeString, ".json", "speaker")
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err := extractKey(ke, "record.jpg", ".jpg", []byte("jpg"))
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(BeNil())
+		})
+
+		It("should extract key by JSON path", func() {
+			ke, err := NewContentKeyExtractor(FormatTypeString, ".json", "speaker")
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err := extractKey(ke, "record.json", ".json", doc)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal("abc"))
+
+			ke, err = NewContentKeyExtractor(FormatTypeInt, ".json", "timestamp")
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err = extractKey(ke, "record.json", ".json", doc)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal(int64(1550000000123)))
+
+			ke, err = NewContentKeyExtractor(FormatTypeFloat, ".json", "labels.1.score")
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err = extractKey(ke, "record.json", ".json", doc)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal(1.25))
+		})
+
+		It("should fail when JSON path does not exist", func() {
+			for _, path := range []string{"missing", "labels.2.score", "speaker.name", "labels"} {
+				ke, err := NewContentKeyExtractor(FormatTypeString, ".json", path)
+				Expect(err).ShouldNot(HaveOccurred())
+				_, err = extractKey(ke, "record.json", ".json", doc)
+				Expect(err).Should(HaveOccurred())
+			}
+		})
+	})
+
+	Context("regex", func() {
+		It("should extract key from the capture group of record name", func() {
+			ke, err := NewRegexKeyExtractor(FormatTypeInt, `speaker_([0-9]+)_`)
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err := extractKey(ke, "dir/speaker_0042_utt_7.wav", ".wav", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal(int64(42)))
+		})
+
+		It("should match against record name without extension", func() {
+			ke, err := NewRegexKeyExtractor(FormatTypeString, `_([a-z]+)$`)
+			Expect(err).ShouldNot(HaveOccurred())
+			key, err := extractKey(ke, "img_cat.jpg", ".jpg", nil)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(key).To(Equal("cat"))
+		})
+
+		It("should fail when record name does not match", func() {
+			ke, err := NewRegexKeyExtractor(FormatTypeString, `speaker_([0-9]+)_`)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = extractKey(ke, "img_cat.jpg", ".jpg", nil)
+			Expect(err).Should(HaveOccurred())
+		})
+
+		It("should not accept regex without capture group", func() {
+			_, err := NewRegexKeyExtractor(FormatTypeString, `speaker_[0-9]+`)
+			Expect(err).To(Equal(errInvalidRegexGroups))
+		})
+	})
+})
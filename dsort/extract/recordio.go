@@ -0,0 +1,106 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	recordIOMagic      = 0xced7230a
+	recordIOHeaderSize = 4 + 4 // magic, lrecord
+	recordIOAlign      = 4
+	recordIOLengthBits = 29
+	recordIOMaxLength  = 1<<recordIOLengthBits - 1
+)
+
+var (
+	errRecordIOMagic = errors.New("recordio: invalid magic number")
+	errRecordIOSplit = errors.New("recordio: split records are not supported")
+)
+
+// recordIOFraming implements the RecordIO format (as used by MXNet), where each
+// record is:
+//
+//	uint32 magic
+//	uint32 lrecord: upper 3 bits - continuation flag, lower 29 bits - length
+//	byte   data[length]
+//	byte   padding[] to 4 bytes boundary
+//
+// All integers are little-endian. Since RecordIO is just a sequence of
+// length-prefixed blobs, it is also the format of choice for MessagePack
+// (or any other) serialized samples.
+type recordIOFraming struct {
+	buf  [recordIOHeaderSize]byte
+	size int64
+}
+
+func newRecordIOFraming() recordFraming {
+	return &recordIOFraming{}
+}
+
+// NewRecordIOExtractCreator returns creator of RecordIO shards. Every record
+// becomes a separate object so the records are shuffled independently.
+func NewRecordIOExtractCreator(ext string) ExtractCreator {
+	return &framedExtractCreator{
+		ext:        ext,
+		newFraming: newRecordIOFraming,
+	}
+}
+
+func recordIOPadding(size int64) int64 {
+	return (recordIOAlign - size%recordIOAlign) % recordIOAlign
+}
+
+func (f *recordIOFraming) readHeader(r io.Reader) (int64, error) {
+	if _, err := io.ReadFull(r, f.buf[:recordIOHeaderSize]); err != nil {
+		return 0, err
+	}
+	if binary.LittleEndian.Uint32(f.buf[:4]) != recordIOMagic {
+		return 0, errRecordIOMagic
+	}
+	lrecord := binary.LittleEndian.Uint32(f.buf[4:])
+	if lrecord>>recordIOLengthBits != 0 {
+		return 0, errRecordIOSplit
+	}
+	f.size = int64(lrecord & recordIOMaxLength)
+	return f.size, nil
+}
+
+func (f *recordIOFraming) dataReader(r io.Reader) io.Reader {
+	return r
+}
+
+func (f *recordIOFraming) readFooter(r io.Reader) error {
+	_, err := io.ReadFull(r, f.buf[:recordIOPadding(f.size)])
+	return err
+}
+
+func (f *recordIOFraming) writeHeader(w io.Writer, size int64) error {
+	if size > recordIOMaxLength {
+		return fmt.Errorf("recordio: record of size %d exceeds max size %d", size, recordIOMaxLength)
+	}
+	f.size = size
+	binary.LittleEndian.PutUint32(f.buf[:4], recordIOMagic)
+	binary.LittleEndian.PutUint32(f.buf[4:], uint32(size))
+	_, err := w.Write(f.buf[:recordIOHeaderSize])
+	return err
+}
+
+func (f *recordIOFraming) dataWriter(w io.Writer) io.Writer {
+	return w
+}
+
+func (f *recordIOFraming) writeFooter(w io.Writer) error {
+	padding := f.buf[:recordIOPadding(f.size)]
+	for i := range padding {
+		padding[i] = 0
+	}
+	_, err := w.Write(padding)
+	return err
+}
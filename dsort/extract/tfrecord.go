@@ -0,0 +1,99 @@
+// Package extract provides provides functions for working with compressed files
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package extract
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+const (
+	tfRecordHeaderSize = 8 + 4 // length, masked crc of length
+	tfRecordFooterSize = 4     // masked crc of data
+	tfRecordMaskDelta  = 0xa282ead8
+)
+
+var (
+	crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+	errTFRecordLengthCRC = errors.New("tfrecord: length checksum mismatch")
+	errTFRecordDataCRC   = errors.New("tfrecord: data checksum mismatch")
+)
+
+// tfRecordFraming implements the TFRecord format, where each record is:
+//
+//	uint64 length
+//	uint32 masked crc32c of length
+//	byte   data[length]
+//	uint32 masked crc32c of data
+//
+// All integers are little-endian.
+type tfRecordFraming struct {
+	buf [tfRecordHeaderSize]byte
+	crc hash.Hash32
+}
+
+func newTFRecordFraming() recordFraming {
+	return &tfRecordFraming{crc: crc32.New(crc32cTable)}
+}
+
+// NewTFRecordExtractCreator returns creator of TFRecord shards. Every record
+// becomes a separate object so the records are shuffled independently.
+func NewTFRecordExtractCreator(ext string) ExtractCreator {
+	return &framedExtractCreator{
+		ext:        ext,
+		newFraming: newTFRecordFraming,
+	}
+}
+
+func maskCRC(crc uint32) uint32 {
+	return ((crc >> 15) | (crc << 17)) + tfRecordMaskDelta
+}
+
+func (f *tfRecordFraming) readHeader(r io.Reader) (int64, error) {
+	if _, err := io.ReadFull(r, f.buf[:tfRecordHeaderSize]); err != nil {
+		return 0, err
+	}
+	if maskCRC(crc32.Checksum(f.buf[:8], crc32cTable)) != binary.LittleEndian.Uint32(f.buf[8:]) {
+		return 0, errTFRecordLengthCRC
+	}
+	f.crc.Reset()
+	return int64(binary.LittleEndian.Uint64(f.buf[:8])), nil
+}
+
+func (f *tfRecordFraming) dataReader(r io.Reader) io.Reader {
+	return io.TeeReader(r, f.crc)
+}
+
+func (f *tfRecordFraming) readFooter(r io.Reader) error {
+	if _, err := io.ReadFull(r, f.buf[:tfRecordFooterSize]); err != nil {
+		return err
+	}
+	if maskCRC(f.crc.Sum32()) != binary.LittleEndian.Uint32(f.buf[:tfRecordFooterSize]) {
+		return errTFRecordDataCRC
+	}
+	return nil
+}
+
+func (f *tfRecordFraming) writeHeader(w io.Writer, size int64) error {
+	binary.LittleEndian.PutUint64(f.buf[:8], uint64(size))
+	binary.LittleEndian.PutUint32(f.buf[8:], maskCRC(crc32.Checksum(f.buf[:8], crc32cTable)))
+	f.crc.Reset()
+	_, err := w.Write(f.buf[:tfRecordHeaderSize])
+	return err
+}
+
+func (f *tfRecordFraming) dataWriter(w io.Writer) io.Writer {
+	return io.MultiWriter(w, f.crc)
+}
+
+func (f *tfRecordFraming) writeFooter(w io.Writer) error {
+	binary.LittleEndian.PutUint32(f.buf[:tfRecordFooterSize], maskCRC(f.crc.Sum32()))
+	_, err := w.Write(f.buf[:tfRecordFooterSize])
+	return err
+}
@@ -12,6 +12,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -49,6 +50,10 @@ func ProxySortHandler(w http.ResponseWriter, r *http.Request) {
 		proxyMetricsSortHandler(w, r)
 	case cmn.Abort:
 		proxyAbortSortHandler(w, r)
+	case cmn.List:
+		proxyListSortHandler(w, r)
+	case cmn.Remove:
+		proxyRemoveSortHandler(w, r)
 	default:
 		cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("invalid request %s", apiItems[0]))
 	}
@@ -69,6 +74,10 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 		cmn.InvalidHandlerWithMsg(w, r, err.Error())
 		return
 	}
+	if err := checkOutputBucket(parsedRS); err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error())
+		return
+	}
 	parsedRS.TargetOrderSalt = []byte(time.Now().Format("15:04:05.000000"))
 	b, err := js.Marshal(parsedRS)
 	if err != nil {
@@ -130,6 +139,16 @@ func proxyStartSortHandler(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(managerUUID))
 }
 
+// checkOutputBucket ensures that the local bucket to which output shards will
+// be written exists. Cloud buckets are not checked since they do not need to
+// be present in the bucket metadata.
+func checkOutputBucket(rs *ParsedRequestSpec) error {
+	if rs.OutputIsLocal && !ctx.bmdowner.Get().IsLocal(rs.OutputBucket) {
+		return fmt.Errorf("output bucket %q does not exist", rs.OutputBucket)
+	}
+	return nil
+}
+
 // GET /v1/sort/metrics
 func proxyMetricsSortHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -189,6 +208,86 @@ func proxyAbortSortHandler(w http.ResponseWriter, r *http.Request) {
 	broadcast(http.MethodDelete, path, nil, ctx.smap.Get().Tmap)
 }
 
+// GET /v1/sort/list
+func proxyListSortHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("invalid HTTP method: %s, must be GET", r.Method))
+		return
+	}
+	if _, err := checkRESTItems(w, r, 0, cmn.Version, cmn.Sort, cmn.List); err != nil {
+		return
+	}
+
+	path := cmn.URLPath(cmn.Version, cmn.Sort, cmn.List)
+	responses := broadcast(http.MethodGet, path, nil, ctx.smap.Get().Tmap)
+
+	jobs := make(map[string]*JobInfo)
+	for _, resp := range responses {
+		if resp.err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, resp.err.Error(), resp.statusCode)
+			return
+		}
+		var targetJobs []*JobInfo
+		if err := js.Unmarshal(resp.res, &targetJobs); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, job := range targetJobs {
+			if j, exists := jobs[job.ID]; exists {
+				j.aggregate(job)
+			} else {
+				jobs[job.ID] = job
+			}
+		}
+	}
+
+	list := make([]*JobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		list = append(list, job)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].StartedTime.Before(list[j].StartedTime) })
+
+	body, err := js.Marshal(list)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(body)
+}
+
+// DELETE /v1/sort/remove
+func proxyRemoveSortHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		s := fmt.Sprintf("invalid HTTP method: %s, must be DELETE", r.Method)
+		cmn.InvalidHandlerWithMsg(w, r, s)
+		return
+	}
+	apiItems, err := checkRESTItems(w, r, 1, cmn.Version, cmn.Sort, cmn.Remove)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	path := cmn.URLPath(cmn.Version, cmn.Sort, cmn.Remove, managerUUID)
+	responses := broadcast(http.MethodDelete, path, nil, ctx.smap.Get().Tmap)
+
+	found := false
+	for _, resp := range responses {
+		if resp.statusCode == http.StatusNotFound {
+			continue
+		}
+		if resp.err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, resp.err.Error(), resp.statusCode)
+			return
+		}
+		found = true
+	}
+	if !found {
+		s := fmt.Sprintf("invalid request: job %s does not exist", managerUUID)
+		cmn.InvalidHandlerWithMsg(w, r, s, http.StatusNotFound)
+	}
+}
+
 ///////////////////
 ///// TARGET //////
 ///////////////////
@@ -209,10 +308,16 @@ func SortHandler(w http.ResponseWriter, r *http.Request) {
 		recordsHandler(Managers)(w, r)
 	case cmn.Shards:
 		shardsHandler(Managers)(w, r)
+	case cmn.Release:
+		releaseHandler(Managers)(w, r)
 	case cmn.Abort:
 		abortSortHandler(w, r)
 	case cmn.Metrics:
 		metricsHandler(w, r)
+	case cmn.List:
+		listSortHandler(w, r)
+	case cmn.Remove:
+		removeSortHandler(w, r)
 	case cmn.FinishedAck:
 		finishedAckHandler(w, r)
 	default:
@@ -342,6 +447,42 @@ func shardsHandler(managers *ManagerGroup) http.HandlerFunc {
 	}
 }
 
+// releaseHandler is the handler for the HTTP endpoint /v1/sort/release.
+// A valid POST to this endpoint releases the contents (listed in the request body)
+// of the records which belong to the shards that have not been created since
+// they had been already created by the previous run of the resumed job.
+func releaseHandler(managers *ManagerGroup) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !checkInvalidMethod(w, r, http.MethodPost) {
+			return
+		}
+		apiItems, err := checkRESTItems(w, r, 1, cmn.Version, cmn.Sort, cmn.Release)
+		if err != nil {
+			return
+		}
+		managerUUID := apiItems[0]
+		dsortManager, exists := managers.Get(managerUUID)
+		if !exists {
+			s := fmt.Sprintf("invalid request: manager with uuid %s does not exist", managerUUID)
+			cmn.InvalidHandlerWithMsg(w, r, s, http.StatusNotFound)
+			return
+		}
+		if dsortManager.aborted() {
+			cmn.InvalidHandlerWithMsg(w, r, "dsort process was aborted")
+			return
+		}
+
+		var paths []string
+		if err := js.NewDecoder(r.Body).Decode(&paths); err != nil {
+			cmn.InvalidHandlerWithMsg(w, r, fmt.Sprintf("could not unmarshal request body, err: %v", err), http.StatusInternalServerError)
+			return
+		}
+		for _, path := range paths {
+			dsortManager.releaseContent(path)
+		}
+	}
+}
+
 // recordsHandler is the handler called for the HTTP endpoint /v1/sort/records.
 // A valid POST to this endpoint updates this target's dsortManager.Records with the
 // []Records from the request body, along with some related state variables.
@@ -463,6 +604,56 @@ func metricsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// listSortHandler is the handler called for the HTTP endpoint /v1/sort/list.
+// A valid GET to this endpoint returns information about all the jobs known to
+// this target.
+func listSortHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkInvalidMethod(w, r, http.MethodGet) {
+		return
+	}
+	if _, err := checkRESTItems(w, r, 0, cmn.Version, cmn.Sort, cmn.List); err != nil {
+		return
+	}
+
+	jobs, err := Managers.List(ctx.node.DaemonID)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	body, err := js.Marshal(jobs)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := w.Write(body); err != nil {
+		glog.Error(err)
+	}
+}
+
+// removeSortHandler is the handler called for the HTTP endpoint /v1/sort/remove.
+// A valid DELETE to this endpoint removes persisted metadata of finished job.
+func removeSortHandler(w http.ResponseWriter, r *http.Request) {
+	if !checkInvalidMethod(w, r, http.MethodDelete) {
+		return
+	}
+	apiItems, err := checkRESTItems(w, r, 1, cmn.Version, cmn.Sort, cmn.Remove)
+	if err != nil {
+		return
+	}
+
+	managerUUID := apiItems[0]
+	exists, err := Managers.Remove(managerUUID)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		s := fmt.Sprintf("invalid request: manager with uuid %s does not exist", managerUUID)
+		cmn.InvalidHandlerWithMsg(w, r, s, http.StatusNotFound)
+		return
+	}
+}
+
 // finishedAckHandler is the handler called for the HTTP endpoint /v1/sort/finished-ack.
 // A valid PUT to this endpoint acknowledges that daemonID has finished dSort operation.
 func finishedAckHandler(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,76 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"time"
+)
+
+const (
+	JobRunning  = "running"
+	JobFinished = "finished"
+	JobAborted  = "aborted"
+)
+
+// JobInfo describes the dSort job. When returned by the proxy, it aggregates
+// the information from all the targets.
+type JobInfo struct {
+	ID          string             `json:"id"`
+	RequestSpec *ParsedRequestSpec `json:"request_spec"`
+	Status      string             `json:"status"` // one of: JobRunning, JobFinished, JobAborted
+	StartedTime time.Time          `json:"started_time"`
+	FinishTime  time.Time          `json:"finish_time"` // zero when the job has not finished
+	// Archived specifies if the job has finished (or has been aborted) and
+	// cleaned up - only then its metadata can be removed.
+	Archived bool `json:"archived"`
+	// Metrics of the job on each target: daemonID => metrics.
+	Metrics map[string]*Metrics `json:"metrics"`
+}
+
+func newJobInfo(m *Manager, daemonID string, archived bool) *JobInfo {
+	m.Metrics.update()
+	j := &JobInfo{
+		ID:          m.ManagerUUID,
+		RequestSpec: m.RequestSpec,
+		Status:      JobRunning,
+		StartedTime: m.Metrics.Extraction.Start,
+		Archived:    archived,
+		Metrics:     map[string]*Metrics{daemonID: m.Metrics},
+	}
+	if m.Metrics.Aborted {
+		j.Status = JobAborted
+	} else if m.Metrics.Creation.Finished {
+		j.Status = JobFinished
+		j.FinishTime = m.Metrics.Creation.End
+	}
+	return j
+}
+
+// aggregate merges the information about the same job from other target.
+func (j *JobInfo) aggregate(other *JobInfo) {
+	for daemonID, metrics := range other.Metrics {
+		j.Metrics[daemonID] = metrics
+	}
+	if j.RequestSpec == nil {
+		j.RequestSpec = other.RequestSpec
+	}
+	if j.StartedTime.IsZero() || (!other.StartedTime.IsZero() && other.StartedTime.Before(j.StartedTime)) {
+		j.StartedTime = other.StartedTime
+	}
+	if other.FinishTime.After(j.FinishTime) {
+		j.FinishTime = other.FinishTime
+	}
+	j.Archived = j.Archived && other.Archived
+
+	// The job is aborted when aborted on any target, finished when finished on all.
+	if j.Status == JobAborted || other.Status == JobAborted {
+		j.Status = JobAborted
+	} else if j.Status == JobRunning || other.Status == JobRunning {
+		j.Status = JobRunning
+	}
+	if j.Status != JobFinished {
+		j.FinishTime = time.Time{}
+	}
+}
@@ -0,0 +1,54 @@
+// Package dsort provides APIs for distributed archive file shuffling.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package dsort
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JobInfo", func() {
+	now := time.Now()
+
+	newJob := func(daemonID, status string, started, finished time.Time) *JobInfo {
+		return &JobInfo{
+			ID:          "uuid",
+			Status:      status,
+			StartedTime: started,
+			FinishTime:  finished,
+			Archived:    status != JobRunning,
+			Metrics:     map[string]*Metrics{daemonID: newMetrics(false)},
+		}
+	}
+
+	It("should aggregate finished jobs", func() {
+		j := newJob("t1", JobFinished, now, now.Add(time.Minute))
+		j.aggregate(newJob("t2", JobFinished, now.Add(-time.Second), now.Add(2*time.Minute)))
+		Expect(j.Status).To(Equal(JobFinished))
+		Expect(j.StartedTime).To(Equal(now.Add(-time.Second)))
+		Expect(j.FinishTime).To(Equal(now.Add(2 * time.Minute)))
+		Expect(j.Archived).To(BeTrue())
+		Expect(j.Metrics).To(HaveLen(2))
+	})
+
+	It("should be running when running on any target", func() {
+		j := newJob("t1", JobFinished, now, now.Add(time.Minute))
+		j.aggregate(newJob("t2", JobRunning, now, time.Time{}))
+		Expect(j.Status).To(Equal(JobRunning))
+		Expect(j.FinishTime.IsZero()).To(BeTrue())
+		Expect(j.Archived).To(BeFalse())
+	})
+
+	It("should be aborted when aborted on any target", func() {
+		j := newJob("t1", JobRunning, now, time.Time{})
+		j.aggregate(newJob("t2", JobAborted, now, time.Time{}))
+		j.aggregate(newJob("t3", JobFinished, now, now.Add(time.Minute)))
+		Expect(j.Status).To(Equal(JobAborted))
+		Expect(j.FinishTime.IsZero()).To(BeTrue())
+		Expect(j.Metrics).To(HaveLen(3))
+	})
+})
@@ -61,6 +61,7 @@ var (
 
 type dsortContext struct {
 	smap       cluster.Sowner
+	bmdowner   cluster.Bowner
 	node       *cluster.Snode
 	t          cluster.Target
 	nameLocker cluster.NameLocker
@@ -89,8 +90,9 @@ type streamWriter struct {
 type Manager struct {
 	// Fields with json tags are the only fields which are persisted
 	// into the disk once the dSort is finished.
-	ManagerUUID string   `json:"manager_uuid"`
-	Metrics     *Metrics `json:"metrics"`
+	ManagerUUID string             `json:"manager_uuid"`
+	Metrics     *Metrics           `json:"metrics"`
+	RequestSpec *ParsedRequestSpec `json:"request_spec"` // same as rs, persisted to list the jobs
 
 	mu   sync.Mutex
 	ctx  dsortContext
@@ -101,6 +103,7 @@ type Manager struct {
 	extractCreator     extract.ExtractCreator
 	startShardCreation chan struct{}
 	rs                 *ParsedRequestSpec
+	checkpoint         *checkpoint
 
 	client        *http.Client
 	fileExtension string
@@ -128,6 +131,7 @@ type Manager struct {
 		request  map[string]*StreamPool
 		response map[string]*StreamPool
 		shards   map[string]*StreamPool // streams for pushing streams to other targets if the fqn is non-local
+		limiter  *transport.RateLimiter // bandwidth limit of all the streams combined (see cmn.TransportConf)
 	}
 	streamWriters struct {
 		mu      sync.Mutex
@@ -141,8 +145,9 @@ type Manager struct {
 	callTimeout time.Duration // Maximal time we will wait for other node to respond
 }
 
-func RegisterNode(smap cluster.Sowner, snode *cluster.Snode, t cluster.Target, nameLocker cluster.NameLocker) {
+func RegisterNode(smap cluster.Sowner, bmdowner cluster.Bowner, snode *cluster.Snode, t cluster.Target, nameLocker cluster.NameLocker) {
 	ctx.smap = smap
+	ctx.bmdowner = bmdowner
 	ctx.node = snode
 	ctx.t = t
 	ctx.nameLocker = nameLocker
@@ -163,15 +168,25 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 	targetCount := m.smap.CountTargets()
 
 	m.rs = rs
+	m.RequestSpec = rs
 	m.Metrics = newMetrics(rs.ExtendedMetrics)
 	m.startShardCreation = make(chan struct{}, 1)
 
 	// Set extract creator depending on extension provided by the user
 	m.setExtractCreator()
 
+	// Checkpoint is not crucial for the job to succeed - it only allows to
+	// resume the job in case of failure.
+	checkpoint, err := newCheckpoint(rs)
+	if err != nil {
+		glog.Errorf("failed to open checkpoint, dsort %s will not be resumable, err: %v", m.ManagerUUID, err)
+	}
+	m.checkpoint = checkpoint
+
 	m.client = cmn.NewClient(cmn.ClientArgs{
 		DialTimeout: 5 * time.Minute,
 		Timeout:     30 * time.Minute,
+		TLSConfig:   cmn.IntraClusterTLS(),
 	})
 
 	m.fileExtension = rs.Extension
@@ -245,31 +260,50 @@ func (m *Manager) initStreams() error {
 	}
 
 	trname = fmt.Sprintf("dsort-%s-shard", m.ManagerUUID)
-	shardPath, err := transport.Register(respNetwork, trname, m.makeRecvShardFunc())
+	// Shards are sent together with their checksums, verify them on the fly.
+	shardPath, err := transport.Register(respNetwork, trname, m.makeRecvShardFunc(), true /*verifyCksum*/)
 	if err != nil {
 		return err
 	}
 
+	m.streams.limiter = transport.NewRateLimiter(config.Transport.MaxBundleBandwidth)
 	for _, si := range ctx.smap.Get().Tmap {
 		m.streams.request[si.DaemonID] = NewStreamPool(2)
 		m.streams.response[si.DaemonID] = NewStreamPool(transport.IntraBundleMultiplier)
 		m.streams.shards[si.DaemonID] = NewStreamPool(transport.IntraBundleMultiplier)
 		for i := 0; i < transport.IntraBundleMultiplier; i++ {
 			url := si.IntraControlNet.DirectURL + reqPath
-			m.streams.request[si.DaemonID].Add(NewStream(url))
+			m.streams.request[si.DaemonID].Add(NewStream(url, m.streams.limiter))
 
 			url = si.IntraDataNet.DirectURL + respPath
-			m.streams.response[si.DaemonID].Add(NewStream(url))
+			m.streams.response[si.DaemonID].Add(NewStream(url, m.streams.limiter))
 
 			url = si.IntraDataNet.DirectURL + shardPath
-			m.streams.shards[si.DaemonID].Add(NewStream(url))
+			m.streams.shards[si.DaemonID].Add(NewStream(url, m.streams.limiter))
 		}
 	}
 
+	// Bandwidth limits can be changed while the job is running.
+	cmn.GCO.Subscribe(m)
 	return nil
 }
 
+// ConfigUpdate adjusts the bandwidth limits of the streams.
+func (m *Manager) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if oldConf.Transport == newConf.Transport {
+		return
+	}
+	for _, streamPoolArr := range []map[string]*StreamPool{m.streams.request, m.streams.response, m.streams.shards} {
+		for _, streamPool := range streamPoolArr {
+			streamPool.SetMaxBandwidth(newConf.Transport.MaxStreamBandwidth)
+		}
+	}
+	m.streams.limiter.SetRate(newConf.Transport.MaxBundleBandwidth)
+}
+
 func (m *Manager) cleanupStreams() error {
+	cmn.GCO.Unsubscribe(m)
+
 	config := cmn.GCO.Get()
 	reqNetwork := cmn.NetworkIntraControl
 	if !config.Net.UseIntraControl {
@@ -342,6 +376,7 @@ func (m *Manager) cleanup() {
 
 	m.shardManager.Cleanup()
 	m.extractCreator = nil
+	m.checkpoint.close()
 	m.client = nil
 
 	m.ctx.smap.Listeners().Unreg(m)
@@ -375,6 +410,11 @@ func (m *Manager) finalCleanup() {
 	m.recManager.Cleanup()
 	extract.FreeMemory()
 
+	// Once all the targets have finished, the job will never be resumed.
+	if !m.aborted() {
+		m.checkpoint.remove()
+	}
+
 	m.finishedAck.m = nil
 	Managers.persist(m.ManagerUUID)
 }
@@ -401,7 +441,9 @@ func (m *Manager) setExtractCreator() (err error) {
 	var keyExtractor extract.KeyExtractor
 	switch m.rs.Algorithm.Kind {
 	case SortKindContent:
-		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension)
+		keyExtractor, err = extract.NewContentKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Extension, m.rs.Algorithm.JSONPath)
+	case SortKindRegex:
+		keyExtractor, err = extract.NewRegexKeyExtractor(m.rs.Algorithm.FormatType, m.rs.Algorithm.Regex)
 	case SortKindMD5:
 		keyExtractor, err = extract.NewMD5KeyExtractor()
 	default:
@@ -420,6 +462,10 @@ func (m *Manager) setExtractCreator() (err error) {
 		m.extractCreator = extract.NewTarExtractCreator(m.rs.Extension != extTar)
 	case extZip:
 		m.extractCreator = extract.NewZipExtractCreator()
+	case extTFRecord:
+		m.extractCreator = extract.NewTFRecordExtractCreator(m.rs.Extension)
+	case extRecordIO:
+		m.extractCreator = extract.NewRecordIOExtractCreator(m.rs.Extension)
 	default:
 		cmn.AssertMsg(false, fmt.Sprintf("unknown extension %s", m.rs.Extension))
 	}
@@ -876,6 +922,47 @@ func (m *Manager) loadContent() extract.LoadContentFunc {
 	}
 }
 
+// releaseShard releases contents of all records of the shard which will not be
+// created (see: checkpoint), the same way as if the contents were loaded, so
+// the targets which keep the contents can eventually clean up.
+func (m *Manager) releaseShard(s *extract.Shard) error {
+	remote := make(map[string][]string)
+	for _, rec := range s.Records.All() {
+		for _, obj := range rec.Objects {
+			if rec.DaemonID != m.ctx.node.DaemonID {
+				remote[rec.DaemonID] = append(remote[rec.DaemonID], rec.FullContentPath(obj))
+				continue
+			}
+			m.releaseContent(rec.FullContentPath(obj))
+		}
+	}
+
+	for daemonID, paths := range remote {
+		body, err := js.Marshal(paths)
+		if err != nil {
+			return err
+		}
+		toNode := m.smap.GetTarget(daemonID)
+		if toNode == nil {
+			return fmt.Errorf("target %q does not exist", daemonID)
+		}
+		u := toNode.URL(cmn.NetworkIntraData) + cmn.URLPath(cmn.Version, cmn.Sort, cmn.Release, m.ManagerUUID)
+		if _, err := m.doWithAbort(http.MethodPost, u, body, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseContent frees the content of a record object which will not be loaded.
+func (m *Manager) releaseContent(pathToContent string) {
+	if v, ok := m.recManager.RecordContents().Load(pathToContent); ok {
+		v.(*memsys.SGL).Free()
+		m.recManager.RecordContents().Delete(pathToContent)
+	}
+	m.decrementRef(1)
+}
+
 // doWithAbort sends requests through client. If manager aborts during the call
 // request is cancelled.
 func (m *Manager) doWithAbort(method, u string, body []byte, w io.Writer) (int64, error) {
@@ -939,6 +1026,7 @@ func (m *Manager) SmapChanged() {
 	// check if some target has been removed - abort in case it does
 	for sid := range m.smap.Tmap {
 		if newSmap.GetTarget(sid) == nil {
+			glog.Warningf("target %s has been removed, aborting dsort %s (it can be resumed with the same request)", sid, m.ManagerUUID)
 			go m.abort() // FIXME: once the smap notification logic will change we could remove `go`
 			return
 		}
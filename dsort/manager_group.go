@@ -86,6 +86,125 @@ func (mg *ManagerGroup) Get(managerUUID string, ap ...bool) (*Manager, bool) {
 	return manager, exists
 }
 
+// List returns information about all the jobs known to this target: the ones
+// which are still in memory (running or being cleaned up) as well as the ones
+// which have been persisted.
+func (mg *ManagerGroup) List(daemonID string) ([]*JobInfo, error) {
+	mg.mtx.Lock()
+	defer mg.mtx.Unlock()
+
+	jobs := make([]*JobInfo, 0, len(mg.managers))
+	for _, manager := range mg.managers {
+		if manager.Metrics == nil { // not yet initialized
+			continue
+		}
+		jobs = append(jobs, newJobInfo(manager, daemonID, false /*archived*/))
+	}
+
+	config := cmn.GCO.Get()
+	db, err := scribble.New(filepath.Join(config.Confdir, persistManagersPath), nil)
+	if err != nil {
+		return nil, err
+	}
+	records, err := db.ReadAll(managersCollection)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return jobs, nil
+		}
+		return nil, err
+	}
+	for _, record := range records {
+		manager := &Manager{}
+		if err := js.UnmarshalFromString(record, manager); err != nil {
+			glog.Error(err)
+			continue
+		}
+		if _, exists := mg.managers[manager.ManagerUUID]; exists || manager.Metrics == nil {
+			continue
+		}
+		jobs = append(jobs, newJobInfo(manager, daemonID, true /*archived*/))
+	}
+	return jobs, nil
+}
+
+// Abort aborts the running job with given managerUUID or, if managerUUID is
+// empty, all running jobs (of a given bucket, if specified). Returns the number
+// of aborted jobs.
+func (mg *ManagerGroup) Abort(managerUUID, bucket string) int {
+	mg.mtx.Lock()
+	managers := make([]*Manager, 0, len(mg.managers))
+	for uuid, manager := range mg.managers {
+		if managerUUID != "" && uuid != managerUUID {
+			continue
+		}
+		if manager.rs == nil || manager.aborted() || !manager.inProgress() {
+			continue
+		}
+		if bucket != "" && manager.rs.Bucket != bucket {
+			continue
+		}
+		managers = append(managers, manager)
+	}
+	mg.mtx.Unlock()
+
+	// abort waits for the job to finish - outside of the lock
+	for _, manager := range managers {
+		manager.abort()
+	}
+	return len(managers)
+}
+
+// Remove removes the persisted manager with given managerUUID. Only finished
+// (or aborted) jobs which have already been persisted can be removed. Returns
+// false if does not exist, true otherwise.
+func (mg *ManagerGroup) Remove(managerUUID string) (bool, error) {
+	mg.mtx.Lock()
+	defer mg.mtx.Unlock()
+	if _, exists := mg.managers[managerUUID]; exists {
+		return true, fmt.Errorf("manager with given uuid %s is still in progress", managerUUID)
+	}
+
+	config := cmn.GCO.Get()
+	db, err := scribble.New(filepath.Join(config.Confdir, persistManagersPath), nil)
+	if err != nil {
+		return false, err
+	}
+	var manager *Manager
+	if err := db.Read(managersCollection, managerUUID, &manager); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := db.Delete(managersCollection, managerUUID); err != nil {
+		return true, err
+	}
+	// The job (if aborted) will not be resumed anymore - unless it is being
+	// resumed right now.
+	if rs := manager.RequestSpec; rs != nil && !mg.resuming(rs) {
+		removeCheckpoint(rs)
+	}
+	return true, nil
+}
+
+// resuming checks if any of the jobs in progress uses the checkpoint of the
+// job described by rs; must be called under lock.
+func (mg *ManagerGroup) resuming(rs *ParsedRequestSpec) bool {
+	fingerprint, err := jobFingerprint(rs)
+	if err != nil {
+		return true // to be on the safe side
+	}
+	for _, manager := range mg.managers {
+		if manager.RequestSpec == nil {
+			continue
+		}
+		if fp, err := jobFingerprint(manager.RequestSpec); err == nil && fp == fingerprint {
+			return true
+		}
+	}
+	return false
+}
+
 // persist removes manager from manager group (memory) and moves all information
 // about it to persistent storage (file). This operation allows for later access
 // of old managers (including managers' metrics).
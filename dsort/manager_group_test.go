@@ -7,7 +7,11 @@
 package dsort
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
@@ -72,6 +76,17 @@ var _ = Describe("ManagerGroup", func() {
 		})
 	})
 
+	Context("abort", func() {
+		It("should not abort jobs which are not running", func() {
+			m, err := mgrp.Add("uuid")
+			m.unlock()
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(mgrp.Abort("uuid", "")).To(Equal(0))
+			Expect(mgrp.Abort("", "")).To(Equal(0))
+			Expect(m.aborted()).To(BeFalse())
+		})
+	})
+
 	Context("persist", func() {
 		ctx.smap = newTestSmap("target")
 		ctx.node = ctx.smap.Get().Tmap["target"]
@@ -106,6 +121,105 @@ var _ = Describe("ManagerGroup", func() {
 		})
 	})
 
+	Context("list", func() {
+		ctx.smap = newTestSmap("target")
+		ctx.node = ctx.smap.Get().Tmap["target"]
+
+		It("should list both in-memory and persisted jobs", func() {
+			for _, uuid := range []string{"uuid1", "uuid2"} {
+				m, err := mgrp.Add(uuid)
+				Expect(err).ShouldNot(HaveOccurred())
+				rs := &ParsedRequestSpec{Extension: extTar, Algorithm: &SortAlgorithm{Kind: SortKindNone}}
+				m.init(rs)
+				m.unlock()
+			}
+			m, _ := mgrp.Get("uuid1")
+			m.setInProgressTo(false)
+			mgrp.persist("uuid1")
+
+			jobs, err := mgrp.List("target")
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(jobs).To(HaveLen(2))
+			for _, job := range jobs {
+				Expect(job.RequestSpec).ToNot(BeNil())
+				Expect(job.Metrics).To(HaveKey("target"))
+				Expect(job.Archived).To(Equal(job.ID == "uuid1"))
+			}
+		})
+	})
+
+	Context("remove", func() {
+		ctx.smap = newTestSmap("target")
+		ctx.node = ctx.smap.Get().Tmap["target"]
+
+		It("should remove only persisted jobs", func() {
+			m, err := mgrp.Add("uuid")
+			Expect(err).ShouldNot(HaveOccurred())
+			rs := &ParsedRequestSpec{Extension: extTar, Algorithm: &SortAlgorithm{Kind: SortKindNone}}
+			m.init(rs)
+			m.unlock()
+
+			exists, err := mgrp.Remove("uuid")
+			Expect(exists).To(BeTrue())
+			Expect(err).Should(HaveOccurred())
+
+			m.setInProgressTo(false)
+			mgrp.persist("uuid")
+			exists, err = mgrp.Remove("uuid")
+			Expect(exists).To(BeTrue())
+			Expect(err).ShouldNot(HaveOccurred())
+
+			_, exists = mgrp.Get("uuid", true /*allowPersisted*/)
+			Expect(exists).To(BeFalse())
+			exists, err = mgrp.Remove("uuid")
+			Expect(exists).To(BeFalse())
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should remove the checkpoint of removed job", func() {
+			m, err := mgrp.Add("uuid")
+			Expect(err).ShouldNot(HaveOccurred())
+			rs := &ParsedRequestSpec{Extension: extTar, Algorithm: &SortAlgorithm{Kind: SortKindNone}}
+			m.init(rs)
+			m.unlock()
+			m.checkpoint.close()
+			path, err := checkpointPath(rs)
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = os.Stat(path)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			m.setInProgressTo(false)
+			mgrp.persist("uuid")
+			exists, err := mgrp.Remove("uuid")
+			Expect(exists).To(BeTrue())
+			Expect(err).ShouldNot(HaveOccurred())
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should not report the job as missing when it cannot be read", func() {
+			dir := filepath.Join(testingConfigDir, persistManagersPath, managersCollection)
+			err := os.MkdirAll(dir, 0750)
+			Expect(err).ShouldNot(HaveOccurred())
+			err = ioutil.WriteFile(filepath.Join(dir, "uuid.json"), []byte("{corrupted"), 0640)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			exists, err := mgrp.Remove("uuid")
+			Expect(exists).To(BeFalse())
+			Expect(err).Should(HaveOccurred())
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodDelete, cmn.URLPath(cmn.Version, cmn.Sort, cmn.Remove, "uuid"), nil)
+			removeSortHandler(w, r)
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+
+			w = httptest.NewRecorder()
+			r = httptest.NewRequest(http.MethodDelete, cmn.URLPath(cmn.Version, cmn.Sort, cmn.Remove, "nonexisting"), nil)
+			removeSortHandler(w, r)
+			Expect(w.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
 	AfterEach(func() {
 		err := os.RemoveAll(testingConfigDir)
 		Expect(err).ShouldNot(HaveOccurred())
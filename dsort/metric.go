@@ -114,6 +114,10 @@ type ShardCreation struct {
 	// CreatedCnt specifies number of shards that have been created to given
 	// moment. Should match ToCreate when phase is finished.
 	CreatedCnt int `json:"created_count"`
+	// SkippedCnt specifies number of shards which have not been created since
+	// they had been already created by the previous run of the resumed job.
+	// Skipped shards are included in CreatedCnt.
+	SkippedCnt int `json:"skipped_count"`
 	// MovedShardCnt describes number of shards that have been moved from this
 	// target to some other. This only applies when dealing with compressed
 	// data. Sometimes is faster to create shard on specific target and send it
@@ -26,6 +26,10 @@ const (
 	extTarTgz = ".tar.gz"
 	// extZip is zip files extension
 	extZip = ".zip"
+	// extTFRecord is TFRecord files extension
+	extTFRecord = ".tfrecord"
+	// extRecordIO is RecordIO files extension
+	extRecordIO = ".rec"
 
 	templBash = "bash"
 	templAt   = "@"
@@ -39,7 +43,7 @@ const (
 
 var (
 	errMissingBucket            = errors.New("missing field 'bucket'")
-	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', or '.tgz'")
+	errInvalidExtension         = errors.New("extension must be one of '.tar', '.tar.gz', '.tgz', '.zip', '.tfrecord', or '.rec'")
 	errNegOutputShardSize       = errors.New("output shard size must be > 0")
 	errNegativeConcurrencyLimit = fmt.Errorf("concurrency limit must be 0 (default: %d) or > 0", defaultConcLimit)
 
@@ -60,11 +64,12 @@ var (
 	errInvalidAlgorithmKind      = fmt.Errorf("invalid algorithm kind, should be one of: %+v", supportedAlgorithms)
 	errInvalidSeed               = errors.New("invalid seed provided, should be int")
 	errInvalidAlgorithmExtension = errors.New("invalid extension provided, should be in format: .ext")
+	errInvalidAlgorithmJSONPath  = errors.New("invalid JSON path provided, should be in format: field.0.subfield")
 )
 
 var (
 	// supportedExtensions is a list of supported extensions by dsort
-	supportedExtensions = []string{extTar, extTgz, extTarTgz, extZip}
+	supportedExtensions = []string{extTar, extTgz, extTarTgz, extZip, extTFRecord, extRecordIO}
 )
 
 // TODO: maybe this struct should be composed of `type` and `template` where
@@ -119,9 +124,12 @@ type RequestSpec struct {
 	Algorithm          SortAlgorithm `json:"algorithm"`                 // Default: alphanumeric, increasing
 	MaxMemUsage        string        `json:"max_mem_usage"`             // Default: "80%"
 	IsLocalBucket      bool          `json:"local"`                     // Default: false
+	OutputBucket       string        `json:"output_bucket"`             // Default: same as `bucket`
+	OutputIsLocal      *bool         `json:"output_local"`              // Default: same as `local`
 	ExtractConcLimit   int           `json:"extract_concurrency_limit"` // Default: DefaultConcLimit
 	CreateConcLimit    int           `json:"create_concurrency_limit"`  // Default: DefaultConcLimit
 	ExtendedMetrics    bool          `json:"extended_metrics"`          // Default: false
+	Resume             bool          `json:"resume"`                    // Default: false
 }
 
 type ParsedRequestSpec struct {
@@ -134,23 +142,32 @@ type ParsedRequestSpec struct {
 	Algorithm          *SortAlgorithm        `json:"algorithm"`
 	MaxMemUsage        *parsedMemUsage       `json:"max_mem_usage"`
 	IsLocalBucket      bool                  `json:"local"`
+	OutputBucket       string                `json:"output_bucket"`
+	OutputIsLocal      bool                  `json:"output_local"`
 	TargetOrderSalt    []byte                `json:"target_order_salt"`
 	ExtractConcLimit   int                   `json:"extract_concurrency_limit"`
 	CreateConcLimit    int                   `json:"create_concurrency_limit"`
 	ExtendedMetrics    bool                  `json:"extended_metrics"`
+	Resume             bool                  `json:"resume"`
 }
 
 type SortAlgorithm struct {
 	Kind string `json:"kind"`
 
-	// Kind: alphanumeric, content
+	// Kind: alphanumeric, content, regex
 	Decreasing bool `json:"decreasing"`
 
 	// Kind: shuffle
 	Seed string `json:"seed"` // seed provided to random generator
 
 	// Kind: content
-	Extension  string `json:"extension"`
+	Extension string `json:"extension"`
+	JSONPath  string `json:"json_path"` // path to the key in JSON content, eg. "labels.0.id"
+
+	// Kind: regex
+	Regex string `json:"regex"` // first capture group is the key, eg. "speaker_([0-9]+)_"
+
+	// Kind: content, regex
 	FormatType string `json:"format_type"`
 }
 
@@ -164,6 +181,13 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.Bucket = rs.Bucket
 
 	parsedRS.IsLocalBucket = rs.IsLocalBucket
+	parsedRS.OutputBucket, parsedRS.OutputIsLocal = rs.OutputBucket, rs.IsLocalBucket
+	if rs.OutputBucket == "" {
+		parsedRS.OutputBucket = rs.Bucket
+	}
+	if rs.OutputIsLocal != nil {
+		parsedRS.OutputIsLocal = *rs.OutputIsLocal
+	}
 
 	var err error
 
@@ -219,6 +243,7 @@ func (rs *RequestSpec) Parse() (*ParsedRequestSpec, error) {
 	parsedRS.ExtractConcLimit = rs.ExtractConcLimit
 	parsedRS.CreateConcLimit = rs.CreateConcLimit
 	parsedRS.ExtendedMetrics = rs.ExtendedMetrics
+	parsedRS.Resume = rs.Resume
 	return parsedRS, nil
 }
 
@@ -409,6 +434,19 @@ func parseAlgorithm(algo SortAlgorithm) (parsedAlgo *SortAlgorithm, err error) {
 			return nil, errInvalidAlgorithmExtension
 		}
 
+		algo.JSONPath = strings.TrimSpace(algo.JSONPath)
+		if algo.JSONPath != "" && cmn.StringInSlice("", strings.Split(algo.JSONPath, ".")) {
+			return nil, errInvalidAlgorithmJSONPath
+		}
+
+		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
+			return nil, err
+		}
+	} else if algo.Kind == SortKindRegex {
+		if _, err := extract.ValidateAlgorithmRegex(algo.Regex); err != nil {
+			return nil, err
+		}
+
 		if err := extract.ValidateAlgorithmFormatType(algo.FormatType); err != nil {
 			return nil, err
 		}
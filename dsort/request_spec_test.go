@@ -7,6 +7,7 @@
 package dsort
 
 import (
+	"github.com/NVIDIA/aistore/dsort/extract"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -29,6 +30,8 @@ var _ = Describe("RequestSpec", func() {
 
 			Expect(parsed.Bucket).To(Equal("test"))
 			Expect(parsed.IsLocalBucket).To(Equal(true))
+			Expect(parsed.OutputBucket).To(Equal("test"))
+			Expect(parsed.OutputIsLocal).To(Equal(true))
 			Expect(parsed.Extension).To(Equal(extTar))
 
 			Expect(parsed.InputFormat.Type).To(Equal(templBash))
@@ -116,6 +119,93 @@ var _ = Describe("RequestSpec", func() {
 			Expect(parsed.Extension).To(Equal(extZip))
 		})
 
+		It("should parse spec with output bucket", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				IsLocalBucket:   true,
+				OutputBucket:    "testing",
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Bucket).To(Equal("test"))
+			Expect(parsed.IsLocalBucket).To(Equal(true))
+			Expect(parsed.OutputBucket).To(Equal("testing"))
+			Expect(parsed.OutputIsLocal).To(Equal(true))
+		})
+
+		It("should parse spec with cloud output bucket", func() {
+			outputIsLocal := false
+			rs := RequestSpec{
+				Bucket:          "test",
+				IsLocalBucket:   true,
+				OutputBucket:    "testing",
+				OutputIsLocal:   &outputIsLocal,
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.IsLocalBucket).To(Equal(true))
+			Expect(parsed.OutputBucket).To(Equal("testing"))
+			Expect(parsed.OutputIsLocal).To(Equal(false))
+		})
+
+		It("should parse spec with .tfrecord extension", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       extTFRecord,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindNone},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Extension).To(Equal(extTFRecord))
+		})
+
+		It("should parse spec with content algorithm and JSON path", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindContent, Extension: ".json", JSONPath: " labels.0.id ", FormatType: extract.FormatTypeInt},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Algorithm.JSONPath).To(Equal("labels.0.id"))
+		})
+
+		It("should parse spec with regex algorithm", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindRegex, Regex: "speaker_([0-9]+)_", FormatType: extract.FormatTypeInt},
+			}
+			parsed, err := rs.Parse()
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(parsed.Algorithm.Kind).To(Equal(SortKindRegex))
+			Expect(parsed.Algorithm.FormatType).To(Equal(extract.FormatTypeInt))
+		})
+
 		It("should parse spec with @ syntax", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
@@ -229,6 +319,33 @@ var _ = Describe("RequestSpec", func() {
 			Expect(err).To(Equal(errInvalidExtension))
 		})
 
+		It("should fail due to invalid JSON path", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindContent, Extension: ".json", JSONPath: "labels..id", FormatType: extract.FormatTypeInt},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+			Expect(err).To(Equal(errInvalidAlgorithm))
+		})
+
+		It("should fail due to regex without capture group", func() {
+			rs := RequestSpec{
+				Bucket:          "test",
+				Extension:       extTar,
+				IntputFormat:    "prefix-{0010..0111}-suffix",
+				OutputFormat:    "prefix-{0010..0111}-suffix",
+				OutputShardSize: 100000,
+				Algorithm:       SortAlgorithm{Kind: SortKindRegex, Regex: "speaker_[0-9]+_", FormatType: extract.FormatTypeInt},
+			}
+			_, err := rs.Parse()
+			Expect(err).Should(HaveOccurred())
+		})
+
 		It("should fail due to invalid mem usage specification", func() {
 			rs := RequestSpec{
 				Bucket:          "test",
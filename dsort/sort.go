@@ -19,10 +19,11 @@ const (
 	SortKindMD5     = "md5"
 	SortKindShuffle = "shuffle" // shuffle randomly, can be used with seed to get reproducible results
 	SortKindContent = "content" // sort by content of given file
+	SortKindRegex   = "regex"   // sort by capture group of regex matched against record name
 )
 
 var (
-	supportedAlgorithms = []string{sortKindEmpty, SortKindMD5, SortKindShuffle, SortKindContent, SortKindNone}
+	supportedAlgorithms = []string{sortKindEmpty, SortKindMD5, SortKindShuffle, SortKindContent, SortKindRegex, SortKindNone}
 )
 
 type (
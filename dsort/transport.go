@@ -10,6 +10,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/transport"
 )
 
@@ -34,15 +35,25 @@ func (sp *StreamPool) Add(s *transport.Stream) {
 	sp.Streams = append(sp.Streams, s)
 }
 
+func (sp *StreamPool) SetMaxBandwidth(bytesPerSec int64) {
+	for _, stream := range sp.Streams {
+		stream.SetMaxBandwidth(bytesPerSec)
+	}
+}
+
 func (sp *StreamPool) Stop() {
 	for _, stream := range sp.Streams {
 		stream.Fin()
 	}
 }
 
-func NewStream(url string) *transport.Stream {
+// NewStream creates the stream limited by the configured per stream bandwidth
+// and, additionally, by the limiter shared with the other streams of the job.
+func NewStream(url string, limiter *transport.RateLimiter) *transport.Stream {
 	extra := &transport.Extra{
-		IdleTimeout: time.Second * 30,
+		IdleTimeout:  time.Second * 30,
+		MaxBandwidth: cmn.GCO.Get().Transport.MaxStreamBandwidth,
+		Limiter:      limiter,
 	}
 	client := transport.NewDefaultClient()
 	return transport.NewStream(client, url, extra)
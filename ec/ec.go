@@ -16,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tracing"
 	jsoniter "github.com/json-iterator/go"
 )
 
@@ -150,20 +151,22 @@ const (
 type (
 	// Metadata - EC information stored in metafiles for every encoded object
 	Metadata struct {
-		Size     int64  `json:"size"`              // size of original file (after EC'ing the total size of slices differs from original)
-		Data     int    `json:"data"`              // the number of data slices
-		Parity   int    `json:"parity"`            // the number of parity slices
-		SliceID  int    `json:"sliceid,omitempty"` // 0 for full replica, 1 to N for slices
-		Checksum string `json:"chk"`               // checksum of the original object
-		IsCopy   bool   `json:"copy"`              // object is replicated(true) or encoded(false)
+		Size     int64  `json:"size"`               // size of original file (after EC'ing the total size of slices differs from original)
+		Data     int    `json:"data"`               // the number of data slices
+		Parity   int    `json:"parity"`             // the number of parity slices
+		SliceID  int    `json:"sliceid,omitempty"`  // 0 for full replica, 1 to N for slices
+		Checksum string `json:"chk"`                // checksum of the original object
+		IsCopy   bool   `json:"copy"`               // object is replicated(true) or encoded(false)
+		UserMeta string `json:"usermeta,omitempty"` // user-defined metadata of the original object (see cmn.EncodeUserMeta)
 	}
 
 	// request - structure to request an object to be EC'ed or restored
 	Request struct {
-		LOM    *cluster.LOM // object info
-		Action string       // what to do with the object (see Act* consts)
-		ErrCh  chan error   // for final EC result
-		IsCopy bool         // replicate or use erasure coding
+		LOM    *cluster.LOM  // object info
+		Action string        // what to do with the object (see Act* consts)
+		ErrCh  chan error    // for final EC result
+		IsCopy bool          // replicate or use erasure coding
+		Span   *tracing.Span // optional: the span of the traced request (restore only)
 
 		// private properties
 		putTime time.Time // time when the object is put into main queue
@@ -188,6 +191,9 @@ type (
 		Exists bool `json:"exists"`
 		// the sent data is slice or full replica
 		IsSlice bool `json:"slice,omitempty"`
+		// trace and parent span IDs of the restore request that is traced (see tracing)
+		TraceID string `json:"trc,omitempty"`
+		SpanID  string `json:"spn,omitempty"`
 	}
 
 	// keeps temporarily a slice of object data until it is sent to remote node
@@ -188,7 +188,7 @@ func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, no
 	// try read a replica from targets one by one until the replica is got
 	for node := range nodes {
 		uname := unique(node, req.LOM.Bucket, req.LOM.Objname)
-		iReqBuf, err := c.parent.newIntraReq(reqGet, nil).marshal()
+		iReqBuf, err := c.parent.newIntraReq(reqGet, nil).traced(req.Span).marshal()
 		if err != nil {
 			glog.Errorf("Failed to marshal %v", err)
 			continue
@@ -264,7 +264,7 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 
 	for node := range nodes {
 		uname := unique(node, req.LOM.Bucket, req.LOM.Objname)
-		iReqBuf, err := c.parent.newIntraReq(reqGet, nil).marshal()
+		iReqBuf, err := c.parent.newIntraReq(reqGet, nil).traced(req.Span).marshal()
 		if err != nil {
 			glog.Errorf("Failed to marshal %v", err)
 			continue
@@ -380,7 +380,7 @@ func (c *getJogger) requestSlices(req *Request, meta *Metadata, nodes map[string
 		}
 	}
 
-	iReq := c.parent.newIntraReq(reqGet, meta)
+	iReq := c.parent.newIntraReq(reqGet, meta).traced(req.Span)
 	iReq.IsSlice = true
 	request, err := iReq.marshal()
 	if err != nil {
@@ -725,6 +725,7 @@ func (c *getJogger) restore(req *Request, toDisk bool, buffer []byte) error {
 	if err != nil {
 		return err
 	}
+	req.LOM.UserMeta = cmn.DecodeUserMeta(meta.UserMeta)
 
 	if meta.IsCopy {
 		if toDisk {
@@ -748,7 +749,7 @@ func (c *getJogger) restore(req *Request, toDisk bool, buffer []byte) error {
 // always use SGL. No `toDisk` check required
 func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]*Metadata, err error) {
 	metaWG := cmn.NewTimeoutGroup()
-	request, _ := c.parent.newIntraReq(reqMeta, nil).marshal()
+	request, _ := c.parent.newIntraReq(reqMeta, nil).traced(req.Span).marshal()
 	hdr := transport.Header{
 		Bucket:  req.LOM.Bucket,
 		Objname: req.LOM.Objname,
@@ -119,6 +119,7 @@ func (c *putJogger) encode(req *Request) error {
 		Parity:   req.LOM.Bprops.ParitySlices,
 		IsCopy:   req.IsCopy,
 		Checksum: cksumValue,
+		UserMeta: cmn.EncodeUserMeta(req.LOM.UserMeta),
 	}
 
 	// calculate the number of targets required to encode the object
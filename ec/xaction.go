@@ -19,6 +19,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 )
 
@@ -143,6 +144,9 @@ func (r *XactEC) DispatchReq(w http.ResponseWriter, hdr transport.Header, object
 	case reqGet:
 		// slice or replica request: send the object's data to the caller
 		var fqn, errstr string
+		span := tracing.Start(iReq.TraceID, iReq.SpanID, "ec.respond")
+		span.Tag("sender", daemonID)
+		defer span.Finish()
 		if iReq.IsSlice {
 			if glog.V(4) {
 				glog.Infof("Received request for slice %d of %s", iReq.Meta.SliceID, hdr.Objname)
@@ -159,17 +163,20 @@ func (r *XactEC) DispatchReq(w http.ResponseWriter, hdr transport.Header, object
 			return
 		}
 
-		if err := r.dataResponse(reqPut, fqn, hdr.Bucket, hdr.Objname, daemonID); err != nil {
+		if err := r.dataResponse(reqPut, fqn, hdr.Bucket, hdr.Objname, daemonID, span); err != nil {
 			glog.Errorf("Failed to send back [GET req] %q: %v", fqn, err)
 		}
 	case reqMeta:
 		// metadata request: send the metadata to the caller
+		span := tracing.Start(iReq.TraceID, iReq.SpanID, "ec.respond")
+		span.Tag("sender", daemonID)
+		defer span.Finish()
 		fqn, errstr := cluster.FQN(MetaType, hdr.Bucket, hdr.Objname, bckIsLocal)
 		if errstr != "" {
 			glog.Errorf(errstr)
 			return
 		}
-		if err := r.dataResponse(iReq.Act, fqn, hdr.Bucket, hdr.Objname, daemonID); err != nil {
+		if err := r.dataResponse(iReq.Act, fqn, hdr.Bucket, hdr.Objname, daemonID, span); err != nil {
 			glog.Errorf("Failed to send back [META req] %q: %v", fqn, err)
 		}
 	default:
@@ -210,7 +217,10 @@ func (r *XactEC) DispatchResp(w http.ResponseWriter, hdr transport.Header, objec
 			glog.Errorf("No writer for %s", uname)
 			return
 		}
-		if err := r.writerReceive(writer, iReq.Exists, object); err != nil && err != ErrorNotFound {
+		span := tracing.Start(iReq.TraceID, iReq.SpanID, "ec.recv")
+		err := r.writerReceive(writer, iReq.Exists, object)
+		span.Finish()
+		if err != nil && err != ErrorNotFound {
 			glog.Errorf("Failed to receive data for %s: %v", iReq.Sender, err)
 		}
 
@@ -233,7 +243,10 @@ func (r *XactEC) DispatchResp(w http.ResponseWriter, hdr transport.Header, objec
 			if hdr.ObjAttrs.CksumType != "" {
 				writer.lom.Cksum = cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue)
 			}
-			if err := r.writerReceive(writer, iReq.Exists, object); err != nil {
+			span := tracing.Start(iReq.TraceID, iReq.SpanID, "ec.recv")
+			err := r.writerReceive(writer, iReq.Exists, object)
+			span.Finish()
+			if err != nil {
 				glog.Errorf("Failed to read replica: %v", err)
 			}
 			return
@@ -290,6 +303,7 @@ func (r *XactEC) DispatchResp(w http.ResponseWriter, hdr transport.Header, objec
 			}
 			lom.Version = hdr.ObjAttrs.Version
 			lom.Atime = time.Unix(0, hdr.ObjAttrs.Atime)
+			lom.UserMeta = cmn.DecodeUserMeta(hdr.ObjAttrs.UserMeta)
 			if hdr.ObjAttrs.CksumType != "" {
 				lom.Cksum = cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue)
 			}
@@ -453,9 +467,10 @@ func (r *XactEC) writeRemote(daemonIDs []string, lom *cluster.LOM, src *dataSour
 		return err
 	}
 	objAttrs := transport.ObjectAttrs{
-		Size:    src.size,
-		Version: lom.Version,
-		Atime:   lom.Atime.UnixNano(),
+		Size:     src.size,
+		Version:  lom.Version,
+		Atime:    lom.Atime.UnixNano(),
+		UserMeta: cmn.EncodeUserMeta(lom.UserMeta),
 	}
 	if lom.Cksum != nil {
 		objAttrs.CksumType, objAttrs.CksumValue = lom.Cksum.Get()
@@ -565,16 +580,16 @@ func (r *XactEC) removeObjAndMeta(bucket, objname string, bckIsLocal bool) error
 // Sends the replica/meta/slice data: either to copy replicas/slices after
 // encoding or to send requested "object" to a client. In the latter case
 // if the local object does not exist, it sends an empty body and sets
-// exists=false in response header
-func (r *XactEC) dataResponse(act intraReqType, fqn, bucket, objname, id string) error {
+// exists=false in response header. The span, if any, is propagated to the client
+func (r *XactEC) dataResponse(act intraReqType, fqn, bucket, objname, id string, span *tracing.Span) error {
 	var (
 		reader cmn.ReadOpenCloser
 		sz     int64
 	)
-	ireq := r.newIntraReq(act, nil)
+	ireq := r.newIntraReq(act, nil).traced(span)
 	fh, err := cmn.NewFileHandle(fqn)
 	lom := &cluster.LOM{FQN: fqn, T: r.t}
-	if errstr := lom.Fill("", cluster.LomFstat|cluster.LomAtime|cluster.LomVersion|cluster.LomCksum); errstr != "" {
+	if errstr := lom.Fill("", cluster.LomFstat|cluster.LomAtime|cluster.LomVersion|cluster.LomCksum|cluster.LomUserMeta); errstr != "" {
 		// an error is OK. Log it and try to go on with what has been read
 		glog.Warningf("Failed to read file stats: %s", errstr)
 	}
@@ -587,9 +602,10 @@ func (r *XactEC) dataResponse(act intraReqType, fqn, bucket, objname, id string)
 	}
 	cmn.Assert((sz == 0 && reader == nil) || (sz != 0 && reader != nil))
 	objAttrs := transport.ObjectAttrs{
-		Size:    sz,
-		Version: lom.Version,
-		Atime:   lom.Atime.UnixNano(),
+		Size:     sz,
+		Version:  lom.Version,
+		Atime:    lom.Atime.UnixNano(),
+		UserMeta: cmn.EncodeUserMeta(lom.UserMeta),
 	}
 	if lom.Cksum != nil {
 		objAttrs.CksumType, objAttrs.CksumValue = lom.Cksum.Get()
@@ -693,6 +709,8 @@ func (r *XactEC) Cleanup(req *Request) {
 
 func (r *XactEC) dispatchRequest(req *Request) {
 	r.IncPending()
+	r.ObjectsAdd(1)
+	r.BytesAdd(req.LOM.Size)
 	switch req.Action {
 	case ActRestore:
 		jogger, ok := r.getJoggers[req.LOM.ParsedFQN.MpathInfo.Path]
@@ -727,6 +745,12 @@ func (r *XactEC) newIntraReq(act intraReqType, meta *Metadata) *intraReq {
 	}
 }
 
+// Propagates the span of the traced request to the destination
+func (r *intraReq) traced(span *tracing.Span) *intraReq {
+	r.TraceID, r.SpanID = span.IDs()
+	return r
+}
+
 // Registers a new slice that will wait for the data to come from
 // a remote target
 func (r *XactEC) regWriter(uname string, writer *slice) bool {
@@ -18,6 +18,7 @@ const (
 	WorkfileRemote      = "remote" // getting object from neighbor target while rebalance is running
 	WorkfileColdget     = "cold"   // object GET: coldget
 	WorkfilePut         = "put"    // object PUT
+	WorkfileAppend      = "append" // object append
 	WorkfileRebalance   = "reb"    // rebalance
 	WorkfileFSHC        = "fshc"   // FSHC test file
 )
@@ -168,6 +168,8 @@ func (lctx *lructx) evict() (err error) {
 	}
 	lctx.ini.Statsif.Add(stats.LruEvictSize, bevicted)
 	lctx.ini.Statsif.Add(stats.LruEvictCount, fevicted)
+	lctx.ini.Xlru.ObjectsAdd(fevicted)
+	lctx.ini.Xlru.BytesAdd(bevicted)
 	return nil
 }
 
@@ -313,3 +313,11 @@ func (x *xactMock) String() string                     { return "" }
 func (x *xactMock) Abort()                             {}
 func (x *xactMock) ChanAbort() <-chan struct{}         { return nil }
 func (x *xactMock) Finished() bool                     { return false }
+func (x *xactMock) Aborted() bool                      { return false }
+func (x *xactMock) ObjectsAdd(cnt int64)               {}
+func (x *xactMock) BytesAdd(size int64)                {}
+func (x *xactMock) ErrorsAdd(cnt int64)                {}
+func (x *xactMock) ObjCount() int64                    { return 0 }
+func (x *xactMock) BytesCount() int64                  { return 0 }
+func (x *xactMock) ErrCount() int64                    { return 0 }
+func (x *xactMock) OnEnd(f func())                     {}
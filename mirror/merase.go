@@ -161,6 +161,8 @@ func (j *eraser) walk(fqn string, osfi os.FileInfo, err error) error {
 	if errstr := lom.DelCopy(); errstr != "" {
 		return errors.New(errstr)
 	}
+	j.parent.ObjectsAdd(1)
+	j.parent.BytesAdd(lom.Size)
 	j.num++
 	if (j.num % throttleNumErased) == 0 {
 		if err = j.yieldTerm(); err != nil {
@@ -225,6 +225,7 @@ func (j *copier) mirror(lom *cluster.LOM) {
 	lom.ParsedFQN.MpathInfo = j.mpathInfo
 	workFQN := lom.GenFQN(fs.WorkfileType, fs.WorkfilePut)
 	if err := lom.CopyObject(workFQN, j.buf); err != nil {
+		j.parent.ErrorsAdd(1)
 		return
 	}
 	cpyFQN := fs.CSM.FQN(j.mpathInfo, lom.ParsedFQN.ContentType, lom.BckIsLocal, lom.Bucket, lom.Objname)
@@ -241,12 +242,15 @@ func (j *copier) mirror(lom *cluster.LOM) {
 		if glog.V(4) {
 			glog.Infof("copied %s/%s %s=>%s", lom.Bucket, lom.Objname, lom.ParsedFQN.MpathInfo, j.mpathInfo)
 		}
+		j.parent.ObjectsAdd(1)
+		j.parent.BytesAdd(lom.Size)
 		if v := atomic.AddInt64(&j.parent.copied, 1); (v % logNumCopied) == 0 {
 			glog.Infof("%s: total~=%d, copied=%d", j.parent.String(), j.parent.total, v)
 		}
 	}
 	return
 fail:
+	j.parent.ErrorsAdd(1)
 	if errRemove := os.Remove(workFQN); errRemove != nil {
 		glog.Errorf("Failed to remove %s, err: %v", workFQN, errRemove)
 		j.parent.T.FSHC(errRemove, workFQN)
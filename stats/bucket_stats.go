@@ -0,0 +1,195 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//
+// per-bucket traffic stats: tracked by each target and aggregated
+// cluster-wide by the proxy (GET /v1/cluster?what=bucketstats)
+//
+
+type (
+	// all counters are cumulative; latencies are cumulative as well, in microseconds
+	BucketStats struct {
+		Bucket         string `json:"bucket"`
+		GetCount       int64  `json:"getCount"`
+		GetColdCount   int64  `json:"getColdCount"`
+		GetSize        int64  `json:"getSize"`
+		GetLatency     int64  `json:"getLatency"`
+		PutCount       int64  `json:"putCount"`
+		PutSize        int64  `json:"putSize"`
+		PutLatency     int64  `json:"putLatency"`
+		DeleteCount    int64  `json:"deleteCount"`
+		ErrGetCount    int64  `json:"errGetCount"`
+		ErrPutCount    int64  `json:"errPutCount"`
+		ErrDeleteCount int64  `json:"errDeleteCount"`
+	}
+	bucketTracker struct {
+		sync.RWMutex
+		buckets map[string]*BucketStats // counters are updated atomically under read lock
+	}
+)
+
+//
+// BucketStats
+//
+
+func (s *BucketStats) Add(other *BucketStats) {
+	s.GetCount += other.GetCount
+	s.GetColdCount += other.GetColdCount
+	s.GetSize += other.GetSize
+	s.GetLatency += other.GetLatency
+	s.PutCount += other.PutCount
+	s.PutSize += other.PutSize
+	s.PutLatency += other.PutLatency
+	s.DeleteCount += other.DeleteCount
+	s.ErrGetCount += other.ErrGetCount
+	s.ErrPutCount += other.ErrPutCount
+	s.ErrDeleteCount += other.ErrDeleteCount
+}
+
+// ColdGetRatio returns the fraction of GETs that had to be served from the Cloud
+func (s *BucketStats) ColdGetRatio() float64 {
+	if s.GetCount == 0 {
+		return 0
+	}
+	return float64(s.GetColdCount) / float64(s.GetCount)
+}
+
+func (s *BucketStats) AvgGetLatency() time.Duration { return avgLatency(s.GetLatency, s.GetCount) }
+func (s *BucketStats) AvgPutLatency() time.Duration { return avgLatency(s.PutLatency, s.PutCount) }
+
+func avgLatency(cumulative, count int64) time.Duration {
+	if count == 0 {
+		return 0
+	}
+	return time.Duration(cumulative/count) * time.Microsecond
+}
+
+// MergeBucketStats sums up the per-target stats of each bucket; the result is sorted by bucket name
+func MergeBucketStats(all ...[]BucketStats) []BucketStats {
+	merged := make(map[string]*BucketStats)
+	for _, list := range all {
+		for i := range list {
+			s, ok := merged[list[i].Bucket]
+			if !ok {
+				s = &BucketStats{Bucket: list[i].Bucket}
+				merged[s.Bucket] = s
+			}
+			s.Add(&list[i])
+		}
+	}
+	out := make([]BucketStats, 0, len(merged))
+	for _, s := range merged {
+		out = append(out, *s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Bucket < out[j].Bucket })
+	return out
+}
+
+//
+// bucketTracker
+//
+
+func (bt *bucketTracker) get(bucket string) *BucketStats {
+	bt.RLock()
+	s, ok := bt.buckets[bucket]
+	bt.RUnlock()
+	if ok {
+		return s
+	}
+	bt.Lock()
+	if s, ok = bt.buckets[bucket]; !ok {
+		if bt.buckets == nil {
+			bt.buckets = make(map[string]*BucketStats, 16)
+		}
+		s = &BucketStats{Bucket: bucket}
+		bt.buckets[bucket] = s
+	}
+	bt.Unlock()
+	return s
+}
+
+func (bt *bucketTracker) del(buckets ...string) {
+	bt.Lock()
+	for _, bucket := range buckets {
+		delete(bt.buckets, bucket)
+	}
+	bt.Unlock()
+}
+
+func (bt *bucketTracker) snapshot() []BucketStats {
+	bt.RLock()
+	out := make([]BucketStats, 0, len(bt.buckets))
+	for _, s := range bt.buckets {
+		out = append(out, BucketStats{
+			Bucket:         s.Bucket,
+			GetCount:       atomic.LoadInt64(&s.GetCount),
+			GetColdCount:   atomic.LoadInt64(&s.GetColdCount),
+			GetSize:        atomic.LoadInt64(&s.GetSize),
+			GetLatency:     atomic.LoadInt64(&s.GetLatency),
+			PutCount:       atomic.LoadInt64(&s.PutCount),
+			PutSize:        atomic.LoadInt64(&s.PutSize),
+			PutLatency:     atomic.LoadInt64(&s.PutLatency),
+			DeleteCount:    atomic.LoadInt64(&s.DeleteCount),
+			ErrGetCount:    atomic.LoadInt64(&s.ErrGetCount),
+			ErrPutCount:    atomic.LoadInt64(&s.ErrPutCount),
+			ErrDeleteCount: atomic.LoadInt64(&s.ErrDeleteCount),
+		})
+	}
+	bt.RUnlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].Bucket < out[j].Bucket })
+	return out
+}
+
+//
+// Trunner
+//
+
+func (r *Trunner) AddBucketGet(bucket string, size int64, latency time.Duration, cold bool) {
+	s := r.buckets.get(bucket)
+	atomic.AddInt64(&s.GetCount, 1)
+	atomic.AddInt64(&s.GetSize, size)
+	atomic.AddInt64(&s.GetLatency, int64(latency/time.Microsecond))
+	if cold {
+		atomic.AddInt64(&s.GetColdCount, 1)
+	}
+}
+
+func (r *Trunner) AddBucketPut(bucket string, size int64, latency time.Duration) {
+	s := r.buckets.get(bucket)
+	atomic.AddInt64(&s.PutCount, 1)
+	atomic.AddInt64(&s.PutSize, size)
+	atomic.AddInt64(&s.PutLatency, int64(latency/time.Microsecond))
+}
+
+func (r *Trunner) AddBucketDelete(bucket string) {
+	atomic.AddInt64(&r.buckets.get(bucket).DeleteCount, 1)
+}
+
+// AddBucketErrorHTTP counts failed GET, PUT, and DELETE requests (other methods are ignored)
+func (r *Trunner) AddBucketErrorHTTP(bucket, method string) {
+	switch method {
+	case http.MethodGet:
+		atomic.AddInt64(&r.buckets.get(bucket).ErrGetCount, 1)
+	case http.MethodPut:
+		atomic.AddInt64(&r.buckets.get(bucket).ErrPutCount, 1)
+	case http.MethodDelete:
+		atomic.AddInt64(&r.buckets.get(bucket).ErrDeleteCount, 1)
+	}
+}
+
+// DelBucketStats removes the stats of the buckets that no longer exist
+func (r *Trunner) DelBucketStats(buckets ...string) { r.buckets.del(buckets...) }
+
+func (r *Trunner) GetBucketStats() []BucketStats { return r.buckets.snapshot() }
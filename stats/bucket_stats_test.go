@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package stats
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBucketStats(t *testing.T) {
+	r1, r2 := &Trunner{}, &Trunner{}
+	r1.AddBucketGet("b", 100, 2*time.Millisecond, true)
+	r1.AddBucketGet("b", 100, 4*time.Millisecond, false)
+	r1.AddBucketPut("a", 10, time.Millisecond)
+	r1.AddBucketErrorHTTP("b", http.MethodGet)
+	r1.AddBucketErrorHTTP("b", http.MethodHead) // not counted
+	r2.AddBucketGet("b", 100, 6*time.Millisecond, true)
+	r2.AddBucketGet("b", 100, 4*time.Millisecond, false)
+	r2.AddBucketDelete("c")
+
+	merged := MergeBucketStats(r1.GetBucketStats(), r2.GetBucketStats())
+	if len(merged) != 3 || merged[0].Bucket != "a" || merged[1].Bucket != "b" || merged[2].Bucket != "c" {
+		t.Fatalf("unexpected merged stats: %+v", merged)
+	}
+	b := merged[1]
+	if b.GetCount != 4 || b.GetSize != 400 || b.ErrGetCount != 1 {
+		t.Errorf("unexpected stats of the bucket: %+v", b)
+	}
+	if ratio := b.ColdGetRatio(); ratio != 0.5 {
+		t.Errorf("expected cold GET ratio 0.5, got %f", ratio)
+	}
+	if latency := b.AvgGetLatency(); latency != 4*time.Millisecond {
+		t.Errorf("expected average GET latency 4ms, got %v", latency)
+	}
+	if merged[0].PutCount != 1 || merged[0].AvgPutLatency() != time.Millisecond || merged[2].DeleteCount != 1 {
+		t.Errorf("unexpected stats: %+v", merged)
+	}
+
+	// destroyed buckets
+	r2.DelBucketStats("c", "nonexisting")
+	if list := r2.GetBucketStats(); len(list) != 1 || list[0].Bucket != "b" {
+		t.Errorf("expected stats of the bucket b only, got %+v", list)
+	}
+}
@@ -39,6 +39,7 @@ const (
 	PostCount        = "pst.n"
 	DeleteCount      = "del.n"
 	RenameCount      = "ren.n"
+	AppendCount      = "app.n"
 	ListCount        = "lst.n"
 	ErrCount         = "err.n"
 	ErrGetCount      = "err.get.n"
@@ -108,7 +109,9 @@ type (
 		kind       string
 		numSamples int64
 		cumulative int64
-		isCommon   bool // optional, common to the proxy and target
+		// total number of samples (never reset) to go with the cumulative latency
+		totalSamples int64
+		isCommon     bool // optional, common to the proxy and target
 	}
 	copyValue struct {
 		Value int64 `json:"v"`
@@ -160,6 +163,7 @@ func (tracker statsTracker) registerCommonStats() {
 	tracker.register(PostCount, KindCounter, true)
 	tracker.register(DeleteCount, KindCounter, true)
 	tracker.register(RenameCount, KindCounter, true)
+	tracker.register(AppendCount, KindCounter, true)
 	tracker.register(ListCount, KindCounter, true)
 	tracker.register(GetLatency, KindLatency, true)
 	tracker.register(ListLatency, KindLatency, true)
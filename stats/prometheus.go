@@ -0,0 +1,294 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+//
+// Prometheus text exposition format (version 0.0.4)
+// see https://prometheus.io/docs/instrumenting/exposition_formats
+//
+// Tracked stats are named as follows:
+// "*.n"   (counter) => ais_*_total
+// "*.size"(counter) => ais_*_bytes_total
+// "*.µs"  (latency) => ais_*_latency_seconds (summary: _sum and _count)
+// "*.bps" (throughput) => ais_*_bytes_total
+// all samples are labeled with daemon="<daemon ID>"
+//
+
+const PromContentType = "text/plain; version=0.0.4"
+
+const (
+	promCounter = "counter"
+	promGauge   = "gauge"
+	promSummary = "summary"
+)
+
+type (
+	promWriter struct {
+		w      io.Writer
+		daemon string // daemon="<daemon ID>", included with each sample
+	}
+	promLabel struct {
+		name, value string
+	}
+)
+
+func newPromWriter(w io.Writer, daemonID string) *promWriter {
+	return &promWriter{w: w, daemon: "daemon=\"" + promEscape(daemonID) + "\""}
+}
+
+func (pw *promWriter) family(name, typ, help string) {
+	fmt.Fprintf(pw.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (pw *promWriter) sample(name string, val interface{}, labels ...promLabel) {
+	var sb strings.Builder
+	sb.WriteString(pw.daemon)
+	for _, l := range labels {
+		sb.WriteString(",")
+		sb.WriteString(l.name)
+		sb.WriteString("=\"")
+		sb.WriteString(promEscape(l.value))
+		sb.WriteString("\"")
+	}
+	fmt.Fprintf(pw.w, "%s{%s} %v\n", name, sb.String(), val)
+}
+
+func (pw *promWriter) tracker(tracker statsTracker, starttime time.Time) {
+	names := make([]string, 0, len(tracker))
+	for name := range tracker {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := tracker[name]
+		pname, typ := promName(name, v.kind)
+		if name == Uptime {
+			pw.family(pname, typ, "time since the daemon has started")
+			pw.sample(pname, time.Since(starttime).Seconds())
+			continue
+		}
+		pw.family(pname, typ, "AIS stats "+name)
+		v.RLock()
+		switch v.kind {
+		case KindLatency:
+			pw.sample(pname+"_sum", float64(v.cumulative)/float64(time.Second/time.Microsecond))
+			pw.sample(pname+"_count", v.totalSamples)
+		case KindThroughput:
+			pw.sample(pname, v.cumulative)
+		default:
+			pw.sample(pname, v.Value)
+		}
+		v.RUnlock()
+	}
+}
+
+func (pw *promWriter) capacity(capacity map[string]*fscapacity) {
+	mpaths := make([]string, 0, len(capacity))
+	for mpath := range capacity {
+		mpaths = append(mpaths, mpath)
+	}
+	sort.Strings(mpaths)
+	pw.family("ais_mountpath_used_bytes", promGauge, "used capacity of the mountpath")
+	for _, mpath := range mpaths {
+		pw.sample("ais_mountpath_used_bytes", capacity[mpath].Used, promLabel{"mountpath", mpath})
+	}
+	pw.family("ais_mountpath_avail_bytes", promGauge, "available capacity of the mountpath")
+	for _, mpath := range mpaths {
+		pw.sample("ais_mountpath_avail_bytes", capacity[mpath].Avail, promLabel{"mountpath", mpath})
+	}
+	pw.family("ais_mountpath_used_percent", promGauge, "used capacity of the mountpath, in percent")
+	for _, mpath := range mpaths {
+		pw.sample("ais_mountpath_used_percent", capacity[mpath].Usedpct, promLabel{"mountpath", mpath})
+	}
+}
+
+// iostats: the most recent disk utilization and queue length (as per iostat) of each mountpath
+func (pw *promWriter) iostats() {
+	availablePaths, _ := fs.Mountpaths.Get()
+	mpaths := make([]string, 0, len(availablePaths))
+	for mpath := range availablePaths {
+		mpaths = append(mpaths, mpath)
+	}
+	sort.Strings(mpaths)
+	for _, st := range []struct{ name, pname, help string }{
+		{fs.StatDiskUtil, "ais_mountpath_disk_util_percent", "max utilization of the mountpath disks (iostat %util)"},
+		{fs.StatQueueLen, "ais_mountpath_disk_queue_len", "max queue length of the mountpath disks (iostat aqu-sz)"},
+	} {
+		pw.family(st.pname, promGauge, st.help)
+		for _, mpath := range mpaths {
+			_, curr := availablePaths[mpath].GetIOstats(st.name)
+			pw.sample(st.pname, curr.Max, promLabel{"mountpath", mpath})
+		}
+	}
+}
+
+// buckets: per-bucket traffic, labeled with bucket="<name>"
+func (pw *promWriter) buckets(list []BucketStats) {
+	for _, m := range []struct {
+		pname, typ, help string
+		val              func(s *BucketStats) int64
+	}{
+		{"ais_bucket_get_total", promCounter, "number of GET-object requests",
+			func(s *BucketStats) int64 { return s.GetCount }},
+		{"ais_bucket_get_cold_total", promCounter, "number of cold GET-object requests",
+			func(s *BucketStats) int64 { return s.GetColdCount }},
+		{"ais_bucket_get_bytes_total", promCounter, "number of bytes read by GET-object requests",
+			func(s *BucketStats) int64 { return s.GetSize }},
+		{"ais_bucket_put_total", promCounter, "number of PUT-object requests",
+			func(s *BucketStats) int64 { return s.PutCount }},
+		{"ais_bucket_put_bytes_total", promCounter, "number of bytes written by PUT-object requests",
+			func(s *BucketStats) int64 { return s.PutSize }},
+		{"ais_bucket_delete_total", promCounter, "number of DELETE-object requests",
+			func(s *BucketStats) int64 { return s.DeleteCount }},
+		{"ais_bucket_err_get_total", promCounter, "number of failed GET-object requests",
+			func(s *BucketStats) int64 { return s.ErrGetCount }},
+		{"ais_bucket_err_put_total", promCounter, "number of failed PUT-object requests",
+			func(s *BucketStats) int64 { return s.ErrPutCount }},
+		{"ais_bucket_err_delete_total", promCounter, "number of failed DELETE-object requests",
+			func(s *BucketStats) int64 { return s.ErrDeleteCount }},
+	} {
+		pw.family(m.pname, m.typ, m.help)
+		for i := range list {
+			pw.sample(m.pname, m.val(&list[i]), promLabel{"bucket", list[i].Bucket})
+		}
+	}
+	for _, m := range []struct {
+		pname, help string
+		sum, count  func(s *BucketStats) int64
+	}{
+		{"ais_bucket_get_latency_seconds", "GET-object latency",
+			func(s *BucketStats) int64 { return s.GetLatency }, func(s *BucketStats) int64 { return s.GetCount }},
+		{"ais_bucket_put_latency_seconds", "PUT-object latency",
+			func(s *BucketStats) int64 { return s.PutLatency }, func(s *BucketStats) int64 { return s.PutCount }},
+	} {
+		pw.family(m.pname, promSummary, m.help)
+		for i := range list {
+			label := promLabel{"bucket", list[i].Bucket}
+			pw.sample(m.pname+"_sum", float64(m.sum(&list[i]))/float64(time.Second/time.Microsecond), label)
+			pw.sample(m.pname+"_count", m.count(&list[i]), label)
+		}
+	}
+}
+
+func (pw *promWriter) memsys(mem *memsys.Mem2) {
+	if mem == nil {
+		return
+	}
+	pw.family("ais_memsys_pressure", promGauge,
+		"memory pressure: 0 - low, 1 - moderate, 2 - high, 3 - extreme, 4 - OOM")
+	pw.sample("ais_memsys_pressure", mem.MemPressure())
+}
+
+// transport: receive-side stats of the endpoints (aka trnames), summed up over the sessions
+func (pw *promWriter) transport() {
+	type endpointStats struct {
+		network, trname string
+		num, size       int64
+	}
+	var all []endpointStats
+	for _, network := range []string{cmn.NetworkPublic, cmn.NetworkIntraControl, cmn.NetworkIntraData} {
+		netstats, err := transport.GetNetworkStats(network)
+		if err != nil {
+			continue // no handlers on this network
+		}
+		for trname, eps := range netstats {
+			st := endpointStats{network: network, trname: trname}
+			for _, s := range eps {
+				st.num += s.Num
+				st.size += s.Size
+			}
+			all = append(all, st)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].network != all[j].network {
+			return all[i].network < all[j].network
+		}
+		return all[i].trname < all[j].trname
+	})
+	pw.family("ais_transport_rx_objects_total", promCounter, "number of objects received by the transport endpoint")
+	for _, st := range all {
+		pw.sample("ais_transport_rx_objects_total", st.num,
+			promLabel{"network", st.network}, promLabel{"endpoint", st.trname})
+	}
+	pw.family("ais_transport_rx_bytes_total", promCounter, "number of bytes received by the transport endpoint")
+	for _, st := range all {
+		pw.sample("ais_transport_rx_bytes_total", st.size,
+			promLabel{"network", st.network}, promLabel{"endpoint", st.trname})
+	}
+}
+
+//
+// Prunner and Trunner
+//
+
+// WritePrometheus renders the proxy's stats in the Prometheus text exposition format
+func (r *Prunner) WritePrometheus(w io.Writer, daemonID string, mem *memsys.Mem2) {
+	pw := newPromWriter(w, daemonID)
+	pw.tracker(r.Core.Tracker, r.starttime)
+	pw.memsys(mem)
+	pw.transport()
+}
+
+// WritePrometheus renders the target's stats, including per-mountpath capacities
+// and disk utilizations, in the Prometheus text exposition format
+func (r *Trunner) WritePrometheus(w io.Writer, daemonID string, mem *memsys.Mem2) {
+	pw := newPromWriter(w, daemonID)
+	pw.tracker(r.Core.Tracker, r.starttime)
+	pw.capacity(r.Capacity)
+	pw.iostats()
+	pw.buckets(r.GetBucketStats())
+	pw.memsys(mem)
+	pw.transport()
+}
+
+//
+// misc
+//
+
+func promName(name, kind string) (pname, typ string) {
+	switch {
+	case name == Uptime:
+		return "ais_uptime_seconds", promGauge
+	case kind == KindLatency:
+		return "ais_" + promSanitize(strings.Replace(name, ".µs", "", 1)) + "_latency_seconds", promSummary
+	case kind == KindThroughput:
+		return "ais_" + promSanitize(strings.TrimSuffix(name, ".bps")) + "_bytes_total", promCounter
+	case kind == KindCounter && strings.HasSuffix(name, ".size"):
+		return "ais_" + promSanitize(strings.TrimSuffix(name, ".size")) + "_bytes_total", promCounter
+	case kind == KindCounter:
+		return "ais_" + promSanitize(strings.TrimSuffix(name, ".n")) + "_total", promCounter
+	}
+	return "ais_" + promSanitize(name), promGauge
+}
+
+// metric names must match [a-zA-Z_:][a-zA-Z0-9_:]*
+func promSanitize(name string) string {
+	name = strings.Replace(name, "µs", "us", -1)
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func promEscape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/stats/statsd"
+)
+
+func TestPromName(t *testing.T) {
+	tests := []struct {
+		name, kind, pname, typ string
+	}{
+		{GetCount, KindCounter, "ais_get_total", promCounter},
+		{ErrGetCount, KindCounter, "ais_err_get_total", promCounter},
+		{GetColdSize, KindCounter, "ais_get_cold_bytes_total", promCounter},
+		{GetLatency, KindLatency, "ais_get_latency_seconds", promSummary},
+		{KeepAliveMinLatency, KindLatency, "ais_kalive_min_latency_seconds", promSummary},
+		{GetThroughput, KindThroughput, "ais_get_bytes_total", promCounter},
+		{Uptime, KindSpecial, "ais_uptime_seconds", promGauge},
+	}
+	for _, test := range tests {
+		if pname, typ := promName(test.name, test.kind); pname != test.pname || typ != test.typ {
+			t.Errorf("%s: expected %s (%s), got %s (%s)", test.name, test.pname, test.typ, pname, typ)
+		}
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := &Prunner{Core: &ProxyCoreStats{StatsdC: &statsd.Client{}}}
+	r.Core.init(24)
+	r.starttime = time.Now()
+	r.doAdd(NamedVal64{GetCount, 3})
+	r.doAdd(NamedVal64{GetLatency, int64(2 * time.Millisecond)})
+	r.doAdd(NamedVal64{GetLatency, int64(4 * time.Millisecond)})
+
+	// latency resets with each log() while the exposed sum and count must not
+	r.Core.copyZeroReset(make(copyTracker, 24))
+
+	buf := &bytes.Buffer{}
+	r.WritePrometheus(buf, `p"1`, nil)
+	out := buf.String()
+	for _, expected := range []string{
+		"# TYPE ais_get_total counter\n",
+		"ais_get_total{daemon=\"p\\\"1\"} 3\n",
+		"# TYPE ais_get_latency_seconds summary\n",
+		"ais_get_latency_seconds_sum{daemon=\"p\\\"1\"} 0.006\n",
+		"ais_get_latency_seconds_count{daemon=\"p\\\"1\"} 2\n",
+		"# TYPE ais_uptime_seconds gauge\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in:\n%s", expected, out)
+		}
+	}
+}
@@ -64,6 +64,7 @@ func (s *ProxyCoreStats) doAdd(name string, val int64) {
 		}
 		v.Lock()
 		v.numSamples++
+		v.totalSamples++
 		val = int64(time.Duration(val) / time.Microsecond)
 		v.cumulative += val
 		v.Value += val
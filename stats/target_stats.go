@@ -77,7 +77,8 @@ type (
 			capLimit, capIdx int64 // update capacity: time interval counting
 			logLimit, logIdx int64 // check log size: ditto
 		}
-		lines []string
+		lines   []string
+		buckets bucketTracker // per-bucket traffic
 	}
 	copyRunner struct {
 		Tracker  copyTracker            `json:"core"`
@@ -18,12 +18,18 @@ type (
 	}
 	// FIXME: redundant vs. XactBase
 	XactionDetails struct {
-		ID        int64     `json:"id"`
-		Kind      string    `json:"kind"`
-		Bucket    string    `json:"bucket"`
-		StartTime time.Time `json:"startTime"`
-		EndTime   time.Time `json:"endTime"`
-		Status    string    `json:"status"`
+		ID         int64         `json:"id"`
+		Kind       string        `json:"kind"`
+		Bucket     string        `json:"bucket"`
+		StartTime  time.Time     `json:"startTime"`
+		EndTime    time.Time     `json:"endTime"`
+		Status     string        `json:"status"`
+		Duration   time.Duration `json:"duration,omitempty"` // when finished
+		ObjCount   int64         `json:"objCount"`           // number of objects processed so far
+		BytesCount int64         `json:"bytesCount"`         // ditto, bytes
+		ErrCount   int64         `json:"errCount"`           // number of errors
+		TargetID   string        `json:"targetId,omitempty"` // set by the proxy when aggregating cluster-wide
+		UUID       string        `json:"uuid,omitempty"`     // dSort job UUID (the ID is zero)
 	}
 
 	RebalanceTargetStats struct {
@@ -42,6 +48,16 @@ type (
 		NumFilesPrefetched int64            `json:"numFilesPrefetched"`
 		NumBytesPrefetched int64            `json:"numBytesPrefetched"`
 	}
+	CopyBucketXactDetails struct {
+		XactionDetails
+		BucketTo       string `json:"bucketTo"`
+		NumCopiedFiles int64  `json:"numCopiedFiles"`
+		NumCopiedBytes int64  `json:"numCopiedBytes"`
+		NumErrors      int64  `json:"numErrors"`
+	}
+	CopyBucketTargetStats struct {
+		Xactions []CopyBucketXactDetails `json:"xactionDetails"`
+	}
 	PrefetchStats struct {
 		Kind        string                   `json:"kind"`
 		TargetStats map[string]PrefetchStats `json:"target"`
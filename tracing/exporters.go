@@ -0,0 +1,120 @@
+// Package tracing provides distributed request tracing across AIS proxies and targets:
+// spans are propagated via HTTP headers (or, in case of redirect, the URL query)
+// and exported to a local file and/or a Zipkin-compatible collector.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const zipkinTimeout = 10 * time.Second
+
+type (
+	// appends spans to a local file, one JSON-encoded span per line
+	fileExporter struct {
+		file *os.File
+	}
+	// sends spans to a collector that supports Zipkin v2 JSON API (e.g., Zipkin or Jaeger),
+	// e.g. http://localhost:9411/api/v2/spans
+	zipkinExporter struct {
+		url         string
+		serviceName string
+		client      *http.Client
+	}
+	zipkinEndpoint struct {
+		ServiceName string `json:"serviceName"`
+	}
+	zipkinSpan struct {
+		TraceID       string            `json:"traceId"`
+		ID            string            `json:"id"`
+		ParentID      string            `json:"parentId,omitempty"`
+		Name          string            `json:"name"`
+		Timestamp     int64             `json:"timestamp"` // microseconds since epoch
+		Duration      int64             `json:"duration"`  // microseconds
+		LocalEndpoint zipkinEndpoint    `json:"localEndpoint"`
+		Tags          map[string]string `json:"tags,omitempty"`
+	}
+)
+
+//
+// fileExporter
+//
+
+func newFileExporter(path string) (*fileExporter, error) {
+	if err := cmn.CreateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &fileExporter{file: file}, nil
+}
+
+func (e *fileExporter) export(spans []*Span) error {
+	w := bufio.NewWriter(e.file)
+	enc := json.NewEncoder(w) // encodes one value per line
+	for _, s := range spans {
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func (e *fileExporter) close() { e.file.Close() }
+
+//
+// zipkinExporter
+//
+
+func newZipkinExporter(url, daemonID string) *zipkinExporter {
+	return &zipkinExporter{
+		url:         url,
+		serviceName: "ais-" + daemonID,
+		client:      &http.Client{Timeout: zipkinTimeout},
+	}
+}
+
+func (e *zipkinExporter) export(spans []*Span) error {
+	zspans := make([]zipkinSpan, len(spans))
+	for i, s := range spans {
+		zspans[i] = zipkinSpan{
+			TraceID:       s.TraceID,
+			ID:            s.ID,
+			ParentID:      s.ParentID,
+			Name:          s.Name,
+			Timestamp:     s.Start.UnixNano() / int64(time.Microsecond),
+			Duration:      cmn.MaxI64(int64(s.Duration/time.Microsecond), 1), // zipkin: at least 1µs
+			LocalEndpoint: zipkinEndpoint{ServiceName: e.serviceName},
+			Tags:          s.Tags,
+		}
+	}
+	body, err := json.Marshal(zspans)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("collector %s: HTTP status %d", e.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *zipkinExporter) close() {}
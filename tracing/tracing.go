@@ -0,0 +1,266 @@
+// Package tracing provides distributed request tracing across AIS proxies and targets:
+// spans are propagated via HTTP headers (or, in case of redirect, the URL query)
+// and exported to a local file and/or a Zipkin-compatible collector.
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	spanChanSize   = 1024
+	batchSize      = 128
+	flushInterval  = time.Second
+	traceFileName  = "trace.json"
+	contextSpanKey = contextKey("span")
+)
+
+type (
+	// Span is a timed phase of a request processed by a given daemon
+	Span struct {
+		TraceID  string            `json:"traceId"`
+		ID       string            `json:"id"`
+		ParentID string            `json:"parentId,omitempty"`
+		Name     string            `json:"name"`
+		Daemon   string            `json:"daemon"`
+		Start    time.Time         `json:"start"`
+		Duration time.Duration     `json:"duration"`
+		Tags     map[string]string `json:"tags,omitempty"`
+		tracer   *Tracer
+	}
+	// Tracer is the runner that batches finished spans and exports them
+	Tracer struct {
+		cmn.Named
+		daemonID  string
+		exporters []exporter
+		spanCh    chan *Span
+		stopCh    chan struct{}
+		dropped   int64
+	}
+	exporter interface {
+		export(spans []*Span) error
+		close()
+	}
+	contextKey string
+)
+
+// the tracer of this daemon; nil when tracing is disabled
+var tracer *Tracer
+
+// Init creates the tracer as per configuration; returns nil when tracing is disabled
+func Init(daemonID string, config *cmn.Config) (*Tracer, error) {
+	conf := &config.Trace
+	if !conf.Enabled {
+		return nil, nil
+	}
+	t := &Tracer{
+		daemonID: daemonID,
+		spanCh:   make(chan *Span, spanChanSize),
+		stopCh:   make(chan struct{}),
+	}
+	file := conf.File
+	if file == "" && conf.Collector == "" {
+		file = filepath.Join(config.Log.Dir, traceFileName)
+	}
+	if file != "" {
+		fe, err := newFileExporter(file)
+		if err != nil {
+			return nil, err
+		}
+		t.exporters = append(t.exporters, fe)
+	}
+	if conf.Collector != "" {
+		t.exporters = append(t.exporters, newZipkinExporter(conf.Collector, daemonID))
+	}
+	tracer = t
+	return t, nil
+}
+
+//
+// Tracer - as a runner
+//
+
+func (t *Tracer) Run() error {
+	glog.Infof("Starting %s", t.Getname())
+	var (
+		ticker = time.NewTicker(flushInterval)
+		batch  = make([]*Span, 0, batchSize)
+	)
+	defer ticker.Stop()
+	for {
+		select {
+		case span := <-t.spanCh:
+			if batch = append(batch, span); len(batch) >= batchSize {
+				batch = t.flush(batch)
+			}
+		case <-ticker.C:
+			batch = t.flush(batch)
+		case <-t.stopCh:
+			for len(t.spanCh) > 0 {
+				batch = append(batch, <-t.spanCh)
+			}
+			t.flush(batch)
+			for _, e := range t.exporters {
+				e.close()
+			}
+			return nil
+		}
+	}
+}
+
+func (t *Tracer) Stop(err error) {
+	glog.Infof("Stopping %s, err: %v", t.Getname(), err)
+	close(t.stopCh)
+}
+
+func (t *Tracer) flush(batch []*Span) []*Span {
+	if len(batch) == 0 {
+		return batch
+	}
+	for _, e := range t.exporters {
+		if err := e.export(batch); err != nil {
+			glog.Errorf("Failed to export %d span(s), err: %v", len(batch), err)
+		}
+	}
+	if dropped := atomic.SwapInt64(&t.dropped, 0); dropped > 0 {
+		glog.Warningf("Dropped %d span(s)", dropped)
+	}
+	return batch[:0]
+}
+
+func (t *Tracer) newSpan(traceID, parentID, name string) *Span {
+	if traceID == "" {
+		traceID = newID()
+	}
+	return &Span{
+		TraceID:  traceID,
+		ID:       newID(),
+		ParentID: parentID,
+		Name:     name,
+		Daemon:   t.daemonID,
+		Start:    time.Now(),
+		tracer:   t,
+	}
+}
+
+//
+// Span - all methods are no-op when the span is nil (tracing disabled)
+//
+
+// StartFromRequest starts a span of the trace carried by the request or a new trace;
+// the redirect URL takes precedence over the headers that clients may set as well
+func StartFromRequest(r *http.Request, name string) *Span {
+	if tracer == nil {
+		return nil
+	}
+	query := r.URL.Query()
+	traceID, parentID := query.Get(cmn.URLParamTraceID), query.Get(cmn.URLParamSpanID)
+	if traceID == "" {
+		traceID, parentID = r.Header.Get(cmn.HeaderTraceID), r.Header.Get(cmn.HeaderSpanID)
+	}
+	return tracer.newSpan(traceID, parentID, name)
+}
+
+// Start starts a span of the trace received via an intra-cluster stream (see IDs);
+// returns nil when tracing is disabled or the stream's object is not traced
+func Start(traceID, parentID, name string) *Span {
+	if tracer == nil || traceID == "" {
+		return nil
+	}
+	return tracer.newSpan(traceID, parentID, name)
+}
+
+// Child starts a span that denotes a phase of this one
+func (s *Span) Child(name string) *Span {
+	if s == nil {
+		return nil
+	}
+	return s.tracer.newSpan(s.TraceID, s.ID, name)
+}
+
+func (s *Span) Tag(key, value string) {
+	if s == nil {
+		return
+	}
+	if s.Tags == nil {
+		s.Tags = make(map[string]string, 4)
+	}
+	s.Tags[key] = value
+}
+
+// Finish records the duration of the span and hands it over to the tracer
+// to export; the span is dropped when the tracer cannot keep up
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.Duration = time.Since(s.Start)
+	select {
+	case s.tracer.spanCh <- s:
+	default:
+		atomic.AddInt64(&s.tracer.dropped, 1)
+	}
+}
+
+// Inject propagates the span to the callee via the intra-cluster request's headers
+func (s *Span) Inject(hdr http.Header) {
+	if s == nil {
+		return
+	}
+	hdr.Set(cmn.HeaderTraceID, s.TraceID)
+	hdr.Set(cmn.HeaderSpanID, s.ID)
+}
+
+// IDs returns the trace and the span IDs to propagate the span via the opaque
+// header of an intra-cluster stream
+func (s *Span) IDs() (traceID, spanID string) {
+	if s == nil {
+		return
+	}
+	return s.TraceID, s.ID
+}
+
+// AddToQuery propagates the span via the redirect URL's query
+func (s *Span) AddToQuery(query url.Values) {
+	if s == nil {
+		return
+	}
+	query.Set(cmn.URLParamTraceID, s.TraceID)
+	query.Set(cmn.URLParamSpanID, s.ID)
+}
+
+//
+// context
+//
+
+func NewContext(ctx context.Context, s *Span) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextSpanKey, s)
+}
+
+func FromContext(ctx context.Context) *Span {
+	s, _ := ctx.Value(contextSpanKey).(*Span)
+	return s
+}
+
+// 64-bit random hex ID
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ *
+ */
+package tracing
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestDisabled(t *testing.T) {
+	tracer = nil
+	config := &cmn.Config{}
+	if tr, err := Init("t1", config); tr != nil || err != nil {
+		t.Fatalf("expected no tracer, got %v, err: %v", tr, err)
+	}
+	r, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/objects/b/o", nil)
+	span := StartFromRequest(r, "get")
+	if span != nil {
+		t.Fatalf("expected nil span, got %+v", span)
+	}
+	// must be no-op
+	span.Child("phase").Finish()
+	span.Tag("k", "v")
+	span.Inject(r.Header)
+	if r.Header.Get(cmn.HeaderTraceID) != "" {
+		t.Error("expected no trace header")
+	}
+	if traceID, spanID := span.IDs(); traceID != "" || spanID != "" {
+		t.Errorf("expected no IDs, got %q, %q", traceID, spanID)
+	}
+	if span := Start("aaaa", "bbbb", "ec.respond"); span != nil {
+		t.Fatalf("expected nil span, got %+v", span)
+	}
+}
+
+func TestTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trace")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := &cmn.Config{}
+	config.Trace.Enabled = true
+	config.Log.Dir = dir
+
+	tr, err := Init("t1", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { tracer = nil }()
+	tr.Setname("tracer")
+	done := make(chan struct{})
+	go func() {
+		tr.Run()
+		close(done)
+	}()
+
+	// the redirect URL takes precedence over the headers
+	r, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/objects/b/o?trc=aaaa&spn=bbbb", nil)
+	r.Header.Set(cmn.HeaderTraceID, "cccc")
+	span := StartFromRequest(r, "target.get")
+	if span.TraceID != "aaaa" || span.ParentID != "bbbb" {
+		t.Errorf("unexpected span %+v", span)
+	}
+	child := span.Child("coldget")
+	child.Tag("object", "b/o")
+	hdr := http.Header{}
+	child.Inject(hdr)
+	if hdr.Get(cmn.HeaderTraceID) != "aaaa" || hdr.Get(cmn.HeaderSpanID) != child.ID {
+		t.Errorf("unexpected headers %v", hdr)
+	}
+	child.Finish()
+	span.Finish()
+
+	// via the opaque header of a stream
+	if Start("", "", "ec.respond") != nil {
+		t.Error("expected no span when the object is not traced")
+	}
+	traceID, spanID := span.IDs()
+	if remote := Start(traceID, spanID, "ec.respond"); remote.TraceID != "aaaa" || remote.ParentID != span.ID {
+		t.Errorf("unexpected span %+v", remote)
+	}
+
+	tr.Stop(nil)
+	<-done
+
+	file, err := os.Open(filepath.Join(dir, traceFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var spans []Span
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var s Span
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		spans = append(spans, s)
+	}
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name != "coldget" || spans[0].ParentID != span.ID || spans[0].Tags["object"] != "b/o" {
+		t.Errorf("unexpected span %+v", spans[0])
+	}
+	if spans[1].Name != "target.get" || spans[1].Daemon != "t1" {
+		t.Errorf("unexpected span %+v", spans[1])
+	}
+}
@@ -0,0 +1,72 @@
+// Package transport provides streaming object-based transport over http for intra-cluster continuous
+// intra-cluster communications (see README for details and usage example).
+/*
+ * Copyright (c) 2018, NVIDIA CORPORATION. All rights reserved.
+ */
+package transport
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// min number of bytes that can be sent at once without waiting (see RateLimiter.reserve)
+const minBurst = 64 * cmn.KiB
+
+// RateLimiter is a token bucket that caps the number of bytes per second sent
+// by a single stream or, when shared, by a group of streams (e.g., StreamBundle).
+// Zero rate means unlimited; the rate can be changed at any time.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   int64     // bytes per second
+	tokens float64   // bytes that can be sent right away; negative when owed by the (sleeping) senders
+	last   time.Time // last time the tokens were added
+}
+
+func NewRateLimiter(bytesPerSec int64) *RateLimiter {
+	rl := &RateLimiter{}
+	rl.SetRate(bytesPerSec)
+	return rl
+}
+
+func (rl *RateLimiter) Rate() int64 { return atomic.LoadInt64(&rl.rate) }
+
+func (rl *RateLimiter) SetRate(bytesPerSec int64) {
+	cmn.Assert(bytesPerSec >= 0)
+	rl.mu.Lock()
+	if rl.rate != bytesPerSec {
+		atomic.StoreInt64(&rl.rate, bytesPerSec)
+		rl.tokens, rl.last = 0, time.Now()
+	}
+	rl.mu.Unlock()
+}
+
+// reserve takes n bytes worth of tokens out of the bucket and returns the time
+// the caller must wait before sending (more)
+func (rl *RateLimiter) reserve(n int) (d time.Duration) {
+	if rl.Rate() == 0 {
+		return
+	}
+	rl.mu.Lock()
+	if rl.rate == 0 {
+		rl.mu.Unlock()
+		return
+	}
+	var (
+		now   = time.Now()
+		rate  = float64(rl.rate)
+		burst = math.Max(rate/10, minBurst) // up to 100ms worth
+	)
+	rl.tokens = math.Min(rl.tokens+now.Sub(rl.last).Seconds()*rate, burst)
+	rl.last = now
+	rl.tokens -= float64(n)
+	if rl.tokens < 0 {
+		d = time.Duration(-rl.tokens / rate * float64(time.Second))
+	}
+	rl.mu.Unlock()
+	return
+}
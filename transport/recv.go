@@ -6,12 +6,17 @@
 package transport
 
 import (
+	"bytes"
+	"compress/flate"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,6 +25,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/xoshiro256"
+	"github.com/OneOfOne/xxhash"
 )
 
 //
@@ -35,17 +41,38 @@ type (
 		trname    string
 		body      io.ReadCloser
 		headerBuf []byte
+		dcmp      *decompressor // nil when the objects are not compressed
+		reliable  bool          // the headers carry sequence numbers (see Extra.Reliable)
 	}
 	objReader struct {
-		body io.ReadCloser
-		hdr  Header
-		off  int64
+		body  io.ReadCloser
+		hdr   Header
+		off   int64
+		wire  int64 // bytes read off the wire (differs from off when compressed)
+		seq   int64 // sequence number assigned by the sender in the reliable mode (zero otherwise)
+		dcmp  *decompressor
+		cksum hash.Hash // computed while reading when the checksum is verified
+		err   error     // checksum mismatch, returned by the read that follows the last byte
+	}
+	// delivered tracks the objects of the reliable stream (see Extra.Reliable)
+	// that have been received and passed to the callback
+	delivered struct {
+		mu   sync.Mutex
+		last int64 // sequence number of the last delivered object
+	}
+	// decompressor reads the frames produced by Stream.sendCompressed
+	decompressor struct {
+		fr    io.ReadCloser
+		zbuf  []byte       // compressed frame
+		frame bytes.Buffer // decompressed frame
 	}
 	handler struct {
 		trname      string
 		callback    Receive
+		verifyCksum bool
 		sessions    sync.Map // map[int64]*Stats
 		oldSessions sync.Map // map[int64]time.Time
+		reliable    sync.Map // map[int64]*delivered
 	}
 )
 
@@ -101,7 +128,13 @@ func SetMux(network string, x *mux.ServeMux) {
 // http.ServeMux private map of its URL paths.
 // This map is protected by a private mutex and is read-accessed to route HTTP requests.
 //
-func Register(network, trname string, callback Receive) (path string, err error) {
+// Optionally, the receive side verifies the checksum of each object that carries
+// one (ObjectAttrs.CksumType and CksumValue); the checksum is computed while the
+// object is being read by the Receive callback and, if it does not match, the last
+// read returns cmn.InvalidCksumError instead of io.EOF. Only the endpoints where the
+// checksum describes the content being sent should request it (which is not the case
+// for EC slices, for instance).
+func Register(network, trname string, callback Receive, verifyCksum ...bool) (path string, err error) {
 	mu.Lock()
 	mux, ok := muxers[network]
 	if !ok {
@@ -111,6 +144,9 @@ func Register(network, trname string, callback Receive) (path string, err error)
 	}
 
 	h := &handler{trname: trname, callback: callback}
+	if len(verifyCksum) > 0 {
+		h.verifyCksum = verifyCksum[0]
+	}
 	path = cmn.URLPath(cmn.Version, cmn.Transport, trname)
 	mux.HandleFunc(path, h.receive)
 	if _, ok = handlers[network][trname]; ok {
@@ -160,6 +196,7 @@ func GetNetworkStats(network string) (netstats map[string]EndpointStats, err err
 			out.Num = atomic.LoadInt64(&in.Num)
 			out.Offset = atomic.LoadInt64(&in.Offset)
 			out.Size = atomic.LoadInt64(&in.Size)
+			out.CompressedSize = atomic.LoadInt64(&in.CompressedSize)
 			eps[sessID] = out
 			return true
 		}
@@ -184,7 +221,13 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 		cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("Invalid transport handler name %s - expecting %s", trname, h.trname))
 		return
 	}
-	it := iterator{trname: trname, body: r.Body, headerBuf: make([]byte, maxHeaderSize)}
+	compression := r.Header.Get(compressionHeader)
+	if compression != CompressionNone && compression != CompressionFlate {
+		cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("Unknown compression %s", compression))
+		return
+	}
+	var dlvr *delivered
+	it := newIterator(trname, r.Body, compression, r.Header.Get(reliableHeader) != "")
 	for {
 		var stats *Stats
 		objReader, sessID, hl64, err := it.next()
@@ -195,6 +238,11 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 			}
 			stats = statsif.(*Stats)
 		}
+		if dlvr == nil && objReader != nil && objReader.seq != 0 {
+			dlvrif, _ := h.reliable.LoadOrStore(sessID, &delivered{})
+			dlvr = dlvrif.(*delivered)
+			h.oldSessions.Delete(sessID) // reconnected
+		}
 		if stats != nil && hl64 != 0 {
 			off := atomic.AddInt64(&stats.Offset, hl64)
 			if glog.FastV(4, glog.SmoduleTransport) {
@@ -203,7 +251,16 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 		}
 		if objReader != nil {
 			hdr := objReader.hdr
-			h.callback(w, hdr, objReader, nil)
+			if h.verifyCksum && hdr.ObjAttrs.CksumType == cmn.ChecksumXXHash && hdr.ObjAttrs.CksumValue != "" {
+				objReader.cksum = xxhash.New64()
+			}
+			if dlvr != nil {
+				if !h.deliver(w, dlvr, objReader) {
+					continue // resent, and had been already delivered
+				}
+			} else {
+				h.callback(w, hdr, objReader, nil)
+			}
 			num := atomic.AddInt64(&stats.Num, 1)
 			if hdr.ObjAttrs.Size != objReader.off {
 				err = fmt.Errorf("%s[%d]: stream breakage type #3: reader offset %d != %d object size, num=%d, NAME: %s",
@@ -211,7 +268,8 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 				glog.Errorln(err)
 			} else {
 				siz := atomic.AddInt64(&stats.Size, hdr.ObjAttrs.Size)
-				off := atomic.AddInt64(&stats.Offset, hdr.ObjAttrs.Size)
+				off := atomic.AddInt64(&stats.Offset, objReader.wire)
+				atomic.AddInt64(&stats.CompressedSize, objReader.wire)
 				if glog.FastV(4, glog.SmoduleTransport) {
 					glog.Infof("%s[%d]: offset=%d, size=%d(%d), num=%d - %s", trname, sessID, off, siz, hdr.ObjAttrs.Size, num, hdr.Objname)
 				}
@@ -219,6 +277,12 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		if err != nil {
+			if dlvr != nil {
+				// tell the sender which objects do not need to be resent
+				dlvr.mu.Lock()
+				w.Header().Set(ackHeader, strconv.FormatInt(dlvr.last, 10))
+				dlvr.mu.Unlock()
+			}
 			if sessID != 0 {
 				// delayed cleanup old sessions
 				f := func(key, value interface{}) bool {
@@ -227,6 +291,7 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 					if time.Since(timeClosed) > cleanupTimeout {
 						h.oldSessions.Delete(id)
 						h.sessions.Delete(id)
+						h.reliable.Delete(id)
 					}
 					return true
 				}
@@ -242,6 +307,32 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// deliver passes the object to the callback unless it has been already delivered
+// in one of the previous sessions of the same (reliable) stream, in which case
+// the object is read and discarded; returns false in the latter case
+func (h *handler) deliver(w http.ResponseWriter, dlvr *delivered, objReader *objReader) bool {
+	dlvr.mu.Lock()
+	defer dlvr.mu.Unlock()
+	if objReader.seq <= dlvr.last {
+		objReader.cksum = nil
+		io.Copy(ioutil.Discard, objReader)
+		return false
+	}
+	h.callback(w, objReader.hdr, objReader, nil)
+	if objReader.off == objReader.hdr.ObjAttrs.Size {
+		dlvr.last = objReader.seq
+	}
+	return true
+}
+
+func newIterator(trname string, body io.ReadCloser, compression string, reliable bool) iterator {
+	it := iterator{trname: trname, body: body, headerBuf: make([]byte, maxHeaderSize), reliable: reliable}
+	if compression == CompressionFlate {
+		it.dcmp = &decompressor{fr: flate.NewReader(nil), zbuf: make([]byte, maxCompressedFrameSize)}
+	}
+	return it
+}
+
 func (it iterator) next() (obj *objReader, sessID, hl64 int64, err error) {
 	var (
 		n   int
@@ -279,7 +370,8 @@ func (it iterator) next() (obj *objReader, sessID, hl64 int64, err error) {
 	if debug {
 		cmn.AssertMsg(n == hlen, fmt.Sprintf("%d != %d", n, hlen))
 	}
-	hdr, sessID = ExtHeader(it.headerBuf, hlen)
+	var seq int64
+	hdr, sessID, seq = extHeader(it.headerBuf, hlen, it.reliable)
 	if hdr.IsLast() {
 		if glog.FastV(4, glog.SmoduleTransport) {
 			glog.Infof("%s[%d]: last", it.trname, sessID)
@@ -290,17 +382,45 @@ func (it iterator) next() (obj *objReader, sessID, hl64 int64, err error) {
 	if glog.FastV(4, glog.SmoduleTransport) {
 		glog.Infof("%s[%d]: new object %s size=%d", it.trname, sessID, hdr.Objname, hdr.ObjAttrs.Size)
 	}
-	obj = &objReader{body: it.body, hdr: hdr}
+	obj = &objReader{body: it.body, hdr: hdr, seq: seq, dcmp: it.dcmp}
 	return
 }
 
 func (obj *objReader) Read(b []byte) (n int, err error) {
+	if obj.err != nil {
+		return 0, obj.err
+	}
+	if obj.dcmp != nil {
+		n, err = obj.readCompressed(b)
+	} else {
+		n, err = obj.read(b)
+	}
+	if obj.cksum != nil {
+		obj.cksum.Write(b[:n])
+		if err == io.EOF && obj.off == obj.hdr.ObjAttrs.Size {
+			if cksum := cmn.HashToStr(obj.cksum); cksum != obj.hdr.ObjAttrs.CksumValue {
+				glog.Errorf("%s/%s: checksum mismatch: %s != %s (expected)", obj.hdr.Bucket, obj.hdr.Objname, cksum, obj.hdr.ObjAttrs.CksumValue)
+				obj.err = cmn.NewInvalidCksumError(obj.hdr.ObjAttrs.CksumValue, cksum)
+				// io.ReadFull and such drop the error that comes with the last bytes
+				if n == 0 {
+					err = obj.err
+				} else {
+					err = nil
+				}
+			}
+		}
+	}
+	return
+}
+
+func (obj *objReader) read(b []byte) (n int, err error) {
 	rem := obj.hdr.ObjAttrs.Size - obj.off
 	if rem < int64(len(b)) {
 		b = b[:int(rem)]
 	}
 	n, err = obj.body.Read(b)
 	obj.off += int64(n)
+	obj.wire += int64(n)
 	switch err {
 	case nil:
 		if obj.off >= obj.hdr.ObjAttrs.Size {
@@ -319,10 +439,70 @@ func (obj *objReader) Read(b []byte) (n int, err error) {
 	return
 }
 
+func (obj *objReader) readCompressed(b []byte) (n int, err error) {
+	if obj.off >= obj.hdr.ObjAttrs.Size {
+		return 0, io.EOF
+	}
+	d := obj.dcmp
+	if d.frame.Len() == 0 {
+		var wire int64
+		wire, err = d.next(obj.body)
+		obj.wire += wire
+		if err != nil {
+			glog.Errorf("%s/%s: failed to read compressed frame: %v", obj.hdr.Bucket, obj.hdr.Objname, err)
+			return
+		}
+	}
+	rem := obj.hdr.ObjAttrs.Size - obj.off
+	if rem < int64(len(b)) {
+		b = b[:int(rem)]
+	}
+	n, _ = d.frame.Read(b)
+	obj.off += int64(n)
+	if obj.off >= obj.hdr.ObjAttrs.Size {
+		if d.frame.Len() != 0 {
+			err = fmt.Errorf("%s/%s: decompressed size exceeds %d object size", obj.hdr.Bucket, obj.hdr.Objname, obj.hdr.ObjAttrs.Size)
+			d.frame.Reset()
+			return
+		}
+		err = io.EOF
+	}
+	return
+}
+
+// next reads and decompresses the next frame; returns the number of bytes read off the wire
+func (d *decompressor) next(body io.Reader) (wire int64, err error) {
+	var n int
+	n, err = io.ReadFull(body, d.zbuf[:sizeofI64])
+	wire += int64(n)
+	if err != nil {
+		return
+	}
+	_, zlen := extInt64(0, d.zbuf)
+	if zlen <= 0 || zlen > int64(len(d.zbuf)) {
+		err = fmt.Errorf("stream breakage type #4: compressed frame length %d", zlen)
+		return
+	}
+	n, err = io.ReadFull(body, d.zbuf[:zlen])
+	wire += int64(n)
+	if err != nil {
+		return
+	}
+	d.fr.(flate.Resetter).Reset(bytes.NewReader(d.zbuf[:zlen]), nil)
+	d.frame.Reset()
+	_, err = d.frame.ReadFrom(io.LimitReader(d.fr, compressFrameSize))
+	return
+}
+
 //
 // helpers
 //
 func ExtHeader(body []byte, hlen int) (hdr Header, sessID int64) {
+	hdr, sessID, _ = extHeader(body, hlen, false)
+	return
+}
+
+func extHeader(body []byte, hlen int, reliable bool) (hdr Header, sessID, seq int64) {
 	var off int
 	off, hdr.Bucket = extString(0, body)
 	off, hdr.Objname = extString(off, body)
@@ -330,6 +510,9 @@ func ExtHeader(body []byte, hlen int) (hdr Header, sessID int64) {
 	off, hdr.Opaque = extByte(off, body)
 	off, hdr.ObjAttrs = extAttrs(off, body)
 	off, sessID = extInt64(off, body)
+	if reliable {
+		off, seq = extInt64(off, body)
+	}
 	if debug {
 		cmn.AssertMsg(off == hlen, fmt.Sprintf("off %d, hlen %d", off, hlen))
 	}
@@ -369,5 +552,6 @@ func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, attr.UserMeta = extString(off, from)
 	return off, attr
 }
@@ -6,6 +6,8 @@
 package transport
 
 import (
+	"bytes"
+	"compress/flate"
 	"container/heap"
 	"context"
 	"encoding/binary"
@@ -29,7 +31,7 @@ import (
 
 // transport defaults
 const (
-	maxHeaderSize  = 1024
+	maxHeaderSize  = 4 * cmn.KiB // to accommodate user-defined object metadata (up to cmn.MaxUserMetaSize)
 	lastMarker     = cmn.MaxInt64
 	tickMarker     = cmn.MaxInt64 ^ 0xa5a5a5a5
 	tickUnit       = time.Second
@@ -38,6 +40,29 @@ const (
 	burstNum       = 32 // default max num objects that can be posted for sending without any back-pressure
 )
 
+// on-the-wire compression of the objects (see Extra.Compression)
+const (
+	CompressionNone  = ""
+	CompressionFlate = "flate"
+
+	compressionHeader = "Ais-Transport-Compression" // HTTP header that tells the receiver how to read the objects
+	compressFrameSize = 64 * cmn.KiB                // max uncompressed size of a single frame
+	// max compressed size of a single frame - stored (incompressible) data
+	// grows by 5 bytes per (up to) 64KiB flate block
+	maxCompressedFrameSize = compressFrameSize + cmn.KiB
+)
+
+// reliable mode (see Extra.Reliable)
+const (
+	reliableHeader = "Ais-Transport-Reliable" // HTTP request header: object headers carry sequence numbers
+	ackHeader      = "Ais-Transport-Ack"      // HTTP response header: sequence number of the last delivered object
+	maxUnacked     = 256                      // max objects sent but not acknowledged - when reached, the session ends to get the ack
+	ackTimeout     = 100 * time.Millisecond   // nothing else to send: end the session to get the ack after this long
+	ackInterval    = time.Second              // max time an object waits for the ack while the stream is busy
+	maxRetries     = 5                        // max consecutive failed sessions before the stream terminates
+	retryDelayUnit = time.Second              // delay before reconnecting: retryDelayUnit * number of failed sessions
+)
+
 // stream TCP/HTTP session: inactive <=> active transitions
 const (
 	inactive = iota
@@ -78,11 +103,29 @@ type (
 			ticks   int           // num 1s ticks until idle timeout
 			index   int           // heap stuff
 		}
-		wg        sync.WaitGroup
-		sendoff   sendoff
-		maxheader []byte // max header buffer
-		header    []byte // object header - slice of the maxheader with bucket/objname, etc. fields
-		term      struct {
+		wg          sync.WaitGroup
+		sendoff     sendoff
+		maxheader   []byte // max header buffer
+		header      []byte // object header - slice of the maxheader with bucket/objname, etc. fields
+		compression string // one of: CompressionNone, CompressionFlate
+		cmpr        struct {
+			fw    *flate.Writer
+			frame []byte       // uncompressed frame read from the object reader
+			buf   bytes.Buffer // compressed frame being sent
+		}
+		limiter *RateLimiter // this stream's bandwidth limit (see Extra.MaxBandwidth)
+		shared  *RateLimiter // optional limit shared with other streams (see Extra.Limiter)
+		rel     struct {     // reliable mode
+			enabled bool
+			seq     int64     // sequence number of the last object taken off SQ
+			unacked []obj     // sent but not yet acknowledged by the receiver, in order
+			resendq []obj     // to be sent again upon reconnecting (takes precedence over SQ)
+			renew   bool      // the session has ended to get the ack - start the next one right away
+			fin     bool      // last marker has been sent (and end-of-stream signaled)
+			retries int       // consecutive failed sessions
+			oldest  time.Time // when the oldest unacknowledged object was sent
+		}
+		term struct {
 			barr   int64
 			err    error
 			reason *string
@@ -95,15 +138,32 @@ type (
 		Callback    SendCallback    // typical usage: to free SGLs, close files, etc.
 		Burst       int             // SQ and CSQ buffer sizes: max num objects and send-completions
 		DryRun      bool            // dry run: short-circuit the stream on the send side
+		Compression string          // on-the-wire compression of objects: CompressionNone (default) | CompressionFlate
+		// MaxBandwidth limits the stream to the specified number of bytes per second
+		// (zero - unlimited); can be adjusted at runtime via Stream.SetMaxBandwidth.
+		MaxBandwidth int64
+		// Limiter, if specified, limits the bandwidth of all the streams that share it
+		// in addition to MaxBandwidth (e.g., StreamBundle).
+		Limiter *RateLimiter
+		// ConfigBandwidth (StreamBundle only) makes the bundle take MaxBandwidth and
+		// Limiter from the cluster configuration and follow its updates; meant for
+		// background traffic (e.g., rebalance) that must leave room for the client I/O.
+		ConfigBandwidth bool
+		// Reliable enables resending of the objects that have not been acknowledged by the receiver
+		// when the HTTP session fails - the stream reconnects and the receiver reads each object
+		// in full exactly once and in order (an object that breaks off mid-way is delivered
+		// again, see README). The object readers must implement cmn.ReadOpenCloser.
+		Reliable bool
 	}
 	// stream stats
 	Stats struct {
-		Num     int64   // number of transferred objects
-		Size    int64   // transferred size, in bytes
-		Offset  int64   // stream offset, in bytes
-		IdleDur int64   // the time stream was idle since the previous GetStats call
-		TotlDur int64   // total time since --/---/---
-		IdlePct float64 // idle time % since --/---/--
+		Num            int64   // number of transferred objects
+		Size           int64   // transferred size, in bytes
+		CompressedSize int64   // transferred size on the wire, in bytes (less than Size when compressed)
+		Offset         int64   // stream offset, in bytes
+		IdleDur        int64   // the time stream was idle since the previous GetStats call
+		TotlDur        int64   // total time since --/---/---
+		IdlePct        float64 // idle time % since --/---/--
 	}
 	EndpointStats map[int64]*Stats // all stats for a given http endpoint defined by a tuple (network, trname) by session ID
 
@@ -114,6 +174,7 @@ type (
 		CksumType  string // checksum type
 		CksumValue string // checksum of the object produced by given checksum type
 		Version    string // version of the object
+		UserMeta   string // user-defined metadata (as per cmn.EncodeUserMeta)
 	}
 
 	// object header
@@ -144,17 +205,27 @@ type (
 		reader   io.ReadCloser // reader, to read the object, and close when done
 		callback SendCallback  // callback fired when sending is done OR when the stream terminates (see term.reason)
 		prc      *int64        // optional refcount; if present, SendCallback gets called if and when *prc reaches zero
+		seq      int64         // sequence number (reliable mode only)
+		reopened io.ReadCloser // reader reopened to resend the object (reliable mode only)
 	}
 	sendoff struct {
 		obj obj
 		// in progress
-		off int64
-		dod int64
+		off  int64
+		dod  int64
+		wire int64 // object bytes sent on the wire (differs from off when compressed)
 	}
 	cmpl struct { // send completions => SCQ
 		obj obj
 		err error
 	}
+	// session is the body of the HTTP request in the reliable mode - allows to wait
+	// until the HTTP client stops reading the stream
+	session struct {
+		s      *Stream
+		closed chan struct{}
+		once   sync.Once
+	}
 	nopReadCloser struct{}
 
 	collector struct {
@@ -179,7 +250,9 @@ var (
 )
 
 // default HTTP client to be used with streams
-func NewDefaultClient() *http.Client { return cmn.NewClient(cmn.ClientArgs{IdleConnsPerHost: 1000}) }
+func NewDefaultClient() *http.Client {
+	return cmn.NewClient(cmn.ClientArgs{IdleConnsPerHost: 1000, TLSConfig: cmn.IntraClusterTLS()})
+}
 
 //
 // Stream Collector - a singleton object with responsibilities that include:
@@ -325,7 +398,7 @@ func NewStream(client *http.Client, toURL string, extra *Extra) (s *Stream) {
 		glog.Errorf("Failed to parse %s: %v", toURL, err)
 		return
 	}
-	s = &Stream{client: client, toURL: toURL}
+	s = &Stream{client: client, toURL: toURL, limiter: NewRateLimiter(0)}
 
 	s.time.idleOut = defaultIdleOut
 	if extra != nil {
@@ -335,6 +408,10 @@ func NewStream(client *http.Client, toURL string, extra *Extra) (s *Stream) {
 		}
 		dryrun = extra.DryRun
 		cmn.Assert(dryrun || client != nil)
+		s.compression = extra.Compression
+		s.rel.enabled = extra.Reliable && !dryrun
+		s.limiter.SetRate(extra.MaxBandwidth)
+		s.shared = extra.Limiter
 	}
 	if s.time.idleOut < tickUnit {
 		s.time.idleOut = tickUnit
@@ -366,6 +443,14 @@ func NewStream(client *http.Client, toURL string, extra *Extra) (s *Stream) {
 	s.postCh = make(chan struct{}, 1)
 	s.maxheader = make([]byte, maxHeaderSize) // NOTE: must be large enough to accommodate all max-size Header
 	atomic.StoreInt64(&s.sessST, inactive)    // NOTE: initiate HTTP session upon arrival of the first object
+	switch s.compression {
+	case CompressionNone:
+	case CompressionFlate:
+		s.cmpr.fw, _ = flate.NewWriter(nil, flate.BestSpeed)
+		s.cmpr.frame = make([]byte, compressFrameSize)
+	default:
+		cmn.AssertMsg(false, "unknown compression "+s.compression)
+	}
 
 	var ctx context.Context
 	if extra != nil && extra.Ctx != nil {
@@ -428,6 +513,11 @@ func (s *Stream) Send(hdr Header, reader io.ReadCloser, callback SendCallback, p
 	if reader == nil {
 		cmn.Assert(hdr.IsHeaderOnly())
 		reader = nopRC
+	} else if _, ok := reader.(cmn.ReadOpenCloser); s.rel.enabled && !ok && !hdr.IsHeaderOnly() {
+		err = fmt.Errorf("%s: cannot send [%s/%s(%d)] in reliable mode: reader does not implement Open",
+			s, hdr.Bucket, hdr.Objname, hdr.ObjAttrs.Size)
+		glog.Errorln(err)
+		return
 	}
 	obj := obj{hdr: hdr, reader: reader, callback: callback}
 	if len(prc) > 0 {
@@ -447,6 +537,9 @@ func (s *Stream) Fin() (err error) {
 	return
 }
 
+// SetMaxBandwidth changes the stream's bandwidth limit (bytes per second, zero - unlimited)
+func (s *Stream) SetMaxBandwidth(bytesPerSec int64) { s.limiter.SetRate(bytesPerSec) }
+
 func (s *Stream) Stop()               { s.stopCh <- struct{}{} }
 func (s *Stream) URL() string         { return s.toURL }
 func (s *Stream) ID() (string, int64) { return s.trname, s.sessID }
@@ -468,6 +561,7 @@ func (s *Stream) GetStats() (stats Stats) {
 	stats.Num = atomic.LoadInt64(&s.stats.Num)
 	stats.Offset = atomic.LoadInt64(&s.stats.Offset)
 	stats.Size = atomic.LoadInt64(&s.stats.Size)
+	stats.CompressedSize = atomic.LoadInt64(&s.stats.CompressedSize)
 	// idle(%)
 	now := time.Now().UnixNano()
 	stats.TotlDur = now - atomic.LoadInt64(&s.time.start)
@@ -492,9 +586,17 @@ func (s *Stream) sendLoop(ctx context.Context, dryrun bool) {
 			if dryrun {
 				s.dryrun()
 			} else if err := s.doRequest(ctx); err != nil {
-				*s.term.reason = reasonError
-				s.term.err = err
+				if s.rel.enabled && s.resend(ctx, err) {
+					continue
+				}
+				if *s.term.reason == "" {
+					*s.term.reason = reasonError
+					s.term.err = err
+				}
 				break
+			} else if s.rel.renew {
+				s.rel.renew = false
+				continue
 			}
 		}
 		if !s.isNextReq(ctx) {
@@ -524,6 +626,14 @@ func (s *Stream) sendLoop(ctx context.Context, dryrun bool) {
 			obj := &s.sendoff.obj
 			s.objDone(obj, s.term.err)
 		}
+		// reliable mode: objects that have never been acknowledged
+		for _, objs := range [][]obj{s.rel.unacked, s.rel.resendq} {
+			for i := range objs {
+				if !objs[i].hdr.IsLast() {
+					s.objDone(&objs[i], s.term.err)
+				}
+			}
+		}
 		// finally, handle pending SQ
 		for obj := range s.workCh {
 			s.objDone(&obj, s.term.err)
@@ -562,6 +672,9 @@ func (s *Stream) objDone(obj *obj, err error) {
 	if obj.reader != nil {
 		obj.reader.Close() // NOTE: always closing
 	}
+	if obj.reopened != nil && obj.reopened != obj.reader {
+		obj.reopened.Close()
+	}
 }
 
 func (s *Stream) isNextReq(ctx context.Context) (next bool) {
@@ -598,13 +711,25 @@ func (s *Stream) doRequest(ctx context.Context) (err error) {
 	var (
 		request  *http.Request
 		response *http.Response
+		body     io.Reader = s
+		sess     *session
 	)
-	if request, err = http.NewRequest(http.MethodPut, s.toURL, s); err != nil {
+	if s.rel.enabled {
+		sess = &session{s: s, closed: make(chan struct{})}
+		body = sess
+	}
+	if request, err = http.NewRequest(http.MethodPut, s.toURL, body); err != nil {
 		return
 	}
 	if ctx != background {
 		request = request.WithContext(ctx)
 	}
+	if s.compression != CompressionNone {
+		request.Header.Set(compressionHeader, s.compression)
+	}
+	if s.rel.enabled {
+		request.Header.Set(reliableHeader, "true")
+	}
 	s.Numcur, s.Sizecur = 0, 0
 	if glog.FastV(4, glog.SmoduleTransport) {
 		glog.Infof("%s: Do", s)
@@ -616,10 +741,17 @@ func (s *Stream) doRequest(ctx context.Context) (err error) {
 		}
 	} else {
 		glog.Errorf("%s: Error [%v]", s, err)
+		if sess != nil {
+			<-sess.closed // the client may still be reading the stream
+		}
 		return
 	}
 	ioutil.ReadAll(response.Body)
 	response.Body.Close()
+	if sess != nil {
+		<-sess.closed
+		err = s.ack(response)
+	}
 	return
 }
 
@@ -642,7 +774,28 @@ func (s *Stream) Read(b []byte) (n int, err error) {
 		}
 	}
 repeat:
+	if s.rel.enabled {
+		if len(s.rel.resendq) > 0 {
+			s.sendoff.obj = s.rel.resendq[0]
+			s.rel.resendq = s.rel.resendq[1:]
+			if err = s.reopen(); err != nil {
+				goto repeat
+			}
+			l := s.insHeader(s.sendoff.obj)
+			s.header = s.maxheader[:l]
+			return s.sendHdr(b)
+		}
+		if l := len(s.rel.unacked); l >= maxUnacked || (l > 0 && time.Since(s.rel.oldest) > ackInterval) {
+			return s.renewSession()
+		}
+	}
+	var ackCh <-chan time.Time
+	if s.rel.enabled && len(s.rel.unacked) > 0 {
+		ackCh = time.After(ackTimeout)
+	}
 	select { // ignoring idle time spent here to optimize-out addIdle(time.Now()) overhead
+	case <-ackCh:
+		return s.renewSession()
 	case s.sendoff.obj = <-s.workCh: // next object OR idle tick
 		if s.sendoff.obj.hdr.IsIdleTick() {
 			if len(s.workCh) > 0 {
@@ -650,7 +803,11 @@ repeat:
 			}
 			return s.deactivate()
 		}
-		l := s.insHeader(s.sendoff.obj.hdr)
+		if s.rel.enabled && !s.sendoff.obj.hdr.IsLast() {
+			s.rel.seq++
+			s.sendoff.obj.seq = s.rel.seq
+		}
+		l := s.insHeader(s.sendoff.obj)
 		s.header = s.maxheader[:l]
 		return s.sendHdr(b)
 	case <-s.stopCh:
@@ -688,8 +845,11 @@ func (s *Stream) sendHdr(b []byte) (n int, err error) {
 				glog.Infof("%s: sent last", s)
 			}
 			err = io.EOF
-			s.lastCh <- struct{}{}
-			close(s.lastCh)
+			if !s.rel.fin {
+				s.rel.fin = s.rel.enabled
+				s.lastCh <- struct{}{}
+				close(s.lastCh)
+			}
 		}
 	} else if glog.FastV(4, glog.SmoduleTransport) {
 		glog.Infof("%s: split header: copied %d < %d hlen", s, s.sendoff.off, len(s.header))
@@ -698,8 +858,13 @@ func (s *Stream) sendHdr(b []byte) (n int, err error) {
 }
 
 func (s *Stream) sendData(b []byte) (n int, err error) {
-	n, err = s.sendoff.obj.reader.Read(b)
+	if s.compression != CompressionNone {
+		return s.sendCompressed(b)
+	}
+	n, err = s.sendoff.obj.dataReader().Read(b)
 	s.sendoff.off += int64(n) // (avg send transfer size tbd)
+	s.sendoff.wire += int64(n)
+	s.throttle(n)
 	if err != nil {
 		if err == io.EOF {
 			err = nil
@@ -711,6 +876,68 @@ func (s *Stream) sendData(b []byte) (n int, err error) {
 	return
 }
 
+// sendCompressed sends the object as a sequence of independently compressed
+// frames, each prefixed with its (compressed) length: [len][data][len][data]...
+func (s *Stream) sendCompressed(b []byte) (n int, err error) {
+	if s.cmpr.buf.Len() == 0 {
+		if err = s.compressFrame(); err != nil {
+			s.cmpr.buf.Reset()
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				err = nil // eoObj will report the offset != size
+			}
+			s.eoObj(err)
+			return
+		}
+	}
+	n, _ = s.cmpr.buf.Read(b)
+	s.sendoff.wire += int64(n)
+	s.throttle(n)
+	if s.cmpr.buf.Len() == 0 && s.sendoff.off >= s.sendoff.obj.hdr.ObjAttrs.Size {
+		s.eoObj(nil)
+	}
+	return
+}
+
+// throttle waits, if need be, to stay within the bandwidth limits
+func (s *Stream) throttle(n int) {
+	d := s.limiter.reserve(n)
+	if s.shared != nil {
+		if ds := s.shared.reserve(n); ds > d {
+			d = ds
+		}
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+func (s *Stream) compressFrame() (err error) {
+	var (
+		n     int
+		rem   = s.sendoff.obj.hdr.ObjAttrs.Size - s.sendoff.off
+		frame = s.cmpr.frame
+	)
+	if rem < int64(len(frame)) {
+		frame = frame[:int(rem)]
+	}
+	n, err = io.ReadFull(s.sendoff.obj.dataReader(), frame)
+	s.sendoff.off += int64(n)
+	if err != nil {
+		return
+	}
+	s.cmpr.buf.Reset()
+	s.cmpr.buf.Write(make([]byte, sizeofI64)) // placeholder for the frame length
+	s.cmpr.fw.Reset(&s.cmpr.buf)
+	if _, err = s.cmpr.fw.Write(frame); err != nil {
+		return
+	}
+	if err = s.cmpr.fw.Close(); err != nil {
+		return
+	}
+	insInt64(0, s.cmpr.buf.Bytes(), int64(s.cmpr.buf.Len()-sizeofI64))
+	return
+}
+
 //
 // end-of-object: updates stats, reset idle timeout, and post completion
 // NOTE: reader.Close() is done by the completion handling code objDone
@@ -719,8 +946,9 @@ func (s *Stream) eoObj(err error) {
 	obj := &s.sendoff.obj
 
 	s.Sizecur += s.sendoff.off
-	atomic.AddInt64(&s.stats.Offset, s.sendoff.off)
+	atomic.AddInt64(&s.stats.Offset, s.sendoff.wire)
 	atomic.AddInt64(&s.stats.Size, s.sendoff.off)
+	atomic.AddInt64(&s.stats.CompressedSize, s.sendoff.wire)
 
 	if err != nil {
 		goto exit
@@ -741,8 +969,15 @@ exit:
 		glog.Errorln(err)
 	}
 
-	// next completion => SCQ
-	s.cmplCh <- cmpl{s.sendoff.obj, err}
+	// next completion => SCQ (or, in reliable mode, wait for the receiver to acknowledge)
+	if s.rel.enabled && err == nil {
+		if len(s.rel.unacked) == 0 {
+			s.rel.oldest = time.Now()
+		}
+		s.rel.unacked = append(s.rel.unacked, s.sendoff.obj)
+	} else {
+		s.cmplCh <- cmpl{s.sendoff.obj, err}
+	}
 
 	s.sendoff = sendoff{}
 }
@@ -750,7 +985,8 @@ exit:
 //
 // stream helpers
 //
-func (s *Stream) insHeader(hdr Header) (l int) {
+func (s *Stream) insHeader(obj obj) (l int) {
+	hdr := obj.hdr
 	l = sizeofI64 * 2
 	l = insString(l, s.maxheader, hdr.Bucket)
 	l = insString(l, s.maxheader, hdr.Objname)
@@ -758,6 +994,9 @@ func (s *Stream) insHeader(hdr Header) (l int) {
 	l = insByte(l, s.maxheader, hdr.Opaque)
 	l = insAttrs(l, s.maxheader, hdr.ObjAttrs)
 	l = insInt64(l, s.maxheader, s.sessID)
+	if s.rel.enabled {
+		l = insInt64(l, s.maxheader, obj.seq)
+	}
 	hlen := l - sizeofI64*2
 	insInt64(0, s.maxheader, int64(hlen))
 	checksum := xoshiro256.Hash(uint64(hlen))
@@ -801,6 +1040,7 @@ func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insString(off, to, attr.UserMeta)
 	return off
 }
 
@@ -813,7 +1053,7 @@ func (s *Stream) addIdle(beg time.Time) { atomic.AddInt64(&s.stats.IdleDur, int6
 func (s *Stream) dryrun() {
 	buf := make([]byte, cmn.KiB*32)
 	scloser := ioutil.NopCloser(s)
-	it := iterator{trname: s.trname, body: scloser, headerBuf: make([]byte, maxHeaderSize)}
+	it := newIterator(s.trname, scloser, s.compression, s.rel.enabled)
 	for {
 		objReader, _, _, err := it.next()
 		if objReader != nil {
@@ -827,6 +1067,113 @@ func (s *Stream) dryrun() {
 	}
 }
 
+//
+// reliable mode ---------------------------
+//
+
+// renewSession ends the session to get the ack; the next session starts right away
+func (s *Stream) renewSession() (n int, err error) {
+	s.rel.renew = true
+	err = io.EOF
+	return
+}
+
+// ack completes the objects acknowledged by the receiver upon the end of the session
+func (s *Stream) ack(response *http.Response) error {
+	if a := response.Header.Get(ackHeader); a != "" {
+		seq, err := strconv.ParseInt(a, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid ack %q: %v", s, a, err)
+		}
+		i := 0
+		for ; i < len(s.rel.unacked) && s.rel.unacked[i].seq <= seq; i++ {
+			s.cmplCh <- cmpl{s.rel.unacked[i], nil}
+		}
+		s.rel.unacked = s.rel.unacked[i:]
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s: session failed with status %d", s, response.StatusCode)
+	}
+	if len(s.rel.unacked) > 0 {
+		return fmt.Errorf("%s: %d object(s) have not been acknowledged", s, len(s.rel.unacked))
+	}
+	s.rel.retries = 0
+	return nil
+}
+
+// resend prepares the objects that have not been acknowledged to be sent again
+// in the next session; returns false when the stream must terminate
+func (s *Stream) resend(ctx context.Context, err error) bool {
+	// the object that was being sent when the session failed
+	last := s.rel.fin
+	if s.sendoff.obj.reader != nil {
+		if s.sendoff.obj.hdr.IsLast() {
+			last = true
+		} else {
+			s.rel.unacked = append(s.rel.unacked, s.sendoff.obj)
+		}
+	}
+	s.sendoff = sendoff{}
+	s.cmpr.buf.Reset()
+
+	s.rel.retries++
+	if s.rel.retries > maxRetries {
+		glog.Errorf("%s: giving up after %d failed sessions", s, maxRetries)
+		return false
+	}
+	glog.Warningf("%s: session failed (%v), resending %d object(s), retry #%d",
+		s, err, len(s.rel.unacked)+len(s.rel.resendq), s.rel.retries)
+	select {
+	case <-time.After(retryDelayUnit * time.Duration(s.rel.retries)):
+	case <-ctx.Done():
+		*s.term.reason = reasonCanceled
+		s.term.err = ctx.Err()
+		return false
+	case <-s.stopCh:
+		*s.term.reason = reasonStopped
+		return false
+	}
+	// unacknowledged objects go first, in order
+	s.rel.resendq = append(s.rel.unacked, s.rel.resendq...)
+	s.rel.unacked = nil
+	if last && (len(s.rel.resendq) == 0 || !s.rel.resendq[len(s.rel.resendq)-1].hdr.IsLast()) {
+		s.rel.resendq = append(s.rel.resendq, obj{hdr: Header{ObjAttrs: ObjectAttrs{Size: lastMarker}}, reader: nopRC})
+	}
+	atomic.StoreInt64(&s.sessST, active)
+	return true
+}
+
+// reopen opens the reader of the object that is about to be resent
+func (s *Stream) reopen() (err error) {
+	obj := &s.sendoff.obj
+	if obj.hdr.IsHeaderOnly() {
+		return
+	}
+	if obj.reopened != nil && obj.reopened != obj.reader {
+		obj.reopened.Close()
+	}
+	if obj.reopened, err = obj.reader.(cmn.ReadOpenCloser).Open(); err != nil {
+		glog.Errorf("%s: failed to reopen %s/%s for resending: %v", s, obj.hdr.Bucket, obj.hdr.Objname, err)
+		obj.reopened = nil
+		s.cmplCh <- cmpl{*obj, err}
+		s.sendoff = sendoff{}
+	}
+	return
+}
+
+func (obj *obj) dataReader() io.Reader {
+	if obj.reopened != nil {
+		return obj.reopened
+	}
+	return obj.reader
+}
+
+func (sess *session) Read(b []byte) (int, error) { return sess.s.Read(b) }
+func (sess *session) Close() error {
+	sess.once.Do(func() { close(sess.closed) })
+	return nil
+}
+
 //
 // nopReadCloser ---------------------------
 //
@@ -78,6 +78,12 @@ func NewStreamBundle(sowner cluster.Sowner, lsnode *cluster.Snode, cl *http.Clie
 	if extra != nil {
 		sb.extra = *extra
 	}
+	if sb.extra.ConfigBandwidth {
+		// bandwidth limits follow the cluster config (see ConfigUpdate)
+		config := cmn.GCO.Get()
+		sb.extra.MaxBandwidth = config.Transport.MaxStreamBandwidth
+		sb.extra.Limiter = NewRateLimiter(config.Transport.MaxBundleBandwidth)
+	}
 	if len(multiplier) > 0 {
 		cmn.Assert(multiplier[0] > 1 && multiplier[0] < 256)
 		sb.multiplier = multiplier[0]
@@ -88,6 +94,9 @@ func NewStreamBundle(sowner cluster.Sowner, lsnode *cluster.Snode, cl *http.Clie
 	sb.resync()
 
 	listeners.Reg(sb)
+	if sb.extra.ConfigBandwidth {
+		cmn.GCO.Subscribe(sb)
+	}
 	return
 }
 
@@ -101,6 +110,9 @@ func (sb *StreamBundle) Close(gracefully bool) {
 	}
 	listeners := sb.sowner.Listeners()
 	listeners.Unreg(sb)
+	if sb.extra.ConfigBandwidth {
+		cmn.GCO.Unsubscribe(sb)
+	}
 }
 
 //
@@ -187,6 +199,31 @@ func (sb *StreamBundle) SmapChanged() {
 	sb.resync()
 }
 
+// SetMaxBandwidth changes the bandwidth limits (bytes per second, zero - unlimited)
+// of each of the bundled streams and of all of them combined (the latter requires
+// the bundle to have been created with Extra.Limiter or Extra.ConfigBandwidth)
+func (sb *StreamBundle) SetMaxBandwidth(perStream, total int64) {
+	sb.smaplock.Lock()
+	sb.extra.MaxBandwidth = perStream // streams to be added upon resync
+	for _, robin := range sb.get() {
+		for _, s := range robin.stsdest {
+			s.SetMaxBandwidth(perStream)
+		}
+	}
+	sb.smaplock.Unlock()
+	if sb.extra.Limiter != nil {
+		sb.extra.Limiter.SetRate(total)
+	}
+}
+
+// adjust bandwidth limits at runtime (see Extra.ConfigBandwidth)
+func (sb *StreamBundle) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if oldConf.Transport == newConf.Transport {
+		return
+	}
+	sb.SetMaxBandwidth(newConf.Transport.MaxStreamBandwidth, newConf.Transport.MaxBundleBandwidth)
+}
+
 func (sb *StreamBundle) apply(action int) {
 	streams := sb.get()
 	for _, robin := range streams {
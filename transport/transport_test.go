@@ -21,8 +21,10 @@ package transport_test
 //
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -35,6 +37,7 @@ import (
 	"path"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -118,8 +121,8 @@ func Example_headers() {
 	sendText(stream, text1, text2)
 	stream.Fin()
 	// Output:
-	// {Bucket:abc Objname:X IsLocal:false Opaque:[] ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2}} (96)
-	// {Bucket:abracadabra Objname:p/q/s IsLocal:true Opaque:[49 50 51] ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2}} (111)
+	// {Bucket:abc Objname:X IsLocal:false Opaque:[] ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2 UserMeta:}} (104)
+	// {Bucket:abracadabra Objname:p/q/s IsLocal:true Opaque:[49 50 51] ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2 UserMeta:}} (119)
 }
 
 func sendText(stream *transport.Stream, txt1, txt2 string) {
@@ -494,10 +497,347 @@ func Test_ObjAttrs(t *testing.T) {
 	}
 }
 
+func Test_CompressedStream(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var (
+		random  = newRand(time.Now().UnixNano())
+		objects = make(map[string][]byte)
+		sizes   = []int{1, len(text), 64*cmn.KiB - 1, 64 * cmn.KiB, 64*cmn.KiB + 1, 3*cmn.MiB + 17}
+	)
+	for idx, size := range sizes {
+		object := bytes.Repeat([]byte(text), size/len(text)+1)[:size]
+		objects[fmt.Sprintf("text-%d", idx)] = object
+		object = make([]byte, size)
+		random.Read(object)
+		objects[fmt.Sprintf("rand-%d", idx)] = object
+	}
+
+	receivedCount := int64(0)
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		cmn.Assert(err == nil)
+		object, err := ioutil.ReadAll(objReader)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if !bytes.Equal(object, objects[hdr.Objname]) {
+			t.Errorf("%s: received content differs (size %d, expected %d)", hdr.Objname, len(object), len(objects[hdr.Objname]))
+		}
+		atomic.AddInt64(&receivedCount, 1)
+	}
+	path, err := transport.Register("n1", "compressed", recvFunc)
+	tutils.CheckFatal(err, t)
+
+	httpclient := &http.Client{Transport: &http.Transport{}}
+	url := ts.URL + path
+	stream := transport.NewStream(httpclient, url, &transport.Extra{Compression: transport.CompressionFlate})
+	for name, object := range objects {
+		hdr := transport.Header{Bucket: "a", Objname: name, ObjAttrs: transport.ObjectAttrs{Size: int64(len(object))}}
+		if err := stream.Send(hdr, ioutil.NopCloser(bytes.NewReader(object)), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.Fin()
+
+	if receivedCount != int64(len(objects)) {
+		t.Fatalf("invalid received count: %d, expected: %d", receivedCount, len(objects))
+	}
+	stats := stream.GetStats()
+	if stats.CompressedSize >= stats.Size {
+		t.Errorf("send$ %s: compressed size %d >= %d size", stream, stats.CompressedSize, stats.Size)
+	}
+	netstats, err := transport.GetNetworkStats("n1")
+	tutils.CheckFatal(err, t)
+	_, sessid := stream.ID()
+	recvStats := netstats["compressed"][sessid]
+	if recvStats.Size != stats.Size || recvStats.CompressedSize != stats.CompressedSize {
+		t.Errorf("recv$ size %d(%d) != %d(%d) send$ size", recvStats.Size, recvStats.CompressedSize, stats.Size, stats.CompressedSize)
+	}
+}
+
+func Test_CksumVerification(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var (
+		mu       sync.Mutex
+		received = make(map[string]error)
+	)
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		cmn.Assert(err == nil)
+		if strings.HasSuffix(hdr.Objname, "full") {
+			// io.ReadFull ignores the error returned together with the last bytes
+			buf := make([]byte, hdr.ObjAttrs.Size)
+			if _, err = io.ReadFull(objReader, buf); err == nil {
+				if _, err = objReader.Read(buf[:1]); err == io.EOF {
+					err = nil
+				}
+			}
+		} else {
+			_, err = ioutil.ReadAll(objReader)
+		}
+		mu.Lock()
+		received[hdr.Objname] = err
+		mu.Unlock()
+	}
+	path, err := transport.Register("n1", "cksum", recvFunc, true /*verifyCksum*/)
+	tutils.CheckFatal(err, t)
+
+	httpclient := &http.Client{Transport: &http.Transport{}}
+	url := ts.URL + path
+	for _, compression := range []string{transport.CompressionNone, transport.CompressionFlate} {
+		stream := transport.NewStream(httpclient, url, &transport.Extra{Compression: compression})
+		for _, name := range []string{"good", "bad", "none", "good-full", "bad-full"} {
+			object := bytes.Repeat([]byte(text), 100)
+			cksum, _ := cmn.ComputeXXHash(bytes.NewReader(object), nil)
+			hdr := transport.Header{Bucket: "a", Objname: compression + name, ObjAttrs: transport.ObjectAttrs{Size: int64(len(object))}}
+			switch name {
+			case "good", "good-full":
+				hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue = cmn.ChecksumXXHash, cksum
+			case "bad", "bad-full":
+				object[len(object)/2]++ // corrupt
+				hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue = cmn.ChecksumXXHash, cksum
+			}
+			if err := stream.Send(hdr, ioutil.NopCloser(bytes.NewReader(object)), nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		stream.Fin()
+
+		if len(received) != 5 {
+			t.Fatalf("invalid received count: %d, expected: %d", len(received), 5)
+		}
+		for _, name := range []string{"good", "none", "good-full"} {
+			if err := received[compression+name]; err != nil {
+				t.Errorf("%q %s: unexpected error: %v", compression, name, err)
+			}
+		}
+		for _, name := range []string{"bad", "bad-full"} {
+			if _, ok := received[compression+name].(cmn.InvalidCksumError); !ok {
+				t.Errorf("%q %s: expected checksum mismatch, got: %v", compression, name, received[compression+name])
+			}
+		}
+		received = make(map[string]error)
+	}
+}
+
+// Test_ReliableStream breaks the first two sessions of the reliable stream:
+// the first one with the receiver returning an error (and the ack), the second
+// one by aborting the connection (no ack at all). The receiver must get each
+// object exactly once and in order.
+func Test_ReliableStream(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+
+	var sessions int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt64(&sessions, 1) {
+		case 1:
+			r.Body = &brokenBody{r.Body, 300 * cmn.KiB}
+			mux.ServeHTTP(w, r)
+		case 2:
+			r.Body = &brokenBody{r.Body, 300 * cmn.KiB}
+			mux.ServeHTTP(httptest.NewRecorder(), r)
+			panic(http.ErrAbortHandler)
+		default:
+			mux.ServeHTTP(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		if err != nil {
+			return
+		}
+		b, err := ioutil.ReadAll(objReader)
+		if err != nil || int64(len(b)) != hdr.ObjAttrs.Size {
+			return // partially received, expecting it to be resent
+		}
+		mu.Lock()
+		received = append(received, hdr.Objname)
+		mu.Unlock()
+	}
+	path, err := transport.Register("n1", "reliable", recvFunc)
+	tutils.CheckFatal(err, t)
+
+	var (
+		sent, failed int64
+		num          = 100
+		expected     = make([]string, 0, num)
+		random       = newRand(time.Now().UnixNano())
+		httpclient   = &http.Client{Transport: &http.Transport{}}
+		stream       = transport.NewStream(httpclient, ts.URL+path, &transport.Extra{Reliable: true})
+	)
+	callback := func(hdr transport.Header, reader io.ReadCloser, err error) {
+		if err != nil {
+			atomic.AddInt64(&failed, 1)
+		}
+		atomic.AddInt64(&sent, 1)
+	}
+	if err := stream.Send(transport.Header{Objname: "x", ObjAttrs: transport.ObjectAttrs{Size: 1}}, ioutil.NopCloser(bytes.NewReader([]byte{1})), nil); err == nil {
+		t.Error("expected reliable stream to reject the reader that cannot be reopened")
+	}
+	for i := 0; i < num; i++ {
+		sgl := Mem2.NewSGL(0)
+		defer sgl.Free()
+		size := 10*cmn.KiB + random.Int63n(40*cmn.KiB)
+		sgl.Write(bytes.Repeat([]byte{byte(i)}, int(size)))
+		hdr := transport.Header{Bucket: "a", Objname: fmt.Sprintf("obj-%03d", i), ObjAttrs: transport.ObjectAttrs{Size: size}}
+		expected = append(expected, hdr.Objname)
+		if err := stream.Send(hdr, memsys.NewReader(sgl), callback); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stream.Fin()
+
+	if atomic.LoadInt64(&sessions) < 3 {
+		t.Fatalf("expected at least 3 sessions, got %d", sessions)
+	}
+	if sent != int64(num) || failed != 0 {
+		t.Errorf("send callbacks: %d (failed %d), expected: %d", sent, failed, num)
+	}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("received %d objects, expected %d - %v", len(received), num, received)
+	}
+}
+
+// Test_ReliableAck sends objects one at a time, waiting for each send completion
+// (as synchronous senders do): the completions must not wait for the idle timeout
+func Test_ReliableAck(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		io.Copy(ioutil.Discard, objReader)
+	}
+	path, err := transport.Register("n1", "reliable-ack", recvFunc)
+	tutils.CheckFatal(err, t)
+
+	var (
+		httpclient = &http.Client{Transport: &http.Transport{}}
+		extra      = &transport.Extra{Reliable: true, IdleTimeout: time.Minute}
+		stream     = transport.NewStream(httpclient, ts.URL+path, extra)
+		doneCh     = make(chan error, 1)
+	)
+	for i := 0; i < 3; i++ {
+		sgl := Mem2.NewSGL(0)
+		defer sgl.Free()
+		sgl.Write([]byte("0123456789"))
+		hdr := transport.Header{Bucket: "a", Objname: strconv.Itoa(i), ObjAttrs: transport.ObjectAttrs{Size: 10}}
+		cb := func(_ transport.Header, _ io.ReadCloser, err error) { doneCh <- err }
+		if err := stream.Send(hdr, memsys.NewReader(sgl), cb); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-doneCh:
+			tutils.CheckFatal(err, t)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("object %d has not been acknowledged", i)
+		}
+	}
+	stream.Fin()
+}
+
+func Test_RateLimit(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var received int64
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		cmn.Assert(err == nil)
+		n, _ := io.Copy(ioutil.Discard, objReader)
+		atomic.AddInt64(&received, n)
+	}
+	path, err := transport.Register("n1", "rate-limit", recvFunc)
+	tutils.CheckFatal(err, t)
+
+	const (
+		rate    = 4 * cmn.MiB
+		objSize = 256 * cmn.KiB
+		total   = 10 * cmn.MiB // => 2.5s
+	)
+	var (
+		httpclient = &http.Client{Transport: &http.Transport{}}
+		url        = ts.URL + path
+		object     = bytes.Repeat([]byte{'a'}, objSize)
+	)
+	send := func(streams ...*transport.Stream) time.Duration {
+		var wg sync.WaitGroup
+		started := time.Now()
+		for i := 0; i < total/objSize; i++ {
+			wg.Add(1)
+			hdr := transport.Header{Bucket: "a", Objname: strconv.Itoa(i), ObjAttrs: transport.ObjectAttrs{Size: objSize}}
+			cb := func(transport.Header, io.ReadCloser, error) { wg.Done() }
+			streams[i%len(streams)].Send(hdr, ioutil.NopCloser(bytes.NewReader(object)), cb)
+		}
+		wg.Wait()
+		return time.Since(started)
+	}
+
+	// per stream, and then changed at runtime
+	stream := transport.NewStream(httpclient, url, &transport.Extra{MaxBandwidth: rate})
+	if elapsed := send(stream); elapsed < 2*time.Second {
+		t.Errorf("sent %s in %v - expected at least %v with %s/s limit", cmn.B2S(total, 0), elapsed, 2*time.Second, cmn.B2S(rate, 0))
+	}
+	stream.SetMaxBandwidth(0)
+	if elapsed := send(stream); elapsed > 2*time.Second {
+		t.Errorf("sent %s in %v - expected no limit", cmn.B2S(total, 0), elapsed)
+	}
+	stream.Fin()
+
+	// shared by multiple streams
+	limiter := transport.NewRateLimiter(rate)
+	streams := make([]*transport.Stream, 4)
+	for i := range streams {
+		streams[i] = transport.NewStream(httpclient, url, &transport.Extra{Limiter: limiter})
+	}
+	if elapsed := send(streams...); elapsed < 2*time.Second {
+		t.Errorf("sent %s in %v - expected at least %v with %s/s shared limit", cmn.B2S(total, 0), elapsed, 2*time.Second, cmn.B2S(rate, 0))
+	}
+	for _, stream := range streams {
+		stream.Fin()
+	}
+	if received != 3*total {
+		t.Errorf("received %d, expected %d", received, 3*total)
+	}
+}
+
 //
 // test helpers
 //
 
+// brokenBody fails after reading the specified number of bytes
+type brokenBody struct {
+	io.ReadCloser
+	rem int64
+}
+
+func (b *brokenBody) Read(p []byte) (n int, err error) {
+	if b.rem <= 0 {
+		return 0, errors.New("broken body")
+	}
+	if int64(len(p)) > b.rem {
+		p = p[:b.rem]
+	}
+	n, err = b.ReadCloser.Read(p)
+	b.rem -= int64(n)
+	return
+}
+
 func streamWriteUntil(t *testing.T, ii int, wg *sync.WaitGroup, ts *httptest.Server, netstats map[string]transport.EndpointStats, lock *sync.Mutex) {
 	if wg != nil {
 		defer wg.Done()